@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines around changes in unified diffs.
+const diffContext = 3
+
+// checkOutputDir compares the generated files, keyed by their slash separated
+// path relative to the output directory, with the files of outputDir, as
+// --check does. A unified diff is written to w for every file that is stale,
+// missing from outputDir, or left over in outputDir while it would not be
+// generated anymore (the output directory is cleared before a generation).
+// Paths are shown under label, the output directory as given on the command
+// line. It reports whether outputDir is up to date.
+func checkOutputDir(generated map[string][]byte, outputDir, label string, w io.Writer) (bool, error) {
+	existing, err := readTree(outputDir)
+	if err != nil {
+		return false, err
+	}
+	files := make([]string, 0, len(generated)+len(existing))
+	for file := range generated {
+		files = append(files, file)
+	}
+	for file := range existing {
+		if _, found := generated[file]; !found {
+			files = append(files, file)
+		}
+	}
+	sort.Strings(files)
+
+	upToDate := true
+	for _, file := range files {
+		oldContents, oldFound := existing[file]
+		newContents, newFound := generated[file]
+		if oldFound && newFound && bytes.Equal(oldContents, newContents) {
+			continue
+		}
+		upToDate = false
+		oldName, newName := filepath.ToSlash(filepath.Join(label, file)), filepath.ToSlash(filepath.Join(label, file))
+		if !oldFound {
+			oldName = "/dev/null"
+		}
+		if !newFound {
+			newName = "/dev/null"
+		}
+		_, err = io.WriteString(w, unifiedDiff(oldName, newName, splitLines(oldContents), splitLines(newContents)))
+		if err != nil {
+			return false, err
+		}
+	}
+	return upToDate, nil
+}
+
+// readTree reads the regular files below dir, keyed by their slash separated
+// path relative to dir. A missing directory is read as an empty tree.
+func readTree(dir string) (map[string][]byte, error) {
+	tree := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if path == dir && os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		tree[filepath.ToSlash(rel)] = contents
+		return nil
+	})
+	return tree, err
+}
+
+// splitLines splits contents into lines, without their trailing newline.
+func splitLines(contents []byte) []string {
+	if len(contents) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(contents), "\n"), "\n")
+}
+
+// diffLine is a line of an edit script: op is ' ' for a line kept, '-' for a
+// line removed from a and '+' for a line added from b. aLine and bLine are the
+// indexes of the line in a and b, or of the next one for lines not in them.
+type diffLine struct {
+	op           byte
+	text         string
+	aLine, bLine int
+}
+
+// editScript returns the edit script turning a into b, along the longest
+// common subsequence of their lines. Common leading and trailing lines are
+// skipped before computing it, as generated files usually differ by a few
+// lines only.
+func editScript(a, b []string) []diffLine {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	// lcs[i][j] is the length of the longest common subsequence of midA[i:] and midB[j:]
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	script := make([]diffLine, 0, len(a)+len(b)-prefix-suffix)
+	for i := 0; i < prefix; i++ {
+		script = append(script, diffLine{op: ' ', text: a[i], aLine: i, bLine: i})
+	}
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			script = append(script, diffLine{op: ' ', text: midA[i], aLine: prefix + i, bLine: prefix + j})
+			i, j = i+1, j+1
+		case j < len(midB) && (i == len(midA) || lcs[i][j+1] > lcs[i+1][j]):
+			script = append(script, diffLine{op: '+', text: midB[j], aLine: prefix + i, bLine: prefix + j})
+			j++
+		default:
+			script = append(script, diffLine{op: '-', text: midA[i], aLine: prefix + i, bLine: prefix + j})
+			i++
+		}
+	}
+	for k := 0; k < suffix; k++ {
+		script = append(script, diffLine{
+			op: ' ', text: a[len(a)-suffix+k],
+			aLine: len(a) - suffix + k, bLine: len(b) - suffix + k,
+		})
+	}
+	return script
+}
+
+// unifiedDiff returns the unified diff turning the lines a of oldName into the
+// lines b of newName, or an empty string if they are equal.
+func unifiedDiff(oldName, newName string, a, b []string) string {
+	script := editScript(a, b)
+	var sb strings.Builder
+	for start := 0; start < len(script); {
+		// Find the next change
+		for start < len(script) && script[start].op == ' ' {
+			start++
+		}
+		if start == len(script) {
+			break
+		}
+		if sb.Len() == 0 {
+			fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+		}
+		// Extend the hunk over changes separated by at most twice the context
+		end := start
+		for end < len(script) {
+			if script[end].op != ' ' {
+				end++
+				continue
+			}
+			next := end
+			for next < len(script) && script[next].op == ' ' {
+				next++
+			}
+			if next == len(script) || next-end > 2*diffContext {
+				break
+			}
+			end = next
+		}
+		from, to := max(start-diffContext, 0), min(end+diffContext, len(script))
+		var aCount, bCount int
+		for _, line := range script[from:to] {
+			if line.op != '+' {
+				aCount++
+			}
+			if line.op != '-' {
+				bCount++
+			}
+		}
+		aStart, bStart := script[from].aLine, script[from].bLine
+		if aCount > 0 {
+			aStart++
+		}
+		if bCount > 0 {
+			bStart++
+		}
+		fmt.Fprintf(&sb, "@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount)
+		for _, line := range script[from:to] {
+			sb.WriteByte(line.op)
+			sb.WriteString(line.text)
+			sb.WriteByte('\n')
+		}
+		start = to
+	}
+	return sb.String()
+}
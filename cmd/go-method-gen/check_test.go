@@ -0,0 +1,71 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCheckExitCodes(t *testing.T) {
+	dir := writeModule(t, map[string]string{
+		"models.go": "package models\n\ntype Frontend struct {\n\tName string\n\tBinds []string\n}\n",
+	})
+	args := []string{"--static", "--output-dir=gen", "example.com/models.Frontend"}
+	check := append([]string{"--check"}, args...)
+
+	// Missing files
+	code, out := runMain(t, dir, check...)
+	if code != 1 || !strings.Contains(out, "+++ gen/example.com/models/frontend_equal_generated.go") {
+		t.Fatalf("--check of missing files exited with %d:\n%s", code, out)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "gen")); !os.IsNotExist(err) {
+		t.Fatalf("--check wrote the output directory: %v", err)
+	}
+
+	code, out = runMain(t, dir, args...)
+	if code != 0 {
+		t.Fatalf("generation exited with %d:\n%s", code, out)
+	}
+	code, out = runMain(t, dir, check...)
+	if code != 0 || out != "" {
+		t.Fatalf("--check of up to date files exited with %d:\n%s", code, out)
+	}
+
+	// Stale files
+	file := filepath.Join(dir, "gen", "example.com", "models", "frontend_equal_generated.go")
+	contents, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stale := strings.Replace(string(contents), "rec.Name == obj.Name", "true", 1)
+	if err := os.WriteFile(file, []byte(stale), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, out = runMain(t, dir, check...)
+	if code != 1 || !strings.Contains(out, "-\treturn true") {
+		t.Errorf("--check of stale files exited with %d:\n%s", code, out)
+	}
+	if got, _ := os.ReadFile(file); string(got) != stale {
+		t.Error("--check updated a stale file")
+	}
+	if err := os.WriteFile(file, contents, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Files left over
+	leftOver := filepath.Join(dir, "gen", "example.com", "models", "backend_equal_generated.go")
+	if err := os.WriteFile(leftOver, []byte("package models\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	code, out = runMain(t, dir, check...)
+	if code != 1 || !strings.Contains(out, "+++ /dev/null") {
+		t.Errorf("--check of a file left over exited with %d:\n%s", code, out)
+	}
+
+	// Errors
+	code, out = runMain(t, dir, "--check", "--static", "--output-dir=gen", "example.com/models.Missing")
+	if code != 1 || !strings.Contains(out, "Missing not found") {
+		t.Errorf("--check of a missing type exited with %d:\n%s", code, out)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+	yaml "gopkg.in/yaml.v3"
+)
+
+// configFileName is the name of the project configuration file, looked up
+// from the current directory up to the root of its module.
+const configFileName = "go-method-gen.yaml"
+
+// Config holds the settings of a run. They are read from the configuration
+// file, whose keys are named after the command line flags, and then layered
+// with the flags.
+type Config struct {
+	Types              []string  `yaml:"types"`                 // Fully-qualified types, exclusive with Scan
+	Scan               string    `yaml:"scan"`                  // Directory to scan for types, "/..." for its subtree
+	Include            []string  `yaml:"include"`               // Regular expressions selecting the scanned types
+	Exclude            []string  `yaml:"exclude"`               // Regular expressions excluding scanned types
+	Tags               []string  `yaml:"tags"`                  // Build tags
+	AllTypes           bool      `yaml:"all-types"`             // Scan every exported type
+	OutputDir          string    `yaml:"output-dir"`            // Output directory, exclusive with InPackage
+	InPackage          bool      `yaml:"in-package"`            // Write files beside the types
+	Static             bool      `yaml:"static"`                // Load types with go/types
+	TypedDiff          bool      `yaml:"typed-diff"`            // Generate typed Diff methods
+	Strict             bool      `yaml:"strict"`                // Fail if fields would be skipped
+	Report             string    `yaml:"report"`                // JSON report of the skipped fields
+	HeaderFile         string    `yaml:"header-file"`           // Header of the generated files
+	Overrides          Overrides `yaml:"overrides"`             // Overrides file or inline overrides
+	Replaces           []string  `yaml:"replaces"`              // "module:path" replaces of the temporary module
+	ReplaceGoMethodGen string    `yaml:"replace-go-method-gen"` // Local path of the go-method-gen module
+	Methods            []string  `yaml:"methods"`               // Methods to generate, all but mergepatch, applydiff, merge3, hash and compare by default, applydiff requires typed-diff
+	DiffNameTag        string    `yaml:"diff-name-tag"`         // Struct tag naming fields in diff keys
+}
+
+// Overrides are given either as the path of an overrides file or inline, with
+// the structure of an overrides file.
+type Overrides struct {
+	File   string // Path of the overrides file
+	Inline []byte // YAML of the inline overrides
+}
+
+// UnmarshalYAML decodes a path or a mapping of overrides.
+func (o *Overrides) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		return node.Decode(&o.File)
+	case yaml.MappingNode:
+		// Report invalid overrides against the configuration file
+		var overrides map[string]common.OverrideFuncs
+		err := node.Decode(&overrides)
+		if err != nil {
+			return err
+		}
+		o.Inline, err = yaml.Marshal(node)
+		return err
+	}
+	return fmt.Errorf("line %d: overrides must be a file path or a mapping", node.Line)
+}
+
+// findConfig returns the path of the configuration file of dir, looked up in
+// dir and its parents up to the root of their module, or "" if there is none.
+func findConfig(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, configFileName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+		// The configuration of a module does not apply to nested modules
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// loadConfig reads the configuration file at path. Relative paths are
+// resolved from the directory of the file, so that the configuration does not
+// depend on the directory go-method-gen is run from.
+func loadConfig(path string) (Config, error) {
+	var cfg Config
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(contents))
+	decoder.KnownFields(true)
+	err = decoder.Decode(&cfg)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return cfg, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if len(cfg.Types) > 0 && cfg.Scan != "" {
+		return cfg, fmt.Errorf("%s: types and scan cannot be used together", path)
+	}
+	if cfg.InPackage && cfg.OutputDir != "" {
+		return cfg, fmt.Errorf("%s: output-dir and in-package cannot be used together", path)
+	}
+	if len(cfg.Methods) > 0 {
+		cfg.Methods, err = eqdiff.ParseMethods(strings.Join(cfg.Methods, ","))
+		if err != nil {
+			return cfg, fmt.Errorf("%s: methods: %w", path, err)
+		}
+	}
+
+	dir := filepath.Dir(path)
+	resolve := func(p string) string {
+		if p == "" || filepath.IsAbs(p) {
+			return p
+		}
+		return filepath.Join(dir, p)
+	}
+	if scanDir, recursive := splitScanPattern(cfg.Scan); recursive {
+		cfg.Scan = filepath.Join(resolve(scanDir), "...")
+	} else {
+		cfg.Scan = resolve(cfg.Scan)
+	}
+	cfg.OutputDir = resolve(cfg.OutputDir)
+	cfg.Report = resolve(cfg.Report)
+	cfg.HeaderFile = resolve(cfg.HeaderFile)
+	cfg.Overrides.File = resolve(cfg.Overrides.File)
+	cfg.ReplaceGoMethodGen = resolve(cfg.ReplaceGoMethodGen)
+	for i, repl := range cfg.Replaces {
+		// Only local paths are resolved, not module versions
+		parts := strings.SplitN(repl, ":", 2)
+		if len(parts) == 2 && isLocalPath(strings.TrimSpace(parts[1])) {
+			cfg.Replaces[i] = parts[0] + ":" + resolve(strings.TrimSpace(parts[1]))
+		}
+	}
+	return cfg, nil
+}
+
+// isLocalPath reports whether the target of a replace directive is a local
+// path rather than a module.
+func isLocalPath(path string) bool {
+	return filepath.IsAbs(path) || path == "." || path == ".." ||
+		strings.HasPrefix(path, "./") || strings.HasPrefix(path, "../")
+}
+
+// layer overrides the settings of cfg with the ones of flags set on the
+// command line, whose names are set. Boolean flags override the
+// configuration as well when they are set to false, e.g. --typed-diff=false.
+// Types and scan, as well as output-dir and in-package, are alternatives: the
+// flags setting one of them replace both settings of the configuration, and
+// include and exclude flags replace both lists of the configuration.
+// Replaces are added to the ones of the configuration.
+func (cfg *Config) layer(flags Config, set map[string]bool) {
+	if len(flags.Types) > 0 || set["scan"] {
+		cfg.Types, cfg.Scan = flags.Types, flags.Scan
+	}
+	if set["output-dir"] || set["in-package"] {
+		cfg.OutputDir, cfg.InPackage = flags.OutputDir, flags.InPackage
+	}
+	if set["include"] || set["exclude"] {
+		cfg.Include, cfg.Exclude = flags.Include, flags.Exclude
+	}
+	if set["tags"] {
+		cfg.Tags = flags.Tags
+	}
+	if set["all-types"] {
+		cfg.AllTypes = flags.AllTypes
+	}
+	if set["static"] {
+		cfg.Static = flags.Static
+	}
+	if set["typed-diff"] {
+		cfg.TypedDiff = flags.TypedDiff
+	}
+	if set["strict"] {
+		cfg.Strict = flags.Strict
+	}
+	if set["report"] {
+		cfg.Report = flags.Report
+	}
+	if set["header-file"] {
+		cfg.HeaderFile = flags.HeaderFile
+	}
+	if set["overrides"] {
+		cfg.Overrides = flags.Overrides
+	}
+	cfg.Replaces = append(cfg.Replaces, flags.Replaces...)
+	if set["replace-go-method-gen"] {
+		cfg.ReplaceGoMethodGen = flags.ReplaceGoMethodGen
+	}
+	if set["methods"] {
+		cfg.Methods = flags.Methods
+	}
+	if set["diff-name-tag"] {
+		cfg.DiffNameTag = flags.DiffNameTag
+	}
+}
+
+// writeInlineOverrides writes inline overrides to a temporary file, as
+// eqdiff reads overrides from a file, and returns its path.
+func writeInlineOverrides(inline []byte) (string, error) {
+	file, err := os.CreateTemp("", "go-method-gen-overrides-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	_, err = file.Write(inline)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return file.Name(), err
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLayer(t *testing.T) {
+	base := Config{
+		Types:     []string{"example.com/models.Frontend"},
+		OutputDir: "/gen",
+		Include:   []string{"A"},
+		Exclude:   []string{"B"},
+		Tags:      []string{"integration"},
+		Static:    true,
+		TypedDiff: true,
+		Strict:    true,
+		Report:    "/report.json",
+		Replaces:  []string{"a:/a"},
+		Methods:   []string{"equal"},
+	}
+	tests := []struct {
+		name string
+		args []string
+		want func(cfg *Config)
+	}{
+		{
+			name: "no flag",
+			want: func(cfg *Config) {},
+		},
+		{
+			name: "booleans set to false",
+			args: []string{"--typed-diff=false", "--static=false"},
+			want: func(cfg *Config) { cfg.TypedDiff, cfg.Static = false, false },
+		},
+		{
+			name: "scan replacing types",
+			args: []string{"--scan=./models/..."},
+			want: func(cfg *Config) { cfg.Types, cfg.Scan = nil, "./models/..." },
+		},
+		{
+			name: "in-package replacing output-dir",
+			args: []string{"--in-package"},
+			want: func(cfg *Config) { cfg.OutputDir, cfg.InPackage = "", true },
+		},
+		{
+			name: "exclude replacing include and exclude",
+			args: []string{"--exclude=C"},
+			want: func(cfg *Config) { cfg.Include, cfg.Exclude = nil, []string{"C"} },
+		},
+		{
+			name: "tags cleared",
+			args: []string{"--tags="},
+			want: func(cfg *Config) { cfg.Tags = nil },
+		},
+		{
+			name: "replaces added",
+			args: []string{"--replace=b:/b", "--methods=diff", "--report=other.json"},
+			want: func(cfg *Config) {
+				cfg.Replaces, cfg.Methods, cfg.Report = []string{"a:/a", "b:/b"}, []string{"diff"}, "other.json"
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			flags, run, err := parseFlags(test.args, io.Discard)
+			if err != nil {
+				t.Fatal(err)
+			}
+			cfg := base
+			cfg.Replaces = slicesClone(base.Replaces)
+			cfg.layer(flags, run.Set)
+			want := base
+			want.Replaces = slicesClone(base.Replaces)
+			test.want(&want)
+			if !reflect.DeepEqual(cfg, want) {
+				t.Errorf("layer() = %+v, want %+v", cfg, want)
+			}
+		})
+	}
+}
+
+// slicesClone returns a copy of s, so that appending to it leaves s as is.
+func slicesClone(s []string) []string {
+	return append([]string(nil), s...)
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, configFileName)
+	contents := `scan: ./models/...
+output-dir: gen
+typed-diff: true
+replaces:
+  - example.com/dep:../dep
+  - example.com/other:example.com/other@v1.0.0
+overrides:
+  example.com/models.Backend.Servers:
+    key: Name
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Relative paths are resolved from the directory of the file
+	if want := filepath.Join(dir, "models", "..."); cfg.Scan != want {
+		t.Errorf("scan = %s, want %s", cfg.Scan, want)
+	}
+	if want := filepath.Join(dir, "gen"); cfg.OutputDir != want {
+		t.Errorf("output-dir = %s, want %s", cfg.OutputDir, want)
+	}
+	wantReplaces := []string{"example.com/dep:" + filepath.Join(filepath.Dir(dir), "dep"), "example.com/other:example.com/other@v1.0.0"}
+	if !reflect.DeepEqual(cfg.Replaces, wantReplaces) {
+		t.Errorf("replaces = %v, want %v", cfg.Replaces, wantReplaces)
+	}
+	if !cfg.TypedDiff || !strings.Contains(string(cfg.Overrides.Inline), "key: Name") {
+		t.Errorf("loadConfig() = %+v", cfg)
+	}
+
+	for _, invalid := range []string{
+		"unknown: true\n",
+		"types: [example.com/models.Frontend]\nscan: ./models\n",
+		"in-package: true\noutput-dir: gen\n",
+		"methods: [unknown]\n",
+		"overrides: [overrides.yaml]\n",
+	} {
+		if err := os.WriteFile(path, []byte(invalid), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadConfig(path); err == nil {
+			t.Errorf("loadConfig(%q): expected an error", invalid)
+		}
+	}
+}
+
+func TestFindConfig(t *testing.T) {
+	root := writeModule(t, map[string]string{configFileName: "typed-diff: true\n", "models/models.go": "package models\n"})
+	path, err := findConfig(filepath.Join(root, "models"))
+	if err != nil || path != filepath.Join(root, configFileName) {
+		t.Errorf("findConfig() = %q, %v, want %q", path, err, filepath.Join(root, configFileName))
+	}
+	// The configuration of a module does not apply to nested modules
+	nested := filepath.Join(root, "nested")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(nested, "go.mod"), []byte("module example.com/nested\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if path, err := findConfig(nested); err != nil || path != "" {
+		t.Errorf("findConfig() of a nested module = %q, %v", path, err)
+	}
+}
+
+func TestInlineOverridesRemoved(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("TMPDIR", tmpDir)
+	dir := writeModule(t, map[string]string{
+		configFileName: "static: true\noverrides:\n  example.com/models.Frontend.Name:\n    ignore: true\n",
+		"models.go":    "package models\n\ntype Frontend struct {\n\tName string\n}\n",
+	})
+	// Successful runs and runs exiting on errors remove the overrides file
+	for _, typ := range []string{"example.com/models.Frontend", "example.com/models.Missing"} {
+		code, out := runMain(t, dir, "--output-dir=gen", typ)
+		if (code != 0) != strings.HasSuffix(typ, "Missing") {
+			t.Errorf("%s: exited with %d:\n%s", typ, code, out)
+		}
+		entries, err := os.ReadDir(tmpDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			if !strings.HasPrefix(entry.Name(), "go-method-gen-overrides-") {
+				continue
+			}
+			t.Errorf("%s: %s left in the temporary directory", typ, entry.Name())
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+// runFlags are the command line flags of a run which are not settings of the
+// configuration file.
+type runFlags struct {
+	ConfigPath string          // Configuration file, found from the current directory if empty
+	KeepTemp   bool            // Keep the temporary module
+	Debug      bool            // Verbose output
+	Check      bool            // Check the output directory instead of writing to it
+	Set        map[string]bool // Names of the flags given on the command line
+}
+
+// listFlag is the value of a flag which can be repeated, each value being
+// appended to the list.
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// nonEmpty returns the function setting *p to the value of a flag which
+// cannot be empty.
+func nonEmpty(p *string) func(string) error {
+	return func(value string) error {
+		if value == "" {
+			return errors.New("value cannot be empty")
+		}
+		*p = value
+		return nil
+	}
+}
+
+// parseFlags parses the command line arguments args into the settings they
+// set, to be layered on the configuration file, and the flags of the run.
+// Positional arguments are types, and can be given before flags. Errors are
+// reported to output with the usage, as is the usage with -help, for which
+// flag.ErrHelp is returned.
+func parseFlags(args []string, output io.Writer) (Config, runFlags, error) {
+	var settings Config
+	var run runFlags
+	fs := flag.NewFlagSet("go-method-gen", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), "Usage:\n"+
+			"  go-method-gen [flags] importpath.TypeName[@version] [...]\n"+
+			"  go-method-gen [flags] --scan=DIR[/...]\n\nFlags:\n")
+		fs.PrintDefaults()
+	}
+
+	fs.Func("scan", "scan `DIR` for types, or the packages of its subtree with DIR/...", nonEmpty(&settings.Scan))
+	fs.Var((*listFlag)(&settings.Include), "include", "only scan types whose importpath.TypeName matches `REGEX` (repeatable)")
+	fs.Var((*listFlag)(&settings.Exclude), "exclude", "do not scan types whose importpath.TypeName matches `REGEX` (repeatable)")
+	fs.Func("tags", "comma separated build `TAGS` selecting the files of the packages", func(value string) error {
+		settings.Tags = splitTags(value)
+		return nil
+	})
+	fs.BoolVar(&settings.AllTypes, "all-types", false, "scan every exported type, including the ones used by other types")
+	fs.Func("output-dir", "write generated code to `DIR` (default ./generated)", nonEmpty(&settings.OutputDir))
+	fs.BoolVar(&settings.InPackage, "in-package", false, "write generated files beside the types")
+	fs.BoolVar(&settings.Static, "static", false, "load types with go/types and generate in process")
+	fs.BoolVar(&settings.TypedDiff, "typed-diff", false, "generate Diff methods returning []eqdiff.Change")
+	fs.BoolVar(&settings.Strict, "strict", false, "fail, without generating anything, if fields would be skipped")
+	fs.Func("report", "write the report of skipped fields to `FILE` as JSON", nonEmpty(&settings.Report))
+	fs.StringVar(&settings.HeaderFile, "header-file", "", "prepend the Go file `PATH` as header of generated files")
+	fs.StringVar(&settings.Overrides.File, "overrides", "", "YAML `FILE` overriding the generation of specific fields")
+	fs.Var((*listFlag)(&settings.Replaces), "replace", "add the `MOD:LOCALPATH` replace directive to the temporary module (repeatable)")
+	fs.StringVar(&settings.ReplaceGoMethodGen, "replace-go-method-gen", "", "use the go-method-gen module of `DIR`")
+	fs.Func("methods", "comma separated `LIST` of methods to generate", func(value string) (err error) {
+		settings.Methods, err = eqdiff.ParseMethods(value)
+		return err
+	})
+	fs.Func("diff-name-tag", "name fields in diff keys after the struct `TAG`", nonEmpty(&settings.DiffNameTag))
+	fs.Func("config", "configuration `FILE` to use instead of the discovered "+configFileName, nonEmpty(&run.ConfigPath))
+	fs.BoolVar(&run.KeepTemp, "keep-temp", false, "keep the temporary module (.go-method-gen-tmp)")
+	fs.BoolVar(&run.Debug, "debug", false, "enable verbose output")
+	fs.BoolVar(&run.Check, "check", false, "check that the output directory is up to date instead of writing to it")
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			return settings, run, err
+		}
+		rest := fs.Args()
+		// Arguments following "--" are all types
+		if len(rest) < len(args) && args[len(args)-len(rest)-1] == "--" {
+			settings.Types = append(settings.Types, rest...)
+			break
+		}
+		if len(rest) == 0 {
+			break
+		}
+		settings.Types = append(settings.Types, rest[0])
+		args = rest[1:]
+	}
+
+	run.Set = map[string]bool{}
+	fs.Visit(func(f *flag.Flag) {
+		run.Set[f.Name] = true
+	})
+	return settings, run, nil
+}
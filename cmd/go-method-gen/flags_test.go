@@ -0,0 +1,53 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+func TestParseFlags(t *testing.T) {
+	settings, run, err := parseFlags([]string{
+		"example.com/models.Frontend", "--typed-diff=false", "--include=A", "-include", "B",
+		"example.com/models.Backend", "--methods=equal,diff", "--check", "--", "--not-a-flag",
+	}, io.Discard)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Config{
+		Types:   []string{"example.com/models.Frontend", "example.com/models.Backend", "--not-a-flag"},
+		Include: []string{"A", "B"},
+		Methods: []string{eqdiff.MethodEqual, eqdiff.MethodDiff},
+	}
+	if !reflect.DeepEqual(settings, want) {
+		t.Errorf("parseFlags() settings = %+v, want %+v", settings, want)
+	}
+	if !run.Check || run.Debug {
+		t.Errorf("parseFlags() run = %+v", run)
+	}
+	wantSet := map[string]bool{"typed-diff": true, "include": true, "methods": true, "check": true}
+	if !reflect.DeepEqual(run.Set, wantSet) {
+		t.Errorf("parseFlags() set = %v, want %v", run.Set, wantSet)
+	}
+}
+
+func TestParseFlagsErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"--unknown"},
+		{"--scan="},
+		{"--output-dir="},
+		{"--methods=equal,unknown"},
+		{"--typed-diff=maybe"},
+	} {
+		if _, _, err := parseFlags(args, io.Discard); err == nil {
+			t.Errorf("parseFlags(%q): expected an error", args)
+		}
+	}
+	if _, _, err := parseFlags([]string{"-h"}, io.Discard); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("parseFlags(-h) = %v, want %v", err, flag.ErrHelp)
+	}
+}
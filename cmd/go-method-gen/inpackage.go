@@ -0,0 +1,100 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+// With --in-package, files generated by a previous run live beside the types.
+// Their methods would be taken for custom ones and not generated again, so
+// these files are left out when loading the types: their declarations are
+// dropped with --static, and they are replaced by an empty file of their
+// package with the overlay of the temporary module otherwise.
+
+// isMethodGenFile reports whether src is the source of a file written by
+// go-method-gen.
+func isMethodGenFile(src []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(src), []byte(eqdiff.GeneratedComment))
+}
+
+// parseFileWithoutGenerated parses Go files for packages.Load, keeping only
+// the package clause of the files written by go-method-gen.
+func parseFileWithoutGenerated(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
+	mode := parser.AllErrors | parser.ParseComments
+	if isMethodGenFile(src) {
+		mode = parser.PackageClauseOnly
+	}
+	return parser.ParseFile(fset, filename, src, mode)
+}
+
+// overlay is the JSON file given to 'go build -overlay'.
+type overlay struct {
+	Replace map[string]string
+}
+
+// writeGeneratedOverlay writes in tmpDir an overlay replacing every file
+// written by go-method-gen below root with a file holding only its package
+// clause, and returns the path of the overlay, or "" if there is no such file.
+func writeGeneratedOverlay(tmpDir, root string, debug bool) (string, error) {
+	stubsDir := filepath.Join(tmpDir, "overlay")
+	replace := map[string]string{}
+	err := filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			// Skip hidden directories, such as .git or the temporary module
+			if path != root && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, "_generated.go") {
+			return nil
+		}
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !isMethodGenFile(src) {
+			return nil
+		}
+		f, err := parser.ParseFile(token.NewFileSet(), path, src, parser.PackageClauseOnly)
+		if err != nil {
+			return err
+		}
+		err = os.MkdirAll(stubsDir, 0o755)
+		if err != nil {
+			return err
+		}
+		stub := filepath.Join(stubsDir, fmt.Sprintf("stub%d.go", len(replace)))
+		err = os.WriteFile(stub, []byte("package "+f.Name.Name+"\n"), 0o644)
+		if err != nil {
+			return err
+		}
+		replace[path] = stub
+		if debug {
+			fmt.Printf("• Leaving out generated file %s\n", path)
+		}
+		return nil
+	})
+	if err != nil || len(replace) == 0 {
+		return "", err
+	}
+	contents, err := json.Marshal(overlay{Replace: replace})
+	if err != nil {
+		return "", err
+	}
+	overlayPath := filepath.Join(tmpDir, "overlay.json")
+	return overlayPath, os.WriteFile(overlayPath, contents, 0o644)
+}
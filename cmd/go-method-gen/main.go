@@ -2,6 +2,8 @@ package main
 
 import (
 	"bytes"
+	"errors"
+	"flag"
 	"fmt"
 	"go/ast"
 	"go/parser"
@@ -11,10 +13,13 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"text/template"
 
 	"github.com/haproxytech/go-method-gen/internal/utils"
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
 	"golang.org/x/tools/go/packages"
 )
 
@@ -59,15 +64,32 @@ func main() {
 		{{end}}{{end}}
 	}
 
+	var report eqdiff.Report
 	err = eqdiff.Generate(types, eqdiff.Options{
 		OutputDir: {{printf "%q" .OutputDir}},
 		OverridesFile: {{printf "%q" .OverridesPath}},
 		HeaderPath: {{printf "%q" .HeaderPath}},
+		TypedDiff: {{.TypedDiff}},
+		InPackage: {{.InPackage}},
+		PackagesDir: {{printf "%q" .PackagesDir}},
+		Strict: {{.Strict}},
+		Report: &report,
+		Methods: {{printf "%#v" .Methods}},
+		DiffNameTag: {{printf "%q" .DiffNameTag}},
 	})
+	{{if .ReportPath}}
+	if errReport := eqdiff.WriteReport({{printf "%q" .ReportPath}}, report); errReport != nil {
+		fmt.Println("Failed to write report:", errReport)
+		os.Exit(1)
+	}
+	{{end}}
 	if err != nil {
 		fmt.Println("Generation error:", err)
 		os.Exit(1)
 	}
+	if summary := report.Summary(); summary != "" {
+		fmt.Fprint(os.Stderr, "Warning: ", summary)
+	}
 }
 `
 
@@ -81,6 +103,13 @@ type TemplateData struct {
 	OutputDir     string
 	OverridesPath string
 	HeaderPath    string
+	TypedDiff     bool
+	InPackage     bool
+	PackagesDir   string
+	Strict        bool
+	ReportPath    string
+	Methods       []string
+	DiffNameTag   string
 	// Cwd is injected into the generated main and used for os.Chdir.
 	Cwd string
 }
@@ -99,119 +128,127 @@ type TypeSpec struct {
 	AliasTypeVar string // variable name used in the template for alias types
 }
 
-// main parses CLI flags, resolves inputs (types or scan), prepares a temp
-// workspace (go.mod, go get, generated main), and runs `go run .` to launch
-// eqdiff in the ephemeral module.
+// main parses CLI flags and the configuration file, resolves inputs (types
+// or scan), prepares a temp workspace (go.mod, go get, generated main), and
+// runs `go run .` to launch eqdiff in the ephemeral module.
 func main() {
-	// Defaults & holders for parsed flags.
-	outputDir := "./generated"
-	var typeArgs []string
-	var keepTemp, debug bool
-	var replaceGoMethodGenPath, overridesPath, headerPath string
-	var extraReplaces []string
-	var seenOutputDir, seenKeepTemp, seenDebug,
-		seenHeader, seenReplace, seenOverrides bool
-	var scanPath string
-	var seenScan bool
+	defer runCleanups()
 	// --- Argument parsing ---
 	// We accept either explicit types as CLI args or a --scan=<path> to discover them.
-	for _, arg := range os.Args[1:] {
-		switch {
-		case strings.HasPrefix(arg, "--scan="):
-			if seenScan {
-				exit("Error: --scan specified more than once")
-			}
-			scanPath = strings.TrimPrefix(arg, "--scan=")
-			seenScan = true
-		case strings.HasPrefix(arg, "--output-dir="):
-			if seenOutputDir {
-				exit("Error: --output-dir specified more than once")
-			}
-			outputDir = strings.TrimPrefix(arg, "--output-dir=")
-			if outputDir == "" {
-				exit("Error: --output-dir value cannot be empty")
-			}
-			seenOutputDir = true
-
-		case arg == "--keep-temp":
-			if seenKeepTemp {
-				exit("Error: --keep-temp specified more than once")
-			}
-			keepTemp = true
-			seenKeepTemp = true
+	// Settings of the flags are layered on top of the configuration file.
+	flags, run, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		// The error is reported with the usage by parseFlags
+		os.Exit(2)
+	}
+	keepTemp, debug, checkMode, configPath := run.KeepTemp, run.Debug, run.Check, run.ConfigPath
 
-		case arg == "--debug":
-			if seenDebug {
-				exit("Error: --debug specified more than once")
-			}
-			debug = true
-			seenDebug = true
+	if len(flags.Types) > 0 && run.Set["scan"] {
+		exit("Error: you cannot provide types as arguments and use --scan=<path> at the same time")
+	}
 
-		case strings.HasPrefix(arg, "--replace-go-method-gen="):
-			if seenReplace {
-				exit("Error: --replace-go-method-gen specified more than once")
-			}
-			replaceGoMethodGenPath = strings.TrimPrefix(arg, "--replace-go-method-gen=")
-			seenReplace = true
-		case strings.HasPrefix(arg, "--overrides="):
-			if seenOverrides {
-				exit("Error: --overrides specified more than once")
-			}
-			overridesPath = strings.TrimPrefix(arg, "--overrides=")
-			seenOverrides = true
-		case strings.HasPrefix(arg, "--replace="):
-			extraReplaces = append(extraReplaces, strings.TrimPrefix(arg, "--replace="))
-		case strings.HasPrefix(arg, "--header-file="):
-			if seenHeader {
-				exit("Error: --header-file specified more than once")
-			}
-			headerPath = strings.TrimPrefix(arg, "--header-file=")
-			seenHeader = true
-		case strings.HasPrefix(arg, "--"):
-			exit(fmt.Sprintf("Error: unknown option: %s", arg))
+	if flags.InPackage && (run.Set["output-dir"] || checkMode) {
+		exit("Error: --output-dir and --check cannot be used with --in-package")
+	}
 
-		default:
-			// Positional args are treated as fully-qualified type identifiers:
-			// "<import.path>.Type[@version]"
-			typeArgs = append(typeArgs, arg)
-		}
+	// --- Load the configuration file, explicit or found from the current directory ---
+	if configPath == "" {
+		configPath, err = findConfig(cwd())
+		check(err)
+	}
+	var cfg Config
+	if configPath != "" {
+		cfg, err = loadConfig(configPath)
+		check(err)
 	}
+	cfg.layer(flags, run.Set)
 
-	if len(typeArgs) > 0 && seenScan {
-		exit("Error: you cannot provide types as arguments and use --scan=<path> at the same time")
+	typeArgs := cfg.Types
+	scanPath := cfg.Scan
+	outputDir := cfg.OutputDir
+	if outputDir == "" {
+		outputDir = "./generated"
 	}
+	typedDiff, static, inPackage, strict := cfg.TypedDiff, cfg.Static, cfg.InPackage, cfg.Strict
+	overridesPath, headerPath, reportPath := cfg.Overrides.File, cfg.HeaderFile, cfg.Report
+	methods, tags, diffNameTag := cfg.Methods, cfg.Tags, cfg.DiffNameTag
+	filter, err := newScanFilter(tags, cfg.Include, cfg.Exclude)
+	check(err)
+	filter.All = cfg.AllTypes
+	filter.Static = static
+	replaceGoMethodGenPath, extraReplaces := cfg.ReplaceGoMethodGen, cfg.Replaces
 
-	if len(typeArgs) == 0 && !seenScan {
+	if len(typeArgs) == 0 && scanPath == "" {
 		exit("Error: you must provide either types as arguments or use --scan=<path>")
 	}
 
+	// Replaces of the configuration file only apply to the temporary module
+	if static && (keepTemp || run.Set["replace-go-method-gen"] || len(flags.Replaces) > 0) {
+		exit("Error: --keep-temp, --replace-go-method-gen and --replace cannot be used with --static")
+	}
+
+	if inPackage && checkMode {
+		exit("Error: --check cannot be used with --in-package")
+	}
+
+	if slices.Contains(methods, eqdiff.MethodApplyDiff) && !typedDiff {
+		exit("Error: --methods=applydiff requires --typed-diff")
+	}
+
+	// Inline overrides of the configuration file are handed to eqdiff as a file
+	if overridesPath == "" && cfg.Overrides.Inline != nil {
+		overridesPath, err = writeInlineOverrides(cfg.Overrides.Inline)
+		check(err)
+		inlineOverridesPath := overridesPath
+		onExit(func() {
+			os.Remove(inlineOverridesPath)
+		})
+	}
+
 	// --- Debug dump of parsed args ---
 	if debug {
 		fmt.Println("▶️ Debug mode ON")
 		fmt.Println("\u2022 Parsed args:")
+		fmt.Printf("  - config: %s\n", configPath)
 		fmt.Printf("  - outputDir: %s\n", outputDir)
 		fmt.Printf("  - keepTemp: %v\n", keepTemp)
 		fmt.Printf("  - typeArgs: %v\n", typeArgs)
+		fmt.Printf("  - scan: %s\n", scanPath)
 		fmt.Printf("  - replaceEqdiffPath: %s\n", replaceGoMethodGenPath)
 		fmt.Printf("  - overridesPath: %s\n", overridesPath)
 		fmt.Printf("  - extraReplaces: %v\n", extraReplaces)
+		fmt.Printf("  - typedDiff: %v\n", typedDiff)
+		fmt.Printf("  - static: %v\n", static)
+		fmt.Printf("  - check: %v\n", checkMode)
+		fmt.Printf("  - inPackage: %v\n", inPackage)
+		fmt.Printf("  - strict: %v\n", strict)
+		fmt.Printf("  - report: %s\n", reportPath)
+		fmt.Printf("  - methods: %v\n", methods)
+		fmt.Printf("  - diffNameTag: %s\n", diffNameTag)
+		fmt.Printf("  - tags: %v\n", tags)
+		fmt.Printf("  - allTypes: %v\n", cfg.AllTypes)
+		fmt.Printf("  - include: %v\n", cfg.Include)
+		fmt.Printf("  - exclude: %v\n", cfg.Exclude)
 	}
 	// --- Resolve module context for --scan (or fall back to current module) ---
-	var moduleName, absScanPath, modRoot, relPath string
+	var moduleName, absScanPath, modRoot string
 	// if scan option is used we modify working directory  ...
-	if seenScan {
+	if scanPath != "" {
+		// "./models/..." scans the packages of the subtree rooted at ./models
+		scanDir, _ := splitScanPattern(scanPath)
 		cmd := exec.Command("go", "list", "-m")
-		cmd.Dir = scanPath
+		cmd.Dir = scanDir
 		out, err := cmd.Output()
 		check(err)
 		moduleName = strings.TrimSpace(string(out))
-		// Compute the relative import path within the module root.
-		absScanPath, err = filepath.Abs(scanPath)
+		// Import paths are computed relative to the module root.
+		absScanPath, err = filepath.Abs(scanDir)
 		check(err)
 		modRoot, err = findModuleRoot(absScanPath)
 		check(err)
-		relPath, err = filepath.Rel(modRoot, absScanPath)
-		check(err)
 		// Add a replace so the ephemeral module can import the local scanning module.
 		extraReplaces = append(extraReplaces, moduleName+":"+modRoot)
 	} else {
@@ -234,8 +271,81 @@ func main() {
 	importSet := make(map[string]bool)
 
 	// Prepare output directory now; generator will write files there.
-	absOutputDir := filepath.Join(cwd(), outputDir)
-	clearOutputDir(absOutputDir, debug)
+	// With --check, files are generated in memory with --static, or in a
+	// scratch directory otherwise, and compared with the output directory,
+	// which is left untouched. With --in-package, files are written beside
+	// the types and no output directory is used.
+	absOutputDir := outputDir
+	if !filepath.IsAbs(outputDir) {
+		absOutputDir = filepath.Join(cwd(), outputDir)
+	}
+	genDir := absOutputDir
+	// Packages are resolved from the scanned module, or from the current one
+	loadDir := cwd()
+	if scanPath != "" {
+		loadDir = absScanPath
+	}
+	switch {
+	case inPackage:
+		genDir = ""
+	case checkMode && static:
+	case checkMode:
+		scratchDir, err := os.MkdirTemp("", "go-method-gen-check-")
+		check(err)
+		onExit(func() {
+			os.RemoveAll(scratchDir)
+		})
+		genDir = scratchDir
+	default:
+		clearOutputDir(absOutputDir, debug)
+	}
+
+	// --- With --static, generate in process from go/types, without temp workspace ---
+	if static {
+		if scanPath != "" {
+			pkgs, err := scanPackages(scanPath, moduleName, modRoot, filter)
+			check(err)
+			for _, pkg := range pkgs {
+				for _, typeName := range pkg.TypeNames {
+					typeArgs = append(typeArgs, pkg.ImportPath+"."+typeName)
+				}
+			}
+		}
+		var report eqdiff.Report
+		files, err := runStatic(loadDir, typeArgs, tags, eqdiff.Options{
+			OutputDir:     genDir,
+			OverridesFile: overridesPath,
+			HeaderPath:    headerPath,
+			TypedDiff:     typedDiff,
+			InPackage:     inPackage,
+			PackagesDir:   loadDir,
+			Strict:        strict,
+			Report:        &report,
+			Methods:       methods,
+			DiffNameTag:   diffNameTag,
+		}, debug)
+		if reportPath != "" {
+			check(eqdiff.WriteReport(reportPath, report))
+		}
+		check(err)
+		if summary := report.Summary(); summary != "" {
+			fmt.Fprint(os.Stderr, "Warning: ", summary)
+		}
+		if !checkMode {
+			check(eqdiff.WriteFiles(files))
+			return
+		}
+		generated := map[string][]byte{}
+		for _, file := range files {
+			rel, err := filepath.Rel(absOutputDir, file.Path)
+			check(err)
+			generated[filepath.ToSlash(rel)] = file.Source
+		}
+		if !checkOutput(generated, absOutputDir, outputDir) {
+			exitStale(outputDir)
+		}
+		return
+	}
 
 	if debug {
 		fmt.Println("• Final import alias map:")
@@ -254,11 +364,13 @@ func main() {
 
 	// --- Create and (optionally) keep the temp workspace ---
 	tmpDir := filepath.Join(cwd(), ".go-method-gen-tmp")
-	err := os.MkdirAll(tmpDir, 0o755)
+	err = os.MkdirAll(tmpDir, 0o755)
 	check(err)
 
 	if !keepTemp {
-		defer os.RemoveAll(tmpDir)
+		onExit(func() {
+			os.RemoveAll(tmpDir)
+		})
 	} else {
 		fmt.Println("Temporary files kept at:", tmpDir)
 	}
@@ -289,32 +401,20 @@ func main() {
 		}
 	}
 	// --- If --scan was requested, discover types in the package and add them ---
-	if seenScan {
-		importsScan, specs, err := scanTypes(scanPath, moduleName, relPath)
+	if scanPath != "" {
+		pkgs, err := scanPackages(scanPath, moduleName, modRoot, filter)
 		check(err)
+		importsScan, specs := scanTypes(pkgs)
 		// Track all discovered imports and reuse them for go get.
-		for _, imp := range importsScan {
-			importSet[imp] = true
+		importsWithVersion = nil
+		for _, pkg := range pkgs {
+			importSet[pkg.ImportPath] = true
+			importsWithVersion = append(importsWithVersion, pkg.ImportPath)
+			imports[pkg.ImportPath] = importsScan[pkg.ImportPath]
 		}
-
-		importsWithVersion = importsScan
-		// Add discovered types and imports (ensuring aliases are set).
+		// Add discovered types.
 		for _, spec := range specs {
 			typeSpecs[spec.FullName] = spec
-			if !importSet[spec.Package] {
-				importSet[spec.Package] = true
-				pkg := spec.Package
-				if spec.Version != "" {
-					pkg += "@" + spec.Version
-				}
-				importsWithVersion = append(importsWithVersion, pkg)
-				imports[spec.Package] = spec.PackageAlias
-			}
-		}
-
-		// For all scanned imports, ensure alias map is filled even if not already set.
-		for _, imp := range importsScan {
-			imports[imp] = utils.AliasImport(filepath.Base(imp))
 		}
 
 		if debug {
@@ -323,7 +423,8 @@ func main() {
 				fmt.Printf("  - %+v\n", t)
 			}
 			fmt.Println("• From imports:")
-			for _, imp := range importsScan {
+			for _, pkg := range pkgs {
+				imp := pkg.ImportPath
 				a := imports[imp]
 				if a != "" {
 					fmt.Printf("  - %s (alias %s)\n", imp, a)
@@ -334,12 +435,25 @@ func main() {
 		}
 	}
 	// --- Render the generated main.go into tmpDir ---
+	// The generator runs from tmpDir, hand it an absolute report path
+	absReportPath := reportPath
+	if reportPath != "" {
+		absReportPath, err = filepath.Abs(reportPath)
+		check(err)
+	}
 	data := TemplateData{
 		Imports:       imports,
 		TypeSpecs:     typeSpecs,
-		OutputDir:     absOutputDir,
+		OutputDir:     genDir,
 		OverridesPath: overridesPath,
 		HeaderPath:    headerPath,
+		TypedDiff:     typedDiff,
+		InPackage:     inPackage,
+		PackagesDir:   loadDir,
+		Strict:        strict,
+		ReportPath:    absReportPath,
+		Methods:       methods,
+		DiffNameTag:   diffNameTag,
 		Cwd:           cwd(),
 	}
 	generateMainGo(tmpDir, data, debug)
@@ -347,7 +461,20 @@ func main() {
 	addGoGetDeps(tmpDir, importsWithVersion, debug)
 
 	// --- Run the ephemeral generator (go run . in tmpDir) ---
-	cmd := exec.Command("go", "run", ".")
+	runArgs := []string{"run"}
+	if len(tags) > 0 {
+		runArgs = append(runArgs, "-tags="+strings.Join(tags, ","))
+	}
+	if inPackage {
+		root, err := findModuleRoot(loadDir)
+		check(err)
+		overlayPath, err := writeGeneratedOverlay(tmpDir, root, debug)
+		check(err)
+		if overlayPath != "" {
+			runArgs = append(runArgs, "-overlay="+overlayPath)
+		}
+	}
+	cmd := exec.Command("go", append(runArgs, ".")...)
 	cmd.Dir = tmpDir
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -355,6 +482,28 @@ func main() {
 		fmt.Printf("\u2022 Executing: go run . (cwd = %s)\n", tmpDir)
 	}
 	check(cmd.Run())
+	if checkMode {
+		generated, err := readTree(genDir)
+		check(err)
+		if !checkOutput(generated, absOutputDir, outputDir) {
+			exitStale(outputDir)
+		}
+	}
+}
+
+// checkOutput compares the generated files (relative path -> contents) with
+// those of absOutputDir, printing a unified diff of the differences to
+// stdout, and reports whether the output directory is up to date.
+func checkOutput(generated map[string][]byte, absOutputDir, outputDir string) bool {
+	upToDate, err := checkOutputDir(generated, absOutputDir, outputDir, os.Stdout)
+	check(err)
+	return upToDate
+}
+
+// exitStale exits with status code 1, reporting that the files of outputDir
+// are out of date.
+func exitStale(outputDir string) {
+	exit(fmt.Sprintf("Error: generated files in %s are out of date, run go-method-gen without --check to update them", outputDir))
 }
 
 // cwd returns the current working directory or exits on error.
@@ -458,11 +607,29 @@ func generateMainGo(tmpDir string, data TemplateData, debug bool) {
 	file.Close()
 }
 
+// cleanups are the functions run when main returns or exits, as deferred
+// calls are not run by os.Exit.
+var cleanups []func()
+
+// onExit registers f to be run when main returns or exits.
+func onExit(f func()) {
+	cleanups = append(cleanups, f)
+}
+
+// runCleanups runs the functions registered with onExit, in reverse order.
+func runCleanups() {
+	for i := len(cleanups) - 1; i >= 0; i-- {
+		cleanups[i]()
+	}
+	cleanups = nil
+}
+
 // check aborts the program if err is non-nil, printing the error to stderr.
 // Centralizing this avoids repeating the same boilerplate all over the file.
 func check(err error) {
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "Error:", err)
+		runCleanups()
 		os.Exit(1)
 	}
 }
@@ -471,6 +638,7 @@ func check(err error) {
 // Use this for deliberate user-facing argument/validation errors.
 func exit(msg string) {
 	fmt.Fprintln(os.Stderr, msg)
+	runCleanups()
 	os.Exit(1)
 }
 
@@ -486,21 +654,69 @@ func clearOutputDir(path string, debug bool) {
 	}
 }
 
-// scanTypes walks all Go files inside scanPath, gathers declared type names,
-// prunes any that are only used as dependencies by other types (so we emit
-// only "top-level" exported types), and returns both the import path of the
-// package and the list of TypeSpec entries to generate.
-//
-// The import path is computed as "<moduleName>/<relPath>" where relPath is the
-// path from the module root to scanPath, normalized to forward slashes.
-func scanTypes(scanPath, moduleName, relPath string) ([]string, []TypeSpec, error) {
-	importPath := moduleName
-	if relPath != "." {
-		importPath = moduleName + "/" + filepath.ToSlash(relPath)
+// scanTypes returns the import aliases (import path -> alias) and the
+// TypeSpec entries of the packages found by --scan. Packages sharing the same
+// name are given distinct aliases, e.g. models and models2.
+func scanTypes(pkgs []scannedPackage) (map[string]string, []TypeSpec) {
+	imports := map[string]string{}
+	aliases := map[string]struct{}{}
+	var typeSpecs []TypeSpec
+	for _, pkg := range pkgs {
+		alias := filepath.Base(pkg.ImportPath)
+		// Prefer a safe alias for import (e.g., to handle names with dashes).
+		pkgAlias := utils.AliasImport(alias)
+		if pkgAlias == "" {
+			pkgAlias = alias
+		}
+		if _, taken := aliases[pkgAlias]; taken {
+			base := pkgAlias
+			for i := 2; ; i++ {
+				pkgAlias = fmt.Sprintf("%s%d", base, i)
+				if _, taken := aliases[pkgAlias]; !taken {
+					break
+				}
+			}
+		}
+		aliases[pkgAlias] = struct{}{}
+		imports[pkg.ImportPath] = pkgAlias
+
+		for _, typeName := range pkg.TypeNames {
+			packaged := fmt.Sprintf("%s.%s", pkgAlias, typeName)
+			typeSpecs = append(typeSpecs, TypeSpec{
+				FullName:     fmt.Sprintf("%s.%s", pkg.ImportPath, typeName),
+				Version:      "",
+				Package:      pkg.ImportPath,
+				PackagedType: packaged,
+				Type:         typeName,
+				ImportName:   alias,
+				PackageAlias: pkgAlias,
+				// Types that are not declared as structs are built from a variable
+				IsAliasType:  !pkg.Structs[typeName],
+				AliasTypeVar: utils.GenerateAliasVarName(packaged),
+			})
+		}
 	}
+	return imports, typeSpecs
+}
 
+// scanImportPath returns the import path of the package at relPath from the
+// root of moduleName.
+func scanImportPath(moduleName, relPath string) string {
+	if relPath == "." {
+		return moduleName
+	}
+	return moduleName + "/" + filepath.ToSlash(relPath)
+}
+
+// scanTypeNames returns the names of the types declared by the Go files of
+// scanPath built with the tags of filter that are not used by other types of
+// the package, or of all its exported types with filter.All, sorted by name,
+// and whether they are declared as structs. Interfaces are left out, and
+// generic declarations are only kept with filter.Static, as reflection only
+// knows instantiated types.
+func scanTypeNames(scanPath string, filter scanFilter) ([]string, map[string]bool, error) {
 	fset := token.NewFileSet()
-	pkgs, err := parser.ParseDir(fset, scanPath, nil, parser.AllErrors)
+	files, err := buildFiles(scanPath, filter.Tags)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -510,23 +726,30 @@ func scanTypes(scanPath, moduleName, relPath string) ([]string, []TypeSpec, erro
 
 	// Collect declared types and their (shallow) dependencies.
 	// We only track dependency *names* here, which is enough to drop "leaf" deps later.
-	for _, pkg := range pkgs {
-		for _, file := range pkg.Files {
-			for _, decl := range file.Decls {
-				genDecl, ok := decl.(*ast.GenDecl)
-				if !ok || genDecl.Tok != token.TYPE {
+	for _, path := range files {
+		file, err := parser.ParseFile(fset, path, nil, parser.AllErrors)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok {
 					continue
 				}
-				for _, spec := range genDecl.Specs {
-					typeSpec, ok := spec.(*ast.TypeSpec)
-					if !ok {
-						continue
+				typeName := typeSpec.Name.Name
+				allTypes[typeName] = typeSpec
+
+				dependencies[typeName] = make(map[string]bool)
+				findDepsInExpr(typeSpec.Type, dependencies[typeName])
+				if typeSpec.TypeParams != nil {
+					for _, param := range typeSpec.TypeParams.List {
+						findDepsInExpr(param.Type, dependencies[typeName])
 					}
-					typeName := typeSpec.Name.Name
-					allTypes[typeName] = typeSpec
-
-					dependencies[typeName] = make(map[string]bool)
-					findDepsInExpr(typeSpec.Type, dependencies[typeName])
 				}
 			}
 		}
@@ -541,42 +764,29 @@ func scanTypes(scanPath, moduleName, relPath string) ([]string, []TypeSpec, erro
 		}
 	}
 
-	var typeSpecs []TypeSpec
-	alias := filepath.Base(importPath)
-	// Prefer a safe alias for import (e.g., to handle names with dashes).
-	pkgAlias := utils.AliasImport(alias)
-	if pkgAlias == "" {
-		pkgAlias = alias
-	}
-
-	modules := make(map[string]struct{})
-	pkgsInfo := map[string][]*packages.Package{}
-	for typeName := range allTypes {
-		if used[typeName] {
+	var typeNames []string
+	structs := map[string]bool{}
+	for typeName, typeSpec := range allTypes {
+		if filter.All && !ast.IsExported(typeName) {
+			continue
+		}
+		if used[typeName] && !filter.All {
 			// Skip types that are only referenced by others (dependencies).
 			continue
 		}
-
-		fullName := fmt.Sprintf("%s.%s", importPath, typeName)
-		// Determine if the named type is an alias (non-struct) or a proper struct.
-		isAlias, _ := isDefinedAlias(fullName, modules, pkgsInfo)
-		packaged := fmt.Sprintf("%s.%s", pkgAlias, typeName)
-		varName := utils.GenerateAliasVarName(packaged)
-
-		typeSpecs = append(typeSpecs, TypeSpec{
-			FullName:     fullName,
-			Version:      "",
-			Package:      importPath,
-			PackagedType: packaged,
-			Type:         typeName,
-			ImportName:   alias,
-			PackageAlias: pkgAlias,
-			IsAliasType:  isAlias,
-			AliasTypeVar: varName,
-		})
+		if _, isInterface := typeSpec.Type.(*ast.InterfaceType); isInterface {
+			// Interfaces have no generated methods, and reflection knows no
+			// value of their own
+			continue
+		}
+		if !filter.Static && typeSpec.TypeParams != nil {
+			continue
+		}
+		typeNames = append(typeNames, typeName)
+		_, structs[typeName] = typeSpec.Type.(*ast.StructType)
 	}
-
-	return []string{importPath}, typeSpecs, nil
+	sort.Strings(typeNames)
+	return typeNames, structs, nil
 }
 
 // findModuleRoot climbs up from 'path' until it finds a directory containing a
@@ -753,5 +963,31 @@ func findDepsInExpr(expr ast.Expr, deps map[string]bool) {
 		for _, field := range t.Fields.List {
 			findDepsInExpr(field.Type, deps)
 		}
+
+	// Instantiated generic types, such as Pair[string, int]
+	case *ast.IndexExpr:
+		findDepsInExpr(t.X, deps)
+		findDepsInExpr(t.Index, deps)
+
+	case *ast.IndexListExpr:
+		findDepsInExpr(t.X, deps)
+		for _, index := range t.Indices {
+			findDepsInExpr(index, deps)
+		}
+
+	// Constraints of type parameters, such as ~int | Num
+	case *ast.BinaryExpr:
+		findDepsInExpr(t.X, deps)
+		findDepsInExpr(t.Y, deps)
+
+	case *ast.UnaryExpr:
+		findDepsInExpr(t.X, deps)
+
+	case *ast.InterfaceType:
+		for _, field := range t.Methods.List {
+			if len(field.Names) == 0 {
+				findDepsInExpr(field.Type, deps)
+			}
+		}
 	}
 }
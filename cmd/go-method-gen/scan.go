@@ -0,0 +1,179 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// scanFilter selects the packages and types found by --scan.
+type scanFilter struct {
+	Tags    []string         // Build tags files are selected with
+	Include []*regexp.Regexp // If any, types must match one of them
+	Exclude []*regexp.Regexp // Types must not match any of them
+	All     bool             // Scan every exported type, including the ones used by other types
+	Static  bool             // Keep generic declarations, only loaded with --static
+}
+
+// newScanFilter compiles the include and exclude regular expressions of a scan.
+func newScanFilter(tags, include, exclude []string) (scanFilter, error) {
+	filter := scanFilter{Tags: tags}
+	for _, expr := range include {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return filter, fmt.Errorf("invalid include expression: %w", err)
+		}
+		filter.Include = append(filter.Include, re)
+	}
+	for _, expr := range exclude {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return filter, fmt.Errorf("invalid exclude expression: %w", err)
+		}
+		filter.Exclude = append(filter.Exclude, re)
+	}
+	return filter, nil
+}
+
+// matches reports whether the fully-qualified type fullName ("<import.path>.Type")
+// is selected by the filter. Expressions are not anchored, so that they can
+// match a type name ("Params$"), a package path ("/internal/") or both.
+func (f scanFilter) matches(fullName string) bool {
+	for _, re := range f.Exclude {
+		if re.MatchString(fullName) {
+			return false
+		}
+	}
+	if len(f.Include) == 0 {
+		return true
+	}
+	for _, re := range f.Include {
+		if re.MatchString(fullName) {
+			return true
+		}
+	}
+	return false
+}
+
+// scannedPackage holds the types found by --scan in a package.
+type scannedPackage struct {
+	ImportPath string
+	TypeNames  []string        // Names of the types to generate, sorted
+	Structs    map[string]bool // Whether the types are declared as structs
+}
+
+// splitScanPattern splits a --scan value into its directory and whether the
+// packages of its subdirectories are scanned too, as with "./models/...".
+func splitScanPattern(scanPath string) (string, bool) {
+	if scanPath == "..." {
+		return ".", true
+	}
+	if dir, found := strings.CutSuffix(filepath.ToSlash(scanPath), "/..."); found {
+		return filepath.FromSlash(dir), true
+	}
+	return scanPath, false
+}
+
+// splitTags splits a comma separated list of build tags, as given to the go
+// command with -tags.
+func splitTags(list string) []string {
+	var tags []string
+	for _, tag := range strings.Split(list, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// scanDirs returns dir and, if recursive, its subdirectories in lexical order.
+// As with the go command, directories whose name starts with "." or "_",
+// testdata directories, vendor directories and nested modules are skipped.
+func scanDirs(dir string, recursive bool) ([]string, error) {
+	if !recursive {
+		return []string{dir}, nil
+	}
+	var dirs []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+		if path != dir {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" || name == "vendor" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+		dirs = append(dirs, path)
+		return nil
+	})
+	return dirs, err
+}
+
+// scanPackages returns the packages found by --scan=scanPath in the module
+// moduleName rooted at modRoot, with the types selected by filter. Packages
+// without any selected type are left out.
+func scanPackages(scanPath, moduleName, modRoot string, filter scanFilter) ([]scannedPackage, error) {
+	dir, recursive := splitScanPattern(scanPath)
+	dirs, err := scanDirs(dir, recursive)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []scannedPackage
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		relPath, err := filepath.Rel(modRoot, absDir)
+		if err != nil {
+			return nil, err
+		}
+		typeNames, structs, err := scanTypeNames(dir, filter)
+		if err != nil {
+			return nil, err
+		}
+		pkg := scannedPackage{ImportPath: scanImportPath(moduleName, relPath), Structs: structs}
+		for _, typeName := range typeNames {
+			if filter.matches(pkg.ImportPath + "." + typeName) {
+				pkg.TypeNames = append(pkg.TypeNames, typeName)
+			}
+		}
+		if len(pkg.TypeNames) > 0 {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+// buildFiles returns the paths of the Go files of the package in dir that are
+// built with tags for the current platform, excluding tests. A directory
+// without such files holds no package and has no files.
+func buildFiles(dir string, tags []string) ([]string, error) {
+	ctx := build.Default
+	ctx.BuildTags = tags
+	pkg, err := ctx.ImportDir(dir, 0)
+	if err != nil {
+		var noGoErr *build.NoGoError
+		if errors.As(err, &noGoErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	files := make([]string, 0, len(pkg.GoFiles)+len(pkg.CgoFiles))
+	for _, name := range append(pkg.GoFiles, pkg.CgoFiles...) {
+		files = append(files, filepath.Join(dir, name))
+	}
+	return files, nil
+}
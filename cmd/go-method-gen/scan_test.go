@@ -0,0 +1,165 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// scanModule writes a module whose packages are scanned by the tests.
+func scanModule(t *testing.T) string {
+	t.Helper()
+	return writeModule(t, map[string]string{
+		"models/models.go": `package models
+
+type Server struct {
+	Name string
+}
+
+type Frontend struct {
+	Servers []Server
+}
+
+type FrontendParams struct {
+	Name string
+}
+
+type Shape interface {
+	Area() float64
+}
+`,
+		"models/tagged.go":             "//go:build integration\n\npackage models\n\ntype Tagged struct{}\n",
+		"models/models_test.go":        "package models\n\ntype TestOnly struct{}\n",
+		"models/backend/backend.go":    "package backend\n\ntype Backend struct{}\n",
+		"models/internal/internal.go":  "package internal\n\ntype Hidden struct{}\n",
+		"models/testdata/testdata.go":  "package testdata\n\ntype Data struct{}\n",
+		"models/_skipped/skipped.go":   "package skipped\n\ntype Skipped struct{}\n",
+		"models/nested/go.mod":         "module example.com/nested\n",
+		"models/nested/nested.go":      "package nested\n\ntype Nested struct{}\n",
+		"models/generic/generic.go":    "package generic\n\ntype Box[T any] struct {\n\tV T\n}\n",
+		"models/empty/doc.go":          "// Package empty declares no type.\npackage empty\n",
+		"models/backend/v2/backend.go": "package backend\n\ntype Backend struct{}\n",
+	})
+}
+
+// scannedTypes returns the fully-qualified names of the types of pkgs.
+func scannedTypes(pkgs []scannedPackage) []string {
+	var names []string
+	for _, pkg := range pkgs {
+		for _, typeName := range pkg.TypeNames {
+			names = append(names, pkg.ImportPath+"."+typeName)
+		}
+	}
+	return names
+}
+
+func TestScanPackages(t *testing.T) {
+	root := scanModule(t)
+	models := filepath.Join(root, "models")
+	tests := []struct {
+		name     string
+		scanPath string
+		tags     []string
+		include  []string
+		exclude  []string
+		all      bool
+		static   bool
+		want     []string
+	}{
+		{
+			name:     "package",
+			scanPath: models,
+			want:     []string{"example.com/models/models.Frontend", "example.com/models/models.FrontendParams"},
+		},
+		{
+			name:     "subtree",
+			scanPath: models + "/...",
+			want: []string{
+				"example.com/models/models.Frontend", "example.com/models/models.FrontendParams",
+				"example.com/models/models/backend.Backend", "example.com/models/models/backend/v2.Backend",
+				"example.com/models/models/internal.Hidden",
+			},
+		},
+		{
+			name:     "include and exclude",
+			scanPath: models + "/...",
+			include:  []string{"Frontend", "Backend$"},
+			exclude:  []string{"Params$", "/v2\\."},
+			want:     []string{"example.com/models/models.Frontend", "example.com/models/models/backend.Backend"},
+		},
+		{
+			name:     "excluded package path",
+			scanPath: models + "/...",
+			exclude:  []string{"/internal\\.", "/backend"},
+			want:     []string{"example.com/models/models.Frontend", "example.com/models/models.FrontendParams"},
+		},
+		{
+			name:     "build tags",
+			scanPath: models,
+			tags:     []string{"integration"},
+			want: []string{
+				"example.com/models/models.Frontend", "example.com/models/models.FrontendParams",
+				"example.com/models/models.Tagged",
+			},
+		},
+		{
+			name:     "all types",
+			scanPath: models,
+			all:      true,
+			want: []string{
+				"example.com/models/models.Frontend", "example.com/models/models.FrontendParams",
+				"example.com/models/models.Server",
+			},
+		},
+		{
+			name:     "generic declarations",
+			scanPath: filepath.Join(models, "generic"),
+			static:   true,
+			want:     []string{"example.com/models/models/generic.Box"},
+		},
+		{
+			name:     "generic declarations without static",
+			scanPath: filepath.Join(models, "generic"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := newScanFilter(test.tags, test.include, test.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+			filter.All, filter.Static = test.all, test.static
+			pkgs, err := scanPackages(test.scanPath, "example.com/models", root, filter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := scannedTypes(pkgs); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("scanPackages() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestNewScanFilterErrors(t *testing.T) {
+	if _, err := newScanFilter(nil, []string{"("}, nil); err == nil {
+		t.Error("expected an error for an invalid include expression")
+	}
+	if _, err := newScanFilter(nil, nil, []string{"["}); err == nil {
+		t.Error("expected an error for an invalid exclude expression")
+	}
+}
+
+func TestScanTypesAliases(t *testing.T) {
+	pkgs := []scannedPackage{
+		{ImportPath: "example.com/a/models", TypeNames: []string{"Frontend"}, Structs: map[string]bool{"Frontend": true}},
+		{ImportPath: "example.com/b/models", TypeNames: []string{"Mode"}},
+	}
+	imports, specs := scanTypes(pkgs)
+	wantImports := map[string]string{"example.com/a/models": "models", "example.com/b/models": "models2"}
+	if !reflect.DeepEqual(imports, wantImports) {
+		t.Errorf("scanTypes() imports = %v, want %v", imports, wantImports)
+	}
+	if len(specs) != 2 || specs[1].PackagedType != "models2.Mode" || !specs[1].IsAliasType || specs[0].IsAliasType {
+		t.Errorf("scanTypes() specs = %+v", specs)
+	}
+}
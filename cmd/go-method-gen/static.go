@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+	"golang.org/x/tools/go/packages"
+)
+
+// runStatic generates the functions of the given fully-qualified types
+// ("<import.path>.Type") in process, from their go/types information.
+//
+// Packages are loaded from dir, with the go.mod and build cache of the module
+// containing it: no temporary module is written, nothing is fetched and no
+// program is run. Packages are type-checked from source, so the toolchain
+// used to build dependencies does not matter. Files are selected with the
+// build tags tags.
+func runStatic(dir string, typeArgs, tags []string, opts eqdiff.Options, debug bool) ([]eqdiff.GeneratedFile, error) {
+	// Group the requested type names by import path.
+	var importPaths []string
+	typeNamesByPkg := map[string][]string{}
+	for _, full := range typeArgs {
+		if strings.Contains(full, "@") {
+			return nil, fmt.Errorf("versions are not supported with --static, types are loaded from the current module: %s", full)
+		}
+		lastDot := strings.LastIndex(full, ".")
+		if lastDot == -1 {
+			return nil, fmt.Errorf("invalid type path: %s", full)
+		}
+		importPath := full[:lastDot]
+		if _, exists := typeNamesByPkg[importPath]; !exists {
+			importPaths = append(importPaths, importPath)
+		}
+		typeNamesByPkg[importPath] = append(typeNamesByPkg[importPath], full[lastDot+1:])
+	}
+
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedTypes | packages.NeedTypesInfo |
+			packages.NeedSyntax | packages.NeedImports | packages.NeedDeps,
+		Dir: dir,
+	}
+	if len(tags) > 0 {
+		cfg.BuildFlags = []string{"-tags=" + strings.Join(tags, ",")}
+	}
+	if opts.InPackage {
+		cfg.ParseFile = parseFileWithoutGenerated
+	}
+	if debug {
+		fmt.Printf("• Loading packages: %v (cwd = %s)\n", importPaths, dir)
+	}
+	pkgs, err := packages.Load(cfg, importPaths...)
+	if err != nil {
+		return nil, err
+	}
+	if loadErrors(pkgs, opts.InPackage) > 0 {
+		return nil, fmt.Errorf("failed to load packages %v", importPaths)
+	}
+	pkgsByPath := map[string]*packages.Package{}
+	for _, pkg := range pkgs {
+		pkgsByPath[pkg.PkgPath] = pkg
+	}
+
+	// Lookup the requested types, keeping the command line order.
+	var typs []types.Type
+	for _, importPath := range importPaths {
+		pkg, found := pkgsByPath[importPath]
+		if !found {
+			return nil, fmt.Errorf("no packages found for %s", importPath)
+		}
+		for _, typeName := range typeNamesByPkg[importPath] {
+			obj := pkg.Types.Scope().Lookup(typeName)
+			if obj == nil {
+				return nil, fmt.Errorf("type %s not found in %s", typeName, importPath)
+			}
+			typeObj, ok := obj.(*types.TypeName)
+			if !ok {
+				return nil, fmt.Errorf("%s is not a named type", typeName)
+			}
+			typs = append(typs, typeObj.Type())
+		}
+	}
+	if debug {
+		fmt.Println("• Generating from go/types:")
+		for _, typ := range typs {
+			fmt.Printf("  - %s\n", typ)
+		}
+	}
+	return eqdiff.GenerateFilesFromGoTypes(typs, opts)
+}
+
+// loadErrors prints the errors of the loaded packages and their dependencies
+// to stderr and returns their number. With inPackage, type errors are printed
+// as warnings and not counted: code of the packages may call the generated
+// methods, which are left out while loading them.
+func loadErrors(pkgs []*packages.Package, inPackage bool) int {
+	var n int
+	packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+		for _, err := range pkg.Errors {
+			if inPackage && err.Kind == packages.TypeError {
+				fmt.Fprintln(os.Stderr, "Warning:", err)
+				continue
+			}
+			fmt.Fprintln(os.Stderr, err)
+			n++
+		}
+	})
+	return n
+}
@@ -0,0 +1,273 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package common
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// ResolveFieldPaths sets the field path of every node of the tree rooted at
+// root, e.g. "example.com/models.Backend.Servers[*]" for the elements of the
+// Servers field of Backend, flags the nodes holding values targeted by field
+// overrides and applies the slice compare options and the interface
+// implementations of the overrides.
+//
+// Values of named types are handled by methods shared by all their parents, so
+// the paths of their fields start at their type. When overrides target fields
+// through a parent instead, e.g. "example.com/models.Frontend.Binds[*].Name",
+// the paths go on from the parent and functions dedicated to that path are
+// generated. The shared methods are then generated from another node of the
+// type, outside of overridden paths, whatever the order of the fields.
+func ResolveFieldPaths(root *data.TypeNode, overrides map[string]OverrideFuncs) {
+	structs := map[string]*data.TypeNode{}
+	indexStructs(root, structs)
+	parsed := make(map[string]*data.TypeNode, len(structs))
+	for typ, node := range structs {
+		parsed[typ] = copyParsedNode(node, nil)
+	}
+	resolveFieldPath(root, root.PkgPath+"."+root.Type, overrides, parsed)
+	shareMethods(root, overrides, structs, parsed)
+}
+
+// LookupOverride returns the overrides of a node: the ones of its field path
+// take precedence over the ones of its type packagedType.
+func LookupOverride(overrides map[string]OverrideFuncs, node *data.TypeNode, packagedType string) (OverrideFuncs, bool) {
+	override, found := overrides[packagedType]
+	if node.UpNode == nil || node.FieldPath == "" {
+		return override, found
+	}
+	fieldOverride, ok := overrides[node.FieldPath]
+	if !ok {
+		return override, found
+	}
+	if fieldOverride.Equal == nil {
+		fieldOverride.Equal = override.Equal
+	}
+	if fieldOverride.Diff == nil {
+		fieldOverride.Diff = override.Diff
+	}
+	if fieldOverride.Merge == nil {
+		fieldOverride.Merge = override.Merge
+	}
+	if fieldOverride.Clone == nil {
+		fieldOverride.Clone = override.Clone
+	}
+	fieldOverride.Ignore = fieldOverride.Ignore || override.Ignore
+	return fieldOverride, true
+}
+
+// resolveFieldPath sets the field path of node and of its descendants, and
+// reports whether overrides target node or one of its descendants.
+func resolveFieldPath(node *data.TypeNode, path string, overrides map[string]OverrideFuncs,
+	parsed map[string]*data.TypeNode,
+) bool {
+	node.FieldPath = path
+	applySliceOverrides(node, overrides)
+	applyImplementationOverrides(node, overrides)
+	_, overridden := overrides[path]
+	if node.UpNode == nil || node.Type == "" || node.Kind == data.TypeParam {
+		node.FieldOverrides = resolveChildrenFieldPaths(node, path, overrides, parsed)
+		return overridden || node.FieldOverrides
+	}
+	if hasOverridesBelow(overrides, path) {
+		// Types already visited have no fields, they are copied from the
+		// node where they were first parsed
+		copied := len(node.Fields) == 0
+		if copied {
+			copyParsedFields(node, parsed)
+		}
+		if resolveChildrenFieldPaths(node, path, overrides, parsed) {
+			node.FieldOverrides = true
+			return true
+		}
+		if copied {
+			node.Fields = nil
+		}
+	}
+	resolveChildrenFieldPaths(node, node.PkgPath+"."+node.Type, overrides, parsed)
+	return overridden
+}
+
+// resolveChildrenFieldPaths resolves the field paths of the fields and
+// elements of node from its path, and reports whether overrides target some
+// of them.
+func resolveChildrenFieldPaths(node *data.TypeNode, path string, overrides map[string]OverrideFuncs,
+	parsed map[string]*data.TypeNode,
+) bool {
+	var found bool
+	if node.SubNode != nil {
+		// Pointed values share the path of their pointer
+		subPath := path
+		if node.Kind != data.Pointer {
+			subPath += "[*]"
+		}
+		found = resolveFieldPath(node.SubNode, subPath, overrides, parsed)
+	}
+	for _, field := range node.Fields {
+		found = resolveFieldPath(field, path+"."+field.Name, overrides, parsed) || found
+	}
+	return found
+}
+
+// applySliceOverrides sets the compare options of a slice node from its
+// overrides: the ones of its field path take precedence over the options of
+// its gmg tag, which take precedence over the ones of its type.
+func applySliceOverrides(node *data.TypeNode, overrides map[string]OverrideFuncs) {
+	if node.Kind != data.Slice {
+		return
+	}
+	if override, found := overrides[node.FieldPath]; found && node.UpNode != nil && override.hasSliceOptions() {
+		setSliceOptions(node, override)
+		node.FieldOptions = true
+		return
+	}
+	if node.FieldOptions || node.Type == "" {
+		return
+	}
+	if override, found := overrides[node.PkgPath+"."+node.Type]; found {
+		setSliceOptions(node, override)
+	}
+}
+
+// applyImplementationOverrides registers the implementations of an interface
+// node set by its overrides, in place of the discovered ones: the ones of its
+// field path take precedence over the ones of its type.
+func applyImplementationOverrides(node *data.TypeNode, overrides map[string]OverrideFuncs) {
+	if node.Kind != data.Interface || node.UpNode == nil {
+		return
+	}
+	override, found := overrides[node.FieldPath]
+	if !found || len(override.Implementations) == 0 {
+		if node.Type == "" {
+			return
+		}
+		override, found = overrides[node.PkgPath+"."+node.Type]
+		if !found || len(override.Implementations) == 0 {
+			return
+		}
+	}
+	implementations := make([]data.Implementation, len(override.Implementations))
+	for i, impl := range override.Implementations {
+		implementations[i] = data.ParseImplementation(impl)
+	}
+	node.SetImplementations(implementations)
+}
+
+// setSliceOptions sets the slice compare options of a node from overrides.
+func setSliceOptions(node *data.TypeNode, override OverrideFuncs) {
+	node.SliceSet, node.SliceKey = override.Set, override.Key
+	node.SliceLCS, node.SliceMoves = override.LCS || override.Moves, override.Moves
+}
+
+// hasOverridesBelow reports whether an override key targets a field or an
+// element below path.
+func hasOverridesBelow(overrides map[string]OverrideFuncs, path string) bool {
+	for key := range overrides {
+		if strings.HasPrefix(key, path+".") || strings.HasPrefix(key, path+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// shareMethods generates the shared methods of the types whose first node,
+// the one holding their fields, has functions dedicated to its overridden field
+// path: their parsed fields are copied to the first node of the type which
+// calls their methods, whose field paths are resolved from the type.
+func shareMethods(root *data.TypeNode, overrides map[string]OverrideFuncs,
+	structs, parsed map[string]*data.TypeNode,
+) {
+	for {
+		node := findUnsharedNode(root, structs)
+		if node == nil {
+			return
+		}
+		typ := node.PkgPath + "." + node.Type
+		structs[typ] = node
+		copyParsedFields(node, parsed)
+		resolveChildrenFieldPaths(node, typ, overrides, parsed)
+	}
+}
+
+// findUnsharedNode returns the first node of the tree rooted at node calling
+// the shared methods of a type whose only node holding fields has functions
+// dedicated to its field path, or nil if there is none.
+func findUnsharedNode(node *data.TypeNode, structs map[string]*data.TypeNode) *data.TypeNode {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == data.Struct && node.Type != "" && len(node.Fields) == 0 && !node.IsInstantiated() {
+		if origin, found := structs[node.PkgPath+"."+node.Type]; found && origin.FieldOverrides && origin.UpNode != nil {
+			return node
+		}
+	}
+	if found := findUnsharedNode(node.SubNode, structs); found != nil {
+		return found
+	}
+	for _, field := range node.Fields {
+		if found := findUnsharedNode(field, structs); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// copyParsedFields copies the fields of the type of node, as parsed, to node.
+func copyParsedFields(node *data.TypeNode, parsed map[string]*data.TypeNode) {
+	origin, found := parsed[node.PkgPath+"."+node.Type]
+	if !found {
+		return
+	}
+	for _, field := range origin.Fields {
+		node.Fields = append(node.Fields, copyParsedNode(field, node))
+	}
+}
+
+// indexStructs indexes by type the struct nodes of the tree rooted at node
+// which have parsed fields.
+func indexStructs(node *data.TypeNode, structs map[string]*data.TypeNode) {
+	if node == nil {
+		return
+	}
+	if node.Kind == data.Struct && node.Type != "" && len(node.Fields) > 0 {
+		if _, found := structs[node.PkgPath+"."+node.Type]; !found {
+			structs[node.PkgPath+"."+node.Type] = node
+		}
+	}
+	indexStructs(node.SubNode, structs)
+	for _, field := range node.Fields {
+		indexStructs(field, structs)
+	}
+}
+
+// copyParsedNode returns a deep copy of node attached to the parent up. As
+// in parsed trees, the named structs found below node have no fields: their
+// fields are copied from the parsed ones if overrides target them.
+func copyParsedNode(node, up *data.TypeNode) *data.TypeNode {
+	nodeCopy := *node
+	nodeCopy.UpNode = up
+	if node.SubNode != nil {
+		nodeCopy.SubNode = copyParsedNode(node.SubNode, &nodeCopy)
+	}
+	nodeCopy.Fields = nil
+	if up != nil && node.Kind == data.Struct && node.Type != "" {
+		return &nodeCopy
+	}
+	for _, field := range node.Fields {
+		nodeCopy.Fields = append(nodeCopy.Fields, copyParsedNode(field, &nodeCopy))
+	}
+	return &nodeCopy
+}
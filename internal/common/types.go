@@ -19,7 +19,21 @@ type FuncRef struct {
 }
 
 type OverrideFuncs struct {
-	Equal *FuncRef `yaml:"equal"`
-	Diff  *FuncRef `yaml:"diff"`
-	Merge *FuncRef `yaml:"merge"`
+	Equal  *FuncRef `yaml:"equal"`
+	Diff   *FuncRef `yaml:"diff"`
+	Merge  *FuncRef `yaml:"merge"`
+	Clone  *FuncRef `yaml:"clone"`
+	Ignore bool     `yaml:"ignore"` // Skip the fields in the functions of their struct
+	Set    bool     `yaml:"set"`    // Compare slices regardless of the order of their elements
+	Key    string   `yaml:"key"`    // Match slice elements by this field of theirs
+	LCS    bool     `yaml:"lcs"`    // Diff slices as insertions and deletions of elements
+	Moves  bool     `yaml:"moves"`  // Diff slices as insertions, deletions and moves of elements
+
+	// Concrete types of interface values, e.g. "*example.com/models.Backend"
+	Implementations []string `yaml:"implementations"`
+}
+
+// hasSliceOptions reports whether the overrides set how slices are compared.
+func (o OverrideFuncs) hasSliceOptions() bool {
+	return o.Set || o.Key != "" || o.LCS || o.Moves
 }
@@ -16,6 +16,7 @@ package data
 import (
 	"encoding/json"
 	"fmt"
+	"go/types"
 	"reflect"
 	"strings"
 	"text/template"
@@ -35,6 +36,27 @@ const (
 	NodeNameMap         = "NodeName"         // Field name
 	IsBuiltinSubNodeMap = "IsBuiltinSubNode" // Indicates if sub-node is a builtin type
 	SubTypeMap          = "SubType"          // Type of sub-node
+	SubInequalityMap    = "SubInequality"    // Expression testing the inequality of builtin sub-node values
+	ElemPrefixMap       = "ElemPrefix"       // Expression prefixing the diff keys of sub-node values with their key
+
+	MergeFuncNameDataMap = "MergeFuncName" // Name of the Merge function
+	MergeElementMap      = "MergeElement"  // Expression for merging
+	SubZeroValueMap      = "SubZeroValue"  // Zero value literal of a builtin sub-node
+
+	CloneFuncNameDataMap = "CloneFuncName"  // Name of the Clone function
+	CloneElementMap      = "CloneElement"   // Expression for cloning
+	IsValueSubNodeMap    = "IsValueSubNode" // Indicates if sub-node is copied by value
+
+	SliceSetMap     = "SliceSet"     // Non-empty if slice elements are compared regardless of order
+	SliceKeyMap     = "SliceKey"     // Field of slice elements matching them
+	SliceKeyPtrMap  = "SliceKeyPtr"  // Non-empty if the key field is a pointer, matched by the value it points to
+	SliceKeyNameMap = "SliceKeyName" // Name of the key field in diff keys
+	SliceLCSMap     = "SliceLCS"     // Non-empty if slices are diffed as insertions and deletions
+	SliceMovesMap   = "SliceMoves"   // Non-empty if moves of slice elements are diffed too
+	NilEmptyMap     = "NilEmpty"     // Non-empty if nil pointers equal pointers to zero values
+	ElemTypeMap     = "ElemType"     // Type of sub-node values
+	ElemPointerMap  = "ElemPointer"  // Non-empty if sub-node values are pointers
+	ElemNilMap      = "ElemNil"      // Typed nil value of sub-node values, if they are pointers
 )
 
 // Kind represents the kind of a type node (builtin, struct, array, slice, map, etc.)
@@ -50,6 +72,7 @@ const (
 	Interface
 	Pointer
 	Func
+	TypeParam
 )
 
 // MarshalJSON allows Kind to be serialized to JSON as a string
@@ -64,24 +87,138 @@ func (k Kind) MarshalYAML() (interface{}, error) {
 
 // TypeNode represents a type or field in the type hierarchy
 type TypeNode struct {
-	HasEqual         bool           // True if type has an existing Equal method
-	HasDiff          bool           // True if type has an existing Diff method
-	Name             string         // Field name, empty for root type
-	Type             string         // Field type name
-	PackagedType     string         // Fully qualified type name including package
-	Kind             Kind           // Kind of the type
-	IsComparable     bool           // True if type can be compared with ==
-	PkgPath          string         // Package path for the type
-	PkgAlias         string         // Alias used when importing the package
-	SamePkgAsReferer bool           // True if type is in same package as reference
-	Fields           []*TypeNode    // Child fields (for structs)
-	Len              int            // Array length
-	Value            *reflect.Value // Optional pointer to value
-	Imports          map[string]struct{}
-	MapKeyType       string
-	SubNode          *TypeNode
-	UpNode           *TypeNode `json:"-"`
-	Err              bool
+	HasEqual          bool           // True if type has an existing Equal method
+	HasDiff           bool           // True if type has an existing Diff method
+	HasTypedDiff      bool           // True if type has an existing Diff method returning []eqdiff.Change
+	HasMerge          bool           // True if type has an existing Merge method
+	HasClone          bool           // True if type has an existing Clone method
+	HasMergePatch     bool           // True if type has an existing MergePatch method
+	HasTypedApplyDiff bool           // True if type has an existing ApplyDiff method taking []eqdiff.Change
+	HasMerge3         bool           // True if type has an existing Merge3 method
+	HasHash           bool           // True if type has an existing Hash method
+	HasCompare        bool           // True if type has an existing Compare method
+	HasJSONMarshaler  bool           // True if type or its pointer implements json.Marshaler or encoding.TextMarshaler
+	Name              string         // Field name, empty for root type
+	Type              string         // Field type name
+	PackagedType      string         // Fully qualified type name including package
+	Kind              Kind           // Kind of the type
+	BuiltinKind       string         // Underlying kind name for builtin types (string, int64, ...)
+	IsComparable      bool           // True if type can be compared with ==
+	PkgPath           string         // Package path for the type
+	PkgAlias          string         // Alias used when importing the package
+	SamePkgAsReferer  bool           // True if type is in same package as reference
+	Fields            []*TypeNode    // Child fields (for structs)
+	Len               int            // Array length
+	Value             *reflect.Value // Optional pointer to value
+	Imports           map[string]struct{}
+	MapKeyType        string
+	TypeParams        string // Type parameters of generated functions, e.g. "[K comparable, V any]"
+	TypeArgs          string // Type parameters passed as arguments, e.g. "[K, V]"
+	FieldPath         string // Path of the values from their struct, e.g. "example.com/models.Backend.Servers[*]"
+	FieldOverrides    bool   // True if field overrides target values below this node
+	SliceSet          bool   // Slice compared as a multiset, regardless of order
+	SliceKey          string // Field of slice elements matching them, regardless of order
+	SliceKeyPointer   bool   // True if the key field is a pointer, matching elements by the value it points to
+	SliceKeyName      string // Name of the key field in diff keys, e.g. "name" for `json:"name"` with json diff names
+	SliceLCS          bool   // Slice diffed as insertions and deletions of elements
+	SliceMoves        bool   // Slice diffed as insertions, deletions and moves of elements
+	NilEmpty          bool   // Nil pointers equal pointers to zero values
+	FieldOptions      bool   // True if compare options are set for the field rather than its type
+	DiffName          string // Name of the field in diff keys, if not its Go name
+	DiffFlattened     bool   // True if the members of an embedded struct are members of its struct in diff keys
+	DiffSkipped       bool   // True if the field is left out of diffs, as its diff name tag names it "-"
+	DiffScalar        bool   // True if a slice of bytes is diffed as a whole value, as encoding/json encodes it as a string
+	StructTag         string // Struct tag of the field
+	TagError          string // Error in the gmg struct tag of the field, failing the generation
+	Embedded          bool   // True if the field is embedded
+	Foreign           bool   // True if the type is declared in a package no file is generated in: functions are generated in the packages of its referers
+	Opaque            bool   // True if the struct is declared in another package than its referer and has no exported field, e.g. time.Time: its values are handled as a whole
+	SubNode           *TypeNode
+	UpNode            *TypeNode `json:"-"`
+	Err               bool
+
+	Implementations []Implementation // Concrete types of the values of an interface
+	RefererPkgPath  string           // Package path of the functions comparing interface values
+	InterfaceType   reflect.Type     `json:"-"` // Interface type parsed with reflect, to discover its implementations
+	InterfaceGoType types.Type       `json:"-"` // Interface type parsed with go/types, to discover its implementations
+
+	KeyFields map[string]KeyField // Fields of structs by name, promoted ones included, which may match slice elements
+}
+
+// KeyField describes a field of a struct by which the elements of slices
+// diffed with the key option may be matched.
+type KeyField struct {
+	Pointer    bool   // True if the field is a pointer, matched by the value it points to
+	Comparable bool   // True if the values matched are comparable
+	Tag        string // Struct tag of the field, naming it in diff keys
+}
+
+// Implementation is a concrete type of the values of an interface, compared
+// with its own Equal and Diff methods.
+type Implementation struct {
+	PkgPath string // Package path of the type, empty for builtin types
+	Type    string // Name of the type
+	Pointer bool   // True if values are pointers to the type
+}
+
+// ParseImplementation parses an implementation written as in overrides, e.g.
+// "*example.com/models.Backend" or "string".
+func ParseImplementation(s string) Implementation {
+	var impl Implementation
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "*") {
+		impl.Pointer = true
+		s = s[1:]
+	}
+	if lastDot := strings.LastIndex(s, "."); lastDot != -1 {
+		impl.PkgPath = s[:lastDot]
+		s = s[lastDot+1:]
+	}
+	impl.Type = s
+	return impl
+}
+
+// TypeIn returns the type of the implementation as written in the package
+// pkgPath, e.g. "*models.Backend".
+func (i Implementation) TypeIn(pkgPath string) string {
+	typ := i.Type
+	if i.PkgPath != "" && i.PkgPath != pkgPath {
+		pkg := utils.ExtractPkg(i.PkgPath)
+		if alias := utils.AliasPkg(pkg); alias != "" {
+			pkg = alias
+		}
+		typ = pkg + "." + typ
+	}
+	if i.Pointer {
+		typ = "*" + typ
+	}
+	return typ
+}
+
+// SetImplementations sets the concrete types of the values of an interface
+// node, which may be none: values of other types are compared with reflect.
+// The node and the nodes holding it are then generated, importing reflect and
+// the packages of the implementations.
+func (en *TypeNode) SetImplementations(implementations []Implementation) {
+	en.Implementations = implementations
+	imports := map[string]struct{}{"reflect": {}}
+	for _, impl := range implementations {
+		if impl.PkgPath != "" && impl.PkgPath != en.RefererPkgPath {
+			imports[impl.PkgPath] = struct{}{}
+		}
+	}
+	// Struct nodes gather the imports of their fields when generated
+	for node := en; node != nil && node.Kind != Struct; node = node.UpNode {
+		if node.Imports == nil {
+			node.Imports = map[string]struct{}{}
+		}
+		for imp := range imports {
+			node.Imports[imp] = struct{}{}
+		}
+	}
+	for node := en; node != nil; node = node.UpNode {
+		node.Err = false
+	}
 }
 
 // IsForType returns true if this node represents a type (not a field)
@@ -94,6 +231,121 @@ func (en *TypeNode) IsForField() bool {
 	return en.Name != ""
 }
 
+// IsInstantiated returns true if this node is an instance of a generic type,
+// such as Pair[string, int]. Methods cannot be declared on instances.
+func (en *TypeNode) IsInstantiated() bool {
+	return strings.Contains(en.Type, "[")
+}
+
+// GeneratesFunctions returns true if functions taking the values of this node
+// as arguments are generated rather than methods of its named type. Methods
+// cannot be declared on instances of generic types, nor on types declared in
+// packages no file is generated in, and values holding fields with their own
+// overrides have functions dedicated to their field path.
+func (en *TypeNode) GeneratesFunctions() bool {
+	return en.IsInstantiated() || en.Foreign || (en.FieldOverrides && en.UpNode != nil)
+}
+
+// DiffKey returns the name of a field node in diff keys.
+func (en *TypeNode) DiffKey() string {
+	if en.DiffFlattened {
+		return ""
+	}
+	if en.DiffName != "" {
+		return en.DiffName
+	}
+	return en.Name
+}
+
+// CompareOptions returns the options changing how the values of this node
+// are compared, e.g. "Set" or "KeyName", used in the names of their Equal and
+// Diff functions. Options of pointed values are included in the ones of their
+// pointers.
+func (en *TypeNode) CompareOptions() string {
+	switch en.Kind {
+	case Slice:
+		if en.SliceKey != "" {
+			return "Key" + en.SliceKey
+		}
+		if en.SliceSet {
+			return "Set"
+		}
+	case Pointer:
+		var options string
+		if en.NilEmpty {
+			options = "NilEmpty"
+		}
+		if en.SubNode != nil {
+			options += en.SubNode.CompareOptions()
+		}
+		return options
+	case Interface:
+		// Interfaces with other implementations have functions of their own
+		if len(en.Implementations) == 0 {
+			return ""
+		}
+		types := make([]string, len(en.Implementations))
+		for i, impl := range en.Implementations {
+			types[i] = utils.Fqn(impl.TypeIn(""))
+		}
+		return "As" + strings.Join(types, "Or")
+	}
+	return ""
+}
+
+// DiffOptions returns the compare options of a node along with the options
+// only changing how its differences are reported, e.g. "Lcs", used in the
+// names of its Diff functions.
+func (en *TypeNode) DiffOptions() string {
+	switch en.Kind {
+	case Slice:
+		if options := en.CompareOptions(); options != "" {
+			return options
+		}
+		if en.SliceMoves {
+			return "LcsMoves"
+		}
+		if en.SliceLCS {
+			return "Lcs"
+		}
+	case Pointer:
+		var options string
+		if en.NilEmpty {
+			options = "NilEmpty"
+		}
+		if en.DiffFlattened {
+			options += "Embedded"
+		}
+		if en.SubNode != nil {
+			options += en.SubNode.DiffOptions()
+		}
+		return options
+	case Interface:
+		return en.CompareOptions()
+	}
+	return ""
+}
+
+// IsGenericDeclaration returns true if this node is a generic type
+// declaration, such as Pair[K comparable, V any].
+func (en *TypeNode) IsGenericDeclaration() bool {
+	return en.TypeArgs != "" && en.Type != "" && en.Kind != TypeParam && !en.IsInstantiated()
+}
+
+// GenericDeclaration returns the generic type declaration this node belongs
+// to, or nil when the node does not use type parameters.
+func (en *TypeNode) GenericDeclaration() *TypeNode {
+	if en.TypeArgs == "" {
+		return nil
+	}
+	for node := en; node != nil; node = node.UpNode {
+		if node.IsGenericDeclaration() {
+			return node
+		}
+	}
+	return nil
+}
+
 // Ctx holds information needed for code generation
 type Ctx struct {
 	PkgPath                                 string
@@ -103,17 +355,44 @@ type Ctx struct {
 	EqualImplementation                     string
 	InequalImplementation                   string
 	DiffImplementation                      string
+	MergeImplementation                     string
+	CloneImplementation                     string
+	MergePatchImplementation                string
+	ApplyDiffImplementation                 string
+	Merge3Implementation                    string
+	HashImplementation                      string
+	CompareImplementation                   string
 	EqualFuncName                           string
 	DiffFuncName                            string
+	MergeFuncName                           string
+	CloneFuncName                           string
+	MergePatchFuncName                      string
+	ApplyDiffFuncName                       string
+	Merge3FuncName                          string
+	HashFuncName                            string
+	CompareFuncName                         string
 	DiffElement                             string
 	ObjectKind                              string
 	Type                                    string
 	Imports                                 map[string]struct{}
 	Err                                     bool
 	DefinedType                             bool
+	TypeArgs                                string // Type parameters of generic receivers, e.g. "[K, V]"
+	Function                                bool   // True if a function is generated for a struct instead of a method
+	DiffKey                                 string // Name of the field in diff keys
 	SubCtxs                                 []*Ctx
 }
 
+// HasMethod returns true if the context generates a method of its type,
+// written to a dedicated file, rather than a function. Methods cannot be
+// declared on instances of generic types.
+func (c Ctx) HasMethod() bool {
+	if c.Function || strings.Contains(c.Type, "[") {
+		return false
+	}
+	return c.ObjectKind == KindToString(Struct) || c.DefinedType
+}
+
 // KindToString converts a Kind enum to a string
 func KindToString(kind Kind) string {
 	switch kind {
@@ -133,6 +412,8 @@ func KindToString(kind Kind) string {
 		return "Pointer"
 	case Func:
 		return "Func"
+	case TypeParam:
+		return "TypeParam"
 	}
 	return "Unknown"
 }
@@ -141,9 +422,11 @@ func KindToString(kind Kind) string {
 // for the given node, storing the result in ctx.
 func ApplyTemplateForEqual(node *TypeNode, ctx *Ctx, t *template.Template) {
 	args := GetTemplateDataFromSubNodeEqual(node, ctx)
+	declName, callName := GenericFuncNames(node, args[EqualFuncNameDataMap])
+	args[EqualFuncNameDataMap] = declName
 	sb := strings.Builder{}
 	t.Execute(&sb, args)
-	ctx.EqualFuncName = args[EqualFuncNameDataMap]
+	ctx.EqualFuncName = callName
 	ctx.EqualImplementation = sb.String()
 }
 
@@ -151,12 +434,38 @@ func ApplyTemplateForEqual(node *TypeNode, ctx *Ctx, t *template.Template) {
 // for the given node, storing the result in ctx.
 func ApplyTemplateForDiff(node *TypeNode, ctx *Ctx, t *template.Template) {
 	args := GetTemplateDataFromSubNodeDiff(node, ctx)
+	declName, callName := GenericFuncNames(node, args[DiffFuncNameDataMap])
+	args[DiffFuncNameDataMap] = declName
 	sb := strings.Builder{}
 	t.Execute(&sb, args)
-	ctx.DiffFuncName = args[DiffFuncNameDataMap]
+	ctx.DiffFuncName = callName
 	ctx.DiffImplementation = sb.String()
 }
 
+// ApplyTemplateForMerge applies a text/template to generate the Merge function
+// for the given node, storing the result in ctx.
+func ApplyTemplateForMerge(node *TypeNode, ctx *Ctx, t *template.Template) {
+	args := GetTemplateDataFromSubNodeMerge(node, ctx)
+	declName, callName := GenericFuncNames(node, args[MergeFuncNameDataMap])
+	args[MergeFuncNameDataMap] = declName
+	sb := strings.Builder{}
+	t.Execute(&sb, args)
+	ctx.MergeFuncName = callName
+	ctx.MergeImplementation = sb.String()
+}
+
+// ApplyTemplateForClone applies a text/template to generate the Clone function
+// for the given node, storing the result in ctx.
+func ApplyTemplateForClone(node *TypeNode, ctx *Ctx, t *template.Template) {
+	args := GetTemplateDataFromSubNodeClone(node, ctx)
+	declName, callName := GenericFuncNames(node, args[CloneFuncNameDataMap])
+	args[CloneFuncNameDataMap] = declName
+	sb := strings.Builder{}
+	t.Execute(&sb, args)
+	ctx.CloneFuncName = callName
+	ctx.CloneImplementation = sb.String()
+}
+
 // GetTemplateDataFromSubNodeEqual prepares template variables for generating Equal function
 func GetTemplateDataFromSubNodeEqual(node *TypeNode, ctx *Ctx) map[string]string {
 	var subValueEqual, subValueUnequal, subType string
@@ -175,7 +484,7 @@ func GetTemplateDataFromSubNodeEqual(node *TypeNode, ctx *Ctx) map[string]string
 		case equalFuncName != "":
 			subValueEqual = subCtx.EqualFuncName + "(" + ctx.LeftSideComparison + "," + ctx.RightSideComparison + ")"
 			subValueUnequal = "!" + subValueEqual
-		case equalFuncName == "" && node.Kind == Pointer:
+		case equalFuncName == "" && node.Kind == Pointer && node.SubNode.Kind != TypeParam:
 			subValueEqual = ctx.LeftSideComparison + " == " + ctx.RightSideComparison
 			subValueUnequal = ctx.LeftSideComparison + " != " + ctx.RightSideComparison
 		default:
@@ -184,14 +493,16 @@ func GetTemplateDataFromSubNodeEqual(node *TypeNode, ctx *Ctx) map[string]string
 		}
 	}
 	parameterType := GetTypeFromNode(node)
-	equalFuncName := utils.EqualFuncName(parameterType)
-	return map[string]string{
+	equalFuncName := utils.EqualFuncName(optionsFuncNameType(node, node.CompareOptions()))
+	args := map[string]string{
 		ParameterTypeDataMap:  parameterType,
 		EqualFuncNameDataMap:  equalFuncName,
 		EqualityTestDataMap:   subValueEqual,
 		InequalityTestDataMap: subValueUnequal,
 		SubTypeMap:            subType,
 	}
+	addCompareOptions(node, args)
+	return args
 }
 
 // GetTemplateDataFromSubNodeDiff prepares template variables for generating Diff function
@@ -214,18 +525,266 @@ func GetTemplateDataFromSubNodeDiff(node *TypeNode, ctx *Ctx) map[string]string
 	}
 	parameterType := GetTypeFromNode(node)
 	isBuiltinSubNodeMap := "false"
-	if node.SubNode != nil && node.SubNode.Kind == Builtin {
+	var subInequality string
+	if node.SubNode != nil && (node.SubNode.Kind == Builtin || node.SubNode.DiffScalar ||
+		((node.SubNode.Kind == TypeParam || node.SubNode.Opaque) && !node.SubNode.HasDiff)) {
 		isBuiltinSubNodeMap = "true"
+		x, y := "vx", "vy"
+		if node.Kind == Pointer {
+			x, y = "*x", "*y"
+		}
+		subInequality = Inequality(node.SubNode, x, y)
+		if node.SubNode.DiffScalar {
+			subInequality = "!slices.Equal(" + x + ", " + y + ")"
+		}
 	}
-	diffFuncName := utils.DiffFuncName(parameterType)
-	return map[string]string{
+	diffFuncName := utils.DiffFuncName(optionsFuncNameType(node, node.DiffOptions()))
+	args := map[string]string{
 		ParameterTypeDataMap: parameterType,
 		DiffFuncNameDataMap:  diffFuncName,
 		DiffElementMap:       subValueDiff,
-		NodeNameMap:          node.Name,
+		NodeNameMap:          node.DiffKey(),
 		IsBuiltinSubNodeMap:  isBuiltinSubNodeMap,
+		SubInequalityMap:     subInequality,
 		SubTypeMap:           subType,
+		ElemPrefixMap:        `key+"."+`,
+	}
+	// Diff keys of container, interface and pointer values start with their own separator
+	if node.SubNode != nil {
+		switch node.SubNode.Kind {
+		case Slice, Array, Map, Interface, Pointer:
+			args[ElemPrefixMap] = "key+"
+		}
+	}
+	addCompareOptions(node, args)
+	// Members of embedded pointers flattened into their struct are members
+	// of the struct in diff keys: nil pointers are diffed as pointers to
+	// zero values, as no key names them
+	if node.Kind == Pointer && node.DiffFlattened {
+		args[NilEmptyMap] = "true"
+		args[ElemPrefixMap] = ""
 	}
+	return args
+}
+
+// optionsFuncNameType returns the type name from which the names of the Equal
+// and Diff functions of a node are derived: values compared with options have
+// functions of their own.
+func optionsFuncNameType(node *TypeNode, options string) string {
+	if options != "" {
+		return options + " " + FuncNameType(node)
+	}
+	return FuncNameType(node)
+}
+
+// addCompareOptions adds the compare options of a node to the data of the
+// Equal and Diff templates.
+func addCompareOptions(node *TypeNode, args map[string]string) {
+	// Template functions are passed it, missing keys are not empty strings
+	args[ElemNilMap] = ""
+	if node.SubNode == nil {
+		return
+	}
+	args[ElemTypeMap] = GetTypeFromNode(node.SubNode)
+	if node.SubNode.Kind == Pointer {
+		args[ElemPointerMap] = "true"
+		args[ElemNilMap] = "(" + args[ElemTypeMap] + ")(nil)"
+	}
+	switch node.Kind {
+	case Slice:
+		args[SliceKeyMap] = node.SliceKey
+		args[SliceKeyNameMap] = node.SliceKeyName
+		if node.SliceKeyPointer {
+			args[SliceKeyPtrMap] = "true"
+		}
+		if node.SliceSet && node.SliceKey == "" {
+			args[SliceSetMap] = "true"
+		}
+		if node.SliceLCS && node.CompareOptions() == "" {
+			args[SliceLCSMap] = "true"
+			if node.SliceMoves {
+				args[SliceMovesMap] = "true"
+			}
+		}
+	case Pointer:
+		if node.NilEmpty {
+			args[NilEmptyMap] = "true"
+		}
+	}
+}
+
+// GetTemplateDataFromSubNodeMerge prepares template variables for generating Merge function
+func GetTemplateDataFromSubNodeMerge(node *TypeNode, ctx *Ctx) map[string]string {
+	var subValueMerge, subType string
+	if len(ctx.SubCtxs) == 1 {
+		subCtx := ctx.SubCtxs[0]
+		subType = subCtx.Type
+		mergeFuncName := subCtx.MergeFuncName
+		switch {
+		case (node.SubNode.HasMerge || mergeFuncName == "Merge") && node.Kind == Pointer:
+			subValueMerge = "(" + ctx.LeftSideComparison + ").Merge(" + ctx.RightSideComparison + ")"
+		case node.HasMerge || mergeFuncName == "Merge":
+			subValueMerge = ctx.LeftSideComparison + ".Merge(" + ctx.RightSideComparison + ")"
+		case mergeFuncName != "":
+			subValueMerge = subCtx.MergeFuncName + "(" + ctx.LeftSideComparison + "," + ctx.RightSideComparison + ")"
+		default:
+			subValueMerge = subCtx.MergeImplementation
+		}
+	}
+	parameterType := GetTypeFromNode(node)
+	isBuiltinSubNodeMap := "false"
+	var subZeroValue string
+	if node.SubNode != nil && node.SubNode.Kind == Builtin {
+		isBuiltinSubNodeMap = "true"
+		subZeroValue = ZeroValue(node.SubNode)
+	}
+	mergeFuncName := utils.MergeFuncName(FuncNameType(node))
+	return map[string]string{
+		ParameterTypeDataMap: parameterType,
+		MergeFuncNameDataMap: mergeFuncName,
+		MergeElementMap:      subValueMerge,
+		IsBuiltinSubNodeMap:  isBuiltinSubNodeMap,
+		SubZeroValueMap:      subZeroValue,
+		SubTypeMap:           subType,
+	}
+}
+
+// GetTemplateDataFromSubNodeClone prepares template variables for generating Clone function
+func GetTemplateDataFromSubNodeClone(node *TypeNode, ctx *Ctx) map[string]string {
+	var subValueClone, subType string
+	isValueSubNode := "false"
+	if len(ctx.SubCtxs) == 1 {
+		subCtx := ctx.SubCtxs[0]
+		subType = subCtx.Type
+		cloneFuncName := subCtx.CloneFuncName
+		switch {
+		case IsCopiedByValue(subCtx):
+			isValueSubNode = "true"
+			subValueClone = ctx.LeftSideComparison
+		case (node.SubNode.HasClone || cloneFuncName == "Clone") && node.Kind == Pointer:
+			subValueClone = "(" + ctx.LeftSideComparison + ").Clone()"
+		case node.SubNode.HasClone || cloneFuncName == "Clone":
+			subValueClone = ctx.LeftSideComparison + ".Clone()"
+		case cloneFuncName != "":
+			subValueClone = subCtx.CloneFuncName + "(" + ctx.LeftSideComparison + ")"
+		default:
+			subValueClone = subCtx.CloneImplementation
+		}
+	}
+	parameterType := GetTypeFromNode(node)
+	cloneFuncName := utils.CloneFuncName(FuncNameType(node))
+	return map[string]string{
+		ParameterTypeDataMap: parameterType,
+		CloneFuncNameDataMap: cloneFuncName,
+		CloneElementMap:      subValueClone,
+		IsValueSubNodeMap:    isValueSubNode,
+		SubTypeMap:           subType,
+	}
+}
+
+// IsCopiedByValue reports whether the values handled by a Clone generation
+// context are fully copied by an assignment: builtins, and containers of
+// builtins for which no helper was generated.
+func IsCopiedByValue(ctx *Ctx) bool {
+	if ctx.Err {
+		return false
+	}
+	if ctx.ObjectKind == KindToString(Builtin) && ctx.CloneFuncName == "Clone" {
+		return true
+	}
+	return ctx.CloneFuncName == "" && ctx.CloneImplementation == ""
+}
+
+// Equality returns the expression testing the equality of x and y, two values
+// of a builtin, type parameter or opaque struct node. Type parameters and
+// opaque structs are compared with their Equal method when they have one, with
+// == when they are comparable and with reflect.DeepEqual otherwise.
+func Equality(node *TypeNode, x, y string) string {
+	if node.Kind == TypeParam || node.Opaque {
+		switch {
+		case node.HasEqual:
+			if strings.HasPrefix(x, "*") {
+				x = "(" + x + ")"
+			}
+			return x + ".Equal(" + y + ")"
+		case !node.IsComparable:
+			return "reflect.DeepEqual(" + x + ", " + y + ")"
+		}
+	}
+	return x + " == " + y
+}
+
+// Inequality returns the negation of Equality.
+func Inequality(node *TypeNode, x, y string) string {
+	if (node.Kind == TypeParam || node.Opaque) && (node.HasEqual || !node.IsComparable) {
+		return "!" + Equality(node, x, y)
+	}
+	return x + " != " + y
+}
+
+// FuncNameType returns the type name from which the names of the functions
+// generated for a node are derived. Functions handling type parameters are
+// prefixed with the generic type declaring them, as the same parameter names
+// may be used with different constraints by other declarations, and functions
+// of generic types are named after the type without its parameters.
+// Functions dedicated to the field path of values holding overridden fields
+// are prefixed with that path.
+func FuncNameType(node *TypeNode) string {
+	if node.IsGenericDeclaration() {
+		return node.Type
+	}
+	parameterType := GetTypeFromNode(node)
+	if node.FieldOverrides && node.UpNode != nil {
+		return FieldPathName(node.FieldPath) + " " + parameterType
+	}
+	if decl := node.GenericDeclaration(); decl != nil && decl != node {
+		return decl.Type + " " + parameterType
+	}
+	return parameterType
+}
+
+// FieldPathName returns the field path without its import path, with
+// elements written as "Elem" (e.g. "Backend.ServersElem" for
+// "example.com/models.Backend.Servers[*]"), to be used in function names.
+func FieldPathName(fieldPath string) string {
+	fieldPath = fieldPath[strings.LastIndex(fieldPath, "/")+1:]
+	if i := strings.Index(fieldPath, "."); i != -1 {
+		fieldPath = fieldPath[i+1:]
+	}
+	return strings.ReplaceAll(fieldPath, "[*]", "Elem")
+}
+
+// GenericFuncNames returns the name used to declare a generated function and
+// the name used to call it. Functions handling type parameters declare all the
+// type parameters of their generic type and are called with explicit type
+// arguments, e.g. EqualPairSliceV[K comparable, V any] and EqualPairSliceV[K, V].
+func GenericFuncNames(node *TypeNode, name string) (string, string) {
+	if node.TypeParams == "" {
+		return name, name
+	}
+	return name + node.TypeParams, name + node.TypeArgs
+}
+
+// ZeroValue returns the literal of the zero value of a builtin type node,
+// usable for any type whose underlying type is that builtin
+func ZeroValue(node *TypeNode) string {
+	switch node.BuiltinKind {
+	case "string":
+		return `""`
+	case "bool":
+		return "false"
+	}
+	return "0"
+}
+
+// DiffResult returns the result type of generated Diff functions and the
+// statement declaring an empty result named diff. Typed results are slices of
+// eqdiff.Change, otherwise diffs are maps of [old, new] values.
+func DiffResult(typed bool) (string, string) {
+	if typed {
+		return "[]eqdiff.Change", "var diff []eqdiff.Change"
+	}
+	return "map[string][]interface{}", "diff := make(map[string][]interface{})"
 }
 
 // GetTypeFromNode returns the string representation of a type node
@@ -233,8 +792,12 @@ func GetTypeFromNode(node *TypeNode) string {
 	if node == nil {
 		return ""
 	}
-	if node.Type != "" && node.Kind != Struct {
-		return node.Type
+	var typeArgs string
+	if node.IsGenericDeclaration() {
+		typeArgs = node.TypeArgs
+	}
+	if node.Type != "" && node.Kind != Struct && node.Kind != Interface {
+		return node.Type + typeArgs
 	}
 	name := ""
 	switch node.Kind {
@@ -254,11 +817,17 @@ func GetTypeFromNode(node *TypeNode) string {
 		name += "*" + GetTypeFromNode(node.SubNode)
 	case Func:
 		name = "FuncIsForbidden" // placeholder for unsupported function type
+	case Interface:
+		if node.Type != "" && node.SamePkgAsReferer {
+			name = node.Type
+		} else {
+			name = strings.ReplaceAll(node.PackagedType, "interface {}", "interface{}")
+		}
 	case Struct:
 		if node.SamePkgAsReferer {
-			name = node.Type
+			name = node.Type + typeArgs
 		} else {
-			name = node.PackagedType
+			name = node.PackagedType + typeArgs
 		}
 	default:
 		name = node.Type
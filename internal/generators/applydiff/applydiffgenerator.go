@@ -0,0 +1,185 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package applydiff
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+type ApplyDiffCtx struct {
+	Overrides map[string]common.OverrideFuncs
+}
+
+// Generate generates the ApplyDiff method of a struct node, applying the diffs
+// returned by its Diff method to the receiver, and the ones of the structs
+// found in its fields. Values of other types are changed in place with the
+// functions of the eqdiff package.
+func Generate(node *data.TypeNode, ctx *data.Ctx, applyDiffCtx ApplyDiffCtx) {
+	if node == nil || node.Err || node.Kind != data.Struct {
+		return
+	}
+	ApplyDiffGeneratorStruct(node, ctx, applyDiffCtx)
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
+	}
+}
+
+// isReplaced reports whether the values of a node are set as a whole by the
+// changes of their diffs. Values of types with their own Diff method but no
+// ApplyDiff method, and values diffed with an override, are replaced as a
+// whole, as are builtin values, anonymous structs, structs with no field
+// accessible from other packages and slices of bytes diffed as whole values.
+func isReplaced(node *data.TypeNode, applyDiffCtx ApplyDiffCtx) bool {
+	if node.HasTypedApplyDiff {
+		return false
+	}
+	if node.HasTypedDiff || hasDiffOverride(node, applyDiffCtx) {
+		return true
+	}
+	switch node.Kind {
+	case data.Struct:
+		return node.Type == "" || node.Opaque
+	case data.Slice:
+		return node.DiffScalar
+	case data.Builtin, data.TypeParam, data.Func:
+		return true
+	}
+	return false
+}
+
+// isGenerated reports whether the ApplyDiff function of a struct node is
+// generated.
+func isGenerated(node *data.TypeNode, applyDiffCtx ApplyDiffCtx) bool {
+	return node != nil && node.Kind == data.Struct && !node.Err &&
+		!node.HasTypedApplyDiff && !isReplaced(node, applyDiffCtx)
+}
+
+// hasDiffOverride reports whether the values of a node are diffed with an
+// override.
+func hasDiffOverride(node *data.TypeNode, applyDiffCtx ApplyDiffCtx) bool {
+	nodeType := node.Type
+	if nodeType == "" {
+		if pkgAndType := strings.SplitN(node.PackagedType, ".", 2); len(pkgAndType) > 1 {
+			nodeType = pkgAndType[0]
+		}
+	}
+	override, found := common.LookupOverride(applyDiffCtx.Overrides, node, node.PkgPath+"."+nodeType)
+	return found && override.Diff != nil
+}
+
+// applyStatements returns the statements applying change to the addressable
+// value x of a node and returning the error.
+func applyStatements(node *data.TypeNode, x string, applyDiffCtx ApplyDiffCtx) string {
+	switch {
+	case node.HasTypedApplyDiff || (node.Kind == data.Struct && isGenerated(node, applyDiffCtx)):
+		return "return " + applyDiffCall(node, x, "change", applyDiffCtx) + "\n"
+	case isReplaced(node, applyDiffCtx):
+		// Diff functions of defined builtin types name their values
+		if node.Kind == data.Builtin && node.PkgPath != "" && node.IsForField() {
+			return "change.Path = \"\"\n" +
+				"return eqdiff.ApplyValue(" + addr(x) + ", change)\n"
+		}
+		return "return eqdiff.ApplyValue(" + addr(x) + ", change)\n"
+	}
+	switch node.Kind {
+	case data.Slice:
+		return "return eqdiff.ApplySlice(" + addr(x) + ", change, " + applyFunc(node.SubNode, applyDiffCtx) + ", " +
+			keyFunc(node) + ")\n"
+	case data.Array:
+		return "if change.Path == \"\" {\n" +
+			"return eqdiff.ApplyValue(" + addr(x) + ", change)\n}\n" +
+			"return eqdiff.ApplyArray(" + x + "[:], change, " + applyFunc(node.SubNode, applyDiffCtx) + ")\n"
+	case data.Map:
+		return "return eqdiff.ApplyMap(" + addr(x) + ", change, " + applyFunc(node.SubNode, applyDiffCtx) + ")\n"
+	case data.Pointer:
+		return "return eqdiff.ApplyPointer(" + addr(x) + ", change, " + applyFunc(node.SubNode, applyDiffCtx) + ")\n"
+	case data.Interface:
+		return "return eqdiff.ApplyInterface(" + addr(x) + ", change)\n"
+	}
+	return "return eqdiff.ApplyValue(" + addr(x) + ", change)\n"
+}
+
+// applyFunc returns the function applying changes to the values of a node,
+// passed to the functions of the eqdiff package applying changes to their
+// elements.
+func applyFunc(node *data.TypeNode, applyDiffCtx ApplyDiffCtx) string {
+	switch {
+	case node.Kind == data.Struct && isGenerated(node, applyDiffCtx) && node.GeneratesFunctions():
+		_, callName := data.GenericFuncNames(node, utils.ApplyDiffFuncName(data.FuncNameType(node)))
+		return callName
+	case isReplaced(node, applyDiffCtx):
+		return "eqdiff.ApplyValue[" + data.GetTypeFromNode(node) + "]"
+	}
+	return "func(v *" + data.GetTypeFromNode(node) + ", change eqdiff.Change) error {\n" +
+		applyStatements(node, "(*v)", applyDiffCtx) + "}"
+}
+
+// applyDiffCall returns the call of the ApplyDiff function of a node, applying
+// the change named change to the addressable value x.
+func applyDiffCall(node *data.TypeNode, x, change string, applyDiffCtx ApplyDiffCtx) string {
+	if !node.HasTypedApplyDiff && node.GeneratesFunctions() {
+		_, callName := data.GenericFuncNames(node, utils.ApplyDiffFuncName(data.FuncNameType(node)))
+		return callName + "(" + addr(x) + ", " + change + ")"
+	}
+	if x == "(*v)" {
+		x = "v"
+	}
+	return x + ".ApplyDiff([]eqdiff.Change{" + change + "})"
+}
+
+// embeddedApplyCall returns the call applying the change named embedded to
+// the members of the flattened embedded struct x of a field node, or "" if
+// its members cannot be changed one by one.
+func embeddedApplyCall(field *data.TypeNode, x string, applyDiffCtx ApplyDiffCtx) string {
+	switch {
+	case field.Kind == data.Pointer && field.SubNode != nil:
+		return "eqdiff.ApplyEmbedded(" + addr(x) + ", embedded, " + applyFunc(field.SubNode, applyDiffCtx) + ")"
+	case field.HasTypedApplyDiff || isGenerated(field, applyDiffCtx):
+		return applyDiffCall(field, x, "embedded", applyDiffCtx)
+	}
+	return ""
+}
+
+// keyFunc returns the function formatting the keys of the elements of a slice
+// node diffed with the key option, as its Diff function does, or nil.
+func keyFunc(node *data.TypeNode) string {
+	if node.SliceKey == "" {
+		return "nil"
+	}
+	var nilCheck string
+	if node.SubNode.Kind == data.Pointer {
+		nilCheck = "if v == nil {\nreturn \"<nil>\"\n}\n"
+	}
+	key := "v." + node.SliceKey
+	// Pointer keys match elements by the values they point to
+	if node.SliceKeyPointer {
+		nilCheck += "if " + key + " == nil {\nreturn \"<nil>\"\n}\n"
+		key = "*" + key
+	}
+	return "func(v " + data.GetTypeFromNode(node.SubNode) + ") string {\n" +
+		nilCheck + "return fmt.Sprint(" + key + ")\n}"
+}
+
+// addr returns the address of the addressable value x.
+func addr(x string) string {
+	if x == "(*v)" {
+		return "v"
+	}
+	return "&" + x
+}
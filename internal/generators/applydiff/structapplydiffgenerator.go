@@ -0,0 +1,134 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package applydiff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/generators/diff"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func ApplyDiffGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, applyDiffCtx ApplyDiffCtx) {
+	if node.HasTypedApplyDiff {
+		return
+	}
+
+	ctxApplyDiff := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "rec",
+		ApplyDiffFuncName:          "ApplyDiff",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxApplyDiff)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking a pointer to the value
+	// and a single change is generated instead.
+	if node.GeneratesFunctions() {
+		ctxApplyDiff.Function = true
+		_, ctxApplyDiff.ApplyDiffFuncName = data.GenericFuncNames(node, utils.ApplyDiffFuncName(data.FuncNameType(node)))
+	}
+
+	// Types already visited have no fields: their ApplyDiff method is
+	// generated where they were first parsed.
+	if len(node.Fields) == 0 {
+		return
+	}
+
+	ctxApplyDiff.Imports = map[string]struct{}{"fmt": {}, utils.EqdiffPkgPath: {}}
+	cases := strings.Builder{}
+	embedded := strings.Builder{}
+	for _, field := range node.Fields {
+		generateNested(field, ctxApplyDiff, applyDiffCtx)
+		if field.Err {
+			continue
+		}
+		// Fields are changed under the keys of their Diff functions, ignored
+		// fields and fields which cannot be diffed are skipped
+		ctxField := &data.Ctx{
+			LeftSideComparison:  ctxApplyDiff.LeftSideComparison,
+			RightSideComparison: "obj",
+		}
+		diff.Generate(field, ctxField, diff.DiffCtx{Overrides: applyDiffCtx.Overrides, Typed: true})
+		if len(ctxField.SubCtxs) != 1 || ctxField.SubCtxs[0].Err {
+			continue
+		}
+		for imp, marker := range ctxField.SubCtxs[0].Imports {
+			ctxApplyDiff.Imports[imp] = marker
+		}
+		// Members of flattened embedded structs have no key of their own:
+		// changes of unknown fields are applied to each struct in turn
+		if field.DiffFlattened {
+			call := embeddedApplyCall(field, ctxApplyDiff.LeftSideComparison+"."+field.Name, applyDiffCtx)
+			if call != "" {
+				embedded.WriteString("if err := " + call + "; !errors.Is(err, eqdiff.ErrUnknownField) {\n" +
+					"return err\n}\n")
+			}
+			continue
+		}
+		cases.WriteString("case " + strconv.Quote(field.DiffKey()) + ":\n" +
+			applyStatements(field, ctxApplyDiff.LeftSideComparison+"."+field.Name, applyDiffCtx))
+	}
+
+	implementation := strings.Builder{}
+	implementation.WriteString("if change.Path == \"\" {\n" +
+		"return eqdiff.ApplyValue(rec, change)\n}\n")
+	if embedded.Len() > 0 {
+		ctxApplyDiff.Imports["errors"] = struct{}{}
+		implementation.WriteString("embedded := change\n")
+	}
+	if cases.Len() == 0 {
+		implementation.WriteString("field, _, err := eqdiff.SplitField(change)\n")
+	} else {
+		implementation.WriteString("field, change, err := eqdiff.SplitField(change)\n")
+	}
+	implementation.WriteString("if err != nil {\nreturn err\n}\n")
+	if cases.Len() > 0 {
+		implementation.WriteString("switch field {\n" + cases.String() + "}\n")
+	}
+	implementation.WriteString(embedded.String())
+	implementation.WriteString("return fmt.Errorf(\"%w %q\", eqdiff.ErrUnknownField, field)")
+	ctxApplyDiff.ApplyDiffImplementation = implementation.String()
+	if node.GeneratesFunctions() {
+		declName, _ := data.GenericFuncNames(node, utils.ApplyDiffFuncName(data.FuncNameType(node)))
+		parameterType := data.GetTypeFromNode(node)
+		ctxApplyDiff.ApplyDiffImplementation = "func " + declName + "(rec *" + parameterType + ", change eqdiff.Change) error {\n" +
+			ctxApplyDiff.ApplyDiffImplementation + "\n}"
+		for imp, marker := range node.Imports {
+			ctxApplyDiff.Imports[imp] = marker
+		}
+	}
+	for _, subCtx := range ctxApplyDiff.SubCtxs {
+		for imp, marker := range subCtx.Imports {
+			ctxApplyDiff.Imports[imp] = marker
+		}
+	}
+}
+
+// generateNested generates the ApplyDiff functions of the structs held by a
+// field. They are generated for any struct having fields, even the ones only
+// found in slices, as the first node of a type met by the parser is the only
+// one holding its fields.
+func generateNested(field *data.TypeNode, ctx *data.Ctx, applyDiffCtx ApplyDiffCtx) {
+	for node := field; node != nil; node = node.SubNode {
+		if node.Kind == data.Struct && len(node.Fields) > 0 && isGenerated(node, applyDiffCtx) {
+			ApplyDiffGeneratorStruct(node, ctx, applyDiffCtx)
+		}
+	}
+}
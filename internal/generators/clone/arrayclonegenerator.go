@@ -0,0 +1,91 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clone
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+var cloneArrayTemplateTxt = `func {{.CloneFuncName}}(x {{.ParameterType}}) {{.ParameterType}} {
+	for i := range x {
+		x[i] = {{.CloneElement}}
+	}
+	return x
+}`
+
+var cloneArrayTemplate = template.Must(template.New("CloneArrayTemplate").Parse(cloneArrayTemplateTxt))
+
+func CloneGeneratorArray(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Type == "" || node.GeneratesFunctions() {
+		CloneGeneratorRawArray(node, ctx, cloneCtx)
+		return
+	}
+	CloneGeneratorDefinedArray(node, ctx, cloneCtx)
+}
+
+func CloneGeneratorDefinedArray(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Kind != data.Array {
+		// TODO log error
+	}
+	if CloneGeneratorForNodeWithClone(node, ctx) {
+		return
+	}
+
+	ctxClone := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		CloneFuncName:              "Clone",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	CloneGeneratorRawArray(node, ctxClone, cloneCtx)
+	ctxClone.Err = ctxClone.SubCtxs[0].Err
+	ctxClone.CloneImplementation = "x"
+	if subCtx := ctxClone.SubCtxs[0]; subCtx.CloneFuncName != "" {
+		ctxClone.CloneImplementation = subCtx.CloneFuncName + "(x)"
+	}
+}
+
+// CloneGeneratorRawArray generates a helper cloning the elements of the array.
+// Arrays of values copied by assignment need no helper.
+func CloneGeneratorRawArray(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Kind != data.Array {
+		// TODO log error
+	}
+	subNode := node.SubNode
+	if subNode == nil {
+		// TODO log error
+	}
+	ctxClone := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x[i]",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	Generate(subNode, ctxClone, cloneCtx)
+	ctxClone.Err = ctxClone.SubCtxs[0].Err
+	if data.IsCopiedByValue(ctxClone.SubCtxs[0]) {
+		return
+	}
+	data.ApplyTemplateForClone(node, ctxClone, cloneArrayTemplate)
+}
@@ -0,0 +1,62 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clone
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+func CloneGeneratorBuiltin(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.PkgPath == "" {
+		CloneGeneratorBuiltinRaw(node, ctx, cloneCtx)
+		return
+	}
+	CloneGeneratorBuiltinDefined(node, ctx, cloneCtx)
+}
+
+// CloneGeneratorBuiltinDefined generates a Clone method returning the receiver,
+// so every named type exposes Clone. Builtins are otherwise copied by value.
+func CloneGeneratorBuiltinDefined(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Kind != data.Builtin {
+		// TODO log error
+	}
+	if CloneGeneratorForNodeWithClone(node, ctx) {
+		return
+	}
+	ctxClone := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		CloneFuncName:              "Clone",
+		CloneImplementation:        "x",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+}
+
+// CloneGeneratorBuiltinRaw produces no code: builtin values are copied by
+// assignment.
+func CloneGeneratorBuiltinRaw(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	ctxClone := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		ObjectKind:                 data.KindToString(node.Kind),
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+}
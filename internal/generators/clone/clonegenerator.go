@@ -0,0 +1,105 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clone
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func Generate(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node == nil {
+		return
+	}
+	if node.Err {
+		return
+	}
+
+	nodeType := node.Type
+	if nodeType == "" {
+		pkgAndType := strings.SplitN(node.PackagedType, ".", 2)
+		if len(pkgAndType) > 1 {
+			nodeType = pkgAndType[0]
+		}
+	}
+	packagedType := node.PkgPath + "." + nodeType
+	override, hasOverride := common.LookupOverride(cloneCtx.Overrides, node, packagedType)
+	// Ignored fields are skipped by the functions of their struct
+	if hasOverride && override.Ignore && node.IsForField() {
+		return
+	}
+	if hasOverride && override.Clone != nil {
+		fn := override.Clone
+		ctxClone := &data.Ctx{
+			ObjectKind:                 data.KindToString(node.Kind),
+			ObjectNameToHaveGeneration: node.Name,
+			LeftSideComparison:         "x",
+			PkgPath:                    node.PkgPath,
+			Pkg:                        strings.Split(node.PackagedType, ".")[0],
+			Type:                       node.Type,
+		}
+		ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+		if node.UpNode == nil {
+			ctxClone.CloneFuncName = fn.Name
+			ctxClone.DefinedType = true
+			ctxClone.CloneImplementation = utils.ExtractPkg(fn.Pkg) + "." + fn.Name + "(x)"
+		} else {
+			ctxClone.CloneFuncName = utils.ExtractPkg(fn.Pkg) + "." + fn.Name
+		}
+
+		if ctxClone.Imports == nil {
+			ctxClone.Imports = make(map[string]struct{})
+		}
+		ctxClone.Imports[fn.Pkg] = struct{}{}
+		return
+	}
+
+	// Values of types declared in other packages keep using their own methods
+	if node.Foreign && CloneGeneratorForNodeWithClone(node, ctx) {
+		return
+	}
+	switch node.Kind {
+	case data.Struct:
+		// Structs with no field accessible from other packages are copied
+		// as a whole, as type parameters are
+		if node.Opaque {
+			CloneGeneratorTypeParam(node, ctx, cloneCtx)
+			break
+		}
+		CloneGeneratorStruct(node, ctx, cloneCtx)
+	case data.Builtin:
+		CloneGeneratorBuiltin(node, ctx, cloneCtx)
+	case data.Array:
+		CloneGeneratorArray(node, ctx, cloneCtx)
+	case data.Slice:
+		CloneGeneratorSlice(node, ctx, cloneCtx)
+	case data.Map:
+		CloneGeneratorMap(node, ctx, cloneCtx)
+	case data.Interface:
+		CloneGeneratorInterface(node, ctx, cloneCtx)
+	case data.Pointer:
+		CloneGeneratorPointer(node, ctx, cloneCtx)
+	case data.Func:
+		CloneGeneratorFunc(node, ctx, cloneCtx)
+	case data.TypeParam:
+		CloneGeneratorTypeParam(node, ctx, cloneCtx)
+	}
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
+	}
+}
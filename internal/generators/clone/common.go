@@ -0,0 +1,45 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clone
+
+import (
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+func CloneGeneratorForNodeWithClone(node *data.TypeNode, ctx *data.Ctx) bool {
+	if !node.HasClone {
+		return false
+	}
+	var cloneImplementation string
+	if node.IsForField() {
+		cloneImplementation = ctx.RightSideComparison + "." + node.Name + " = " +
+			ctx.LeftSideComparison + "." + node.Name + ".Clone()"
+	} else {
+		cloneImplementation = ctx.LeftSideComparison + ".Clone()"
+	}
+	ctxClone := &data.Ctx{
+		CloneImplementation:        cloneImplementation,
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		Imports:                    node.Imports,
+		DefinedType:                true,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	return true
+}
+
+type CloneCtx struct {
+	Overrides map[string]common.OverrideFuncs
+}
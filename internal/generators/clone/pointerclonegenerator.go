@@ -0,0 +1,88 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clone
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+var clonePointerTemplateTxt = `func {{.CloneFuncName}}(x {{.ParameterType}}) {{.ParameterType}} {
+	if x == nil {
+		return nil
+	}
+	clone := {{.CloneElement}}
+	return &clone
+}`
+
+var clonePointerTemplate = template.Must(template.New("ClonePointerTemplate").Parse(clonePointerTemplateTxt))
+
+func CloneGeneratorPointer(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Type == "" || node.GeneratesFunctions() {
+		CloneGeneratorPointerRawType(node, ctx, cloneCtx)
+		return
+	}
+	CloneGeneratorPointerDefinedType(node, ctx, cloneCtx)
+}
+
+func CloneGeneratorPointerDefinedType(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Kind != data.Pointer {
+		// TODO log error
+	}
+
+	if CloneGeneratorForNodeWithClone(node, ctx) {
+		return
+	}
+
+	ctxClone := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "*x",
+		CloneFuncName:              "Clone",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	CloneGeneratorPointerRawType(node, ctxClone, cloneCtx)
+	ctxClone.Err = ctxClone.SubCtxs[0].Err
+	ctxClone.CloneImplementation = ctxClone.SubCtxs[0].CloneFuncName + "(x)"
+}
+
+func CloneGeneratorPointerRawType(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Kind != data.Pointer {
+		// TODO log error
+	}
+	subNode := node.SubNode
+	if subNode == nil {
+		// TODO log error
+	}
+	ctxClone := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "*x",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+		Type:                       node.Type,
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	Generate(subNode, ctxClone, cloneCtx)
+	ctxClone.Err = ctxClone.SubCtxs[0].Err
+	data.ApplyTemplateForClone(node, ctxClone, clonePointerTemplate)
+}
@@ -0,0 +1,95 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clone
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+var cloneSliceRawTemplateTxt = `func {{.CloneFuncName}}(x {{.ParameterType}}) {{.ParameterType}} {
+	if x == nil {
+		return nil
+	}
+	clone := make({{.ParameterType}}, len(x))
+	{{ if  (eq .IsValueSubNode "true") }}
+	copy(clone, x)
+	{{ else }}
+	for i, vx := range x {
+		clone[i] = {{.CloneElement}}
+	}
+	{{ end }}
+	return clone
+}`
+
+var cloneSliceRawTemplate = template.Must(template.New("CloneSliceRawTemplate").Parse(cloneSliceRawTemplateTxt))
+
+func CloneGeneratorSlice(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Type == "" || node.GeneratesFunctions() {
+		CloneGeneratorSliceRawType(node, ctx, cloneCtx)
+		return
+	}
+	CloneGeneratorSliceDefinedType(node, ctx, cloneCtx)
+}
+
+func CloneGeneratorSliceRawType(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Kind != data.Slice {
+		// TODO log error
+	}
+	subNode := node.SubNode
+	if subNode == nil {
+		// TODO log error
+	}
+	ctxClone := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "vx",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+		Type:                       node.Type,
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	Generate(subNode, ctxClone, cloneCtx)
+	ctxClone.Err = ctxClone.SubCtxs[0].Err
+	data.ApplyTemplateForClone(node, ctxClone, cloneSliceRawTemplate)
+}
+
+func CloneGeneratorSliceDefinedType(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if node.Kind != data.Slice {
+		// TODO log error
+	}
+	if CloneGeneratorForNodeWithClone(node, ctx) {
+		return
+	}
+
+	ctxClone := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		CloneFuncName:              "Clone",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	CloneGeneratorSliceRawType(node, ctxClone, cloneCtx)
+	ctxClone.Err = ctxClone.SubCtxs[0].Err
+	ctxClone.CloneImplementation = ctxClone.SubCtxs[0].CloneFuncName + "(x)"
+}
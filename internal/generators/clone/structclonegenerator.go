@@ -0,0 +1,96 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clone
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func CloneGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, cloneCtx CloneCtx) {
+	if CloneGeneratorForNodeWithClone(node, ctx) {
+		return
+	}
+
+	ctxClone := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "rec",
+		RightSideComparison:        "clone",
+		CloneFuncName:              "Clone",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxClone)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the value as argument is
+	// generated instead.
+	if node.GeneratesFunctions() {
+		ctxClone.Function = true
+		ctxClone.LeftSideComparison = "x"
+		_, ctxClone.CloneFuncName = data.GenericFuncNames(node, utils.CloneFuncName(data.FuncNameType(node)))
+	}
+
+	// Types already visited have no fields: their Clone method is generated
+	// where they were first parsed.
+	if len(node.Fields) == 0 {
+		return
+	}
+	for _, field := range node.Fields {
+		Generate(field, ctxClone, cloneCtx)
+	}
+
+	ctxClone.Imports = map[string]struct{}{}
+
+	implementation := strings.Builder{}
+	// The shallow copy already holds every value copied by assignment, only
+	// the fields sharing memory with the receiver are cloned afterwards.
+	implementation.WriteString(ctxClone.RightSideComparison + " := " + ctxClone.LeftSideComparison)
+
+	for _, subCtx := range ctxClone.SubCtxs {
+		if subCtx.Err || data.IsCopiedByValue(subCtx) {
+			continue
+		}
+
+		for imp, marker := range subCtx.Imports {
+			ctxClone.Imports[imp] = marker
+		}
+
+		implementation.WriteString("\n")
+		field := subCtx.ObjectNameToHaveGeneration
+		switch {
+		case subCtx.CloneFuncName == "Clone":
+			implementation.WriteString(ctxClone.RightSideComparison + "." + field + " = " +
+				ctxClone.LeftSideComparison + "." + field + ".Clone()")
+		case subCtx.CloneFuncName != "":
+			implementation.WriteString(ctxClone.RightSideComparison + "." + field + " = " +
+				subCtx.CloneFuncName + "(" + ctxClone.LeftSideComparison + "." + field + ")")
+		default:
+			implementation.WriteString(subCtx.CloneImplementation)
+		}
+	}
+	ctxClone.CloneImplementation = implementation.String()
+	if node.GeneratesFunctions() {
+		declName, _ := data.GenericFuncNames(node, utils.CloneFuncName(data.FuncNameType(node)))
+		parameterType := data.GetTypeFromNode(node)
+		ctxClone.CloneImplementation = "func " + declName + "(x " + parameterType + ") " + parameterType + " {\n" +
+			ctxClone.CloneImplementation + "\nreturn clone\n}"
+		for imp, marker := range node.Imports {
+			ctxClone.Imports[imp] = marker
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package compare
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/generators/equal"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+type CompareCtx struct {
+	Overrides map[string]common.OverrideFuncs
+}
+
+// Generate generates the Compare method of a struct node, ordering values by
+// the fields compared by its Equal method, in their declaration order, and the
+// ones of the structs found in its fields. Values of other types are compared
+// with the functions of the cmp and eqdiff packages: pointers nil first,
+// slices and arrays lexicographically, and maps by their entries sorted by key.
+func Generate(node *data.TypeNode, ctx *data.Ctx, compareCtx CompareCtx) {
+	if node == nil || node.Err || node.Kind != data.Struct {
+		return
+	}
+	CompareGeneratorStruct(node, ctx, compareCtx)
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
+	}
+}
+
+// isGenerated reports whether the Compare function of a struct node is
+// generated.
+func isGenerated(node *data.TypeNode, compareCtx CompareCtx) bool {
+	return node != nil && node.Kind == data.Struct && !node.Err && node.Type != "" &&
+		!node.HasCompare && !node.HasEqual && !hasEqualOverride(node, compareCtx)
+}
+
+// hasEqualOverride reports whether the values of a node are compared with an
+// override.
+func hasEqualOverride(node *data.TypeNode, compareCtx CompareCtx) bool {
+	nodeType := node.Type
+	if nodeType == "" {
+		if pkgAndType := strings.SplitN(node.PackagedType, ".", 2); len(pkgAndType) > 1 {
+			nodeType = pkgAndType[0]
+		}
+	}
+	override, found := common.LookupOverride(compareCtx.Overrides, node, node.PkgPath+"."+nodeType)
+	return found && override.Equal != nil
+}
+
+// isComparedByEqual reports whether the Equal function of the struct holding
+// a field compares it: ignored fields and fields which cannot be compared are
+// skipped.
+func isComparedByEqual(field *data.TypeNode, compareCtx CompareCtx) bool {
+	ctxField := &data.Ctx{}
+	equal.Generate(field, ctxField, equal.EqualCtx{Overrides: compareCtx.Overrides})
+	return len(ctxField.SubCtxs) == 1 && !ctxField.SubCtxs[0].Err
+}
+
+// comparison returns the expression comparing the values x and y of a node,
+// or "" if they cannot be ordered consistently with their Equal function:
+// values compared with an override or with a hand-written Equal method but no
+// Compare method are equal. Values compared with reflect.DeepEqual are
+// ordered with eqdiff.CompareDeep.
+func comparison(node *data.TypeNode, x, y string, ctx *data.Ctx, compareCtx CompareCtx) string {
+	for imp, marker := range node.Imports {
+		ctx.Imports[imp] = marker
+	}
+	switch {
+	case hasEqualOverride(node, compareCtx):
+		return ""
+	case node.HasCompare:
+		return x + ".Compare(" + y + ")"
+	case node.HasEqual:
+		return ""
+	}
+	args := x + ", " + y
+	switch node.Kind {
+	case data.Builtin:
+		return builtinComparison(node, args, ctx)
+	case data.Struct:
+		if !isGenerated(node, compareCtx) {
+			return ""
+		}
+		if !node.GeneratesFunctions() {
+			return x + ".Compare(" + y + ")"
+		}
+		_, callName := data.GenericFuncNames(node, utils.CompareFuncName(data.FuncNameType(node)))
+		return callName + "(" + args + ")"
+	case data.Pointer:
+		if node.SubNode == nil {
+			return ""
+		}
+		// Nil pointers equal pointers to the zero value with the nilempty option
+		if node.NilEmpty {
+			return "eqdiff.CompareNilEmpty(" + args + ", " + compareFunc(node.SubNode, ctx, compareCtx) + ")"
+		}
+		return "eqdiff.ComparePointer(" + args + ", " + compareFunc(node.SubNode, ctx, compareCtx) + ")"
+	case data.Slice:
+		if node.SubNode == nil {
+			return ""
+		}
+		// Slices compared regardless of order are ordered regardless of order
+		if node.SliceSet || node.SliceKey != "" {
+			return "eqdiff.CompareSet(" + args + ", " + compareFunc(node.SubNode, ctx, compareCtx) + ")"
+		}
+		return "eqdiff.CompareSlice(" + args + ", " + compareFunc(node.SubNode, ctx, compareCtx) + ")"
+	case data.Array:
+		if node.SubNode == nil {
+			return ""
+		}
+		return "eqdiff.CompareSlice(" + x + "[:], " + y + "[:], " + compareFunc(node.SubNode, ctx, compareCtx) + ")"
+	case data.Map:
+		if node.SubNode == nil {
+			return ""
+		}
+		return "eqdiff.CompareMap(" + args + ", " + compareFunc(node.SubNode, ctx, compareCtx) + ")"
+	case data.Interface:
+		// Values are compared consistently with the reflect.DeepEqual
+		// fallback of Equal functions
+		if len(node.Implementations) == 0 {
+			return "eqdiff.CompareDeep(" + args + ")"
+		}
+		return "eqdiff.CompareInterface(" + args + ")"
+	case data.TypeParam:
+		if node.IsComparable {
+			return "eqdiff.CompareComparable(" + args + ")"
+		}
+		return "eqdiff.CompareDeep(" + args + ")"
+	}
+	return ""
+}
+
+// builtinComparison returns the expression comparing the values args of a
+// builtin node, with the function of the cmp or eqdiff package matching its
+// underlying kind. Channels and unsafe pointers have no stable order.
+func builtinComparison(node *data.TypeNode, args string, ctx *data.Ctx) string {
+	switch node.BuiltinKind {
+	case "bool":
+		return "eqdiff.CompareBool(" + args + ")"
+	case "complex64", "complex128":
+		return "eqdiff.CompareComplex(" + args + ")"
+	case "int", "int8", "int16", "int32", "int64", "rune",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte",
+		"float32", "float64", "string":
+		ctx.Imports["cmp"] = struct{}{}
+		return "cmp.Compare(" + args + ")"
+	}
+	return ""
+}
+
+// compareFunc returns the function comparing the values of a node, passed to
+// the functions of the eqdiff package comparing their elements, or nil if
+// they cannot be ordered.
+func compareFunc(node *data.TypeNode, ctx *data.Ctx, compareCtx CompareCtx) string {
+	expression := comparison(node, "x", "y", ctx, compareCtx)
+	parameterType := data.GetTypeFromNode(node)
+	switch {
+	case expression == "":
+		return "nil"
+	case node.Kind == data.Struct && (node.HasCompare || !node.GeneratesFunctions()):
+		// Method expression, taking the receiver as first argument
+		return parameterType + ".Compare"
+	case node.Kind == data.Struct:
+		_, callName := data.GenericFuncNames(node, utils.CompareFuncName(data.FuncNameType(node)))
+		return callName
+	}
+	return "func(x, y " + parameterType + ") int {\nreturn " + expression + "\n}"
+}
@@ -0,0 +1,100 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package compare
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func CompareGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, compareCtx CompareCtx) {
+	if node.HasCompare {
+		return
+	}
+
+	ctxCompare := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "rec",
+		RightSideComparison:        "obj",
+		CompareFuncName:            "Compare",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxCompare)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the values as arguments is
+	// generated instead.
+	if node.GeneratesFunctions() {
+		ctxCompare.Function = true
+		ctxCompare.LeftSideComparison, ctxCompare.RightSideComparison = "x", "y"
+		_, ctxCompare.CompareFuncName = data.GenericFuncNames(node, utils.CompareFuncName(data.FuncNameType(node)))
+	}
+
+	// Types already visited have no fields: their Compare method is generated
+	// where they were first parsed.
+	if len(node.Fields) == 0 {
+		return
+	}
+
+	ctxCompare.Imports = map[string]struct{}{utils.EqdiffPkgPath: {}}
+	implementation := strings.Builder{}
+	for _, field := range node.Fields {
+		generateNested(field, ctxCompare, compareCtx)
+		// Fields skipped by Equal are skipped too, for equal values to be
+		// ordered as equal
+		if field.Err || !isComparedByEqual(field, compareCtx) {
+			continue
+		}
+		expression := comparison(field, ctxCompare.LeftSideComparison+"."+field.Name,
+			ctxCompare.RightSideComparison+"."+field.Name, ctxCompare, compareCtx)
+		if expression != "" {
+			implementation.WriteString("if c := " + expression + "; c != 0 {\nreturn c\n}\n")
+		}
+	}
+	ctxCompare.CompareImplementation = strings.TrimSuffix(implementation.String(), "\n")
+	// The method is declared even if no field is compared, as the functions
+	// of the structs holding the type call it
+	if ctxCompare.CompareImplementation == "" {
+		ctxCompare.CompareImplementation = "// No field can be ordered consistently with Equal"
+	}
+	if node.GeneratesFunctions() {
+		declName, _ := data.GenericFuncNames(node, utils.CompareFuncName(data.FuncNameType(node)))
+		ctxCompare.CompareImplementation = "func " + declName + "(x, y " + data.GetTypeFromNode(node) + ") int {\n" +
+			ctxCompare.CompareImplementation + "\nreturn 0\n}"
+		for imp, marker := range node.Imports {
+			ctxCompare.Imports[imp] = marker
+		}
+	}
+	for _, subCtx := range ctxCompare.SubCtxs {
+		for imp, marker := range subCtx.Imports {
+			ctxCompare.Imports[imp] = marker
+		}
+	}
+}
+
+// generateNested generates the Compare functions of the structs held by a
+// field. They are generated for any struct having fields, even the ones only
+// found in slices, as the first node of a type met by the parser is the only
+// one holding its fields.
+func generateNested(field *data.TypeNode, ctx *data.Ctx, compareCtx CompareCtx) {
+	for node := field; node != nil; node = node.SubNode {
+		if node.Kind == data.Struct && len(node.Fields) > 0 && isGenerated(node, compareCtx) {
+			CompareGeneratorStruct(node, ctx, compareCtx)
+		}
+	}
+}
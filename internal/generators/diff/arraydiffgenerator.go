@@ -15,33 +15,30 @@ package diff
 
 import (
 	"strings"
-	"text/template"
 
 	"github.com/haproxytech/go-method-gen/internal/data"
 )
 
-var diffArrayTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) map[string][]interface{}  {
-	diff := make(map[string][]interface{})
+var diffArrayTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) {{ result }}  {
+	{{ init }}
 	for i, vx := range x {
-		key := fmt.Sprintf("[%d]{{ .SubType }}",i)
+		key := fmt.Sprintf("[%d]",i)
 		vy := y[i]
 		{{ if  (eq .IsBuiltinSubNode "true") }}
-		if vx != vy {
-			diff[key] = []interface{}{vx, vy}
+		if {{ .SubInequality }} {
+			{{ modified "key" "vx" "vy" }}
 		}
 		{{ else }}
-        for diffKey, diffValue := range {{.DiffElement}} {
-			diff[key+"."+diffKey]=diffValue
-		}
+		{{ nestedElem .ElemPrefix .DiffElement .ElemNil }}
 		{{ end }}
     }
     return diff
 }`
 
-var diffArrayTemplate = template.Must(template.New("DiffArrayTemplate").Parse(diffArrayTemplateTxt))
+var diffArrayTemplate = newDiffTemplate("DiffArrayTemplate", diffArrayTemplateTxt)
 
 func DiffGeneratorArray(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
-	if node.Type == "" {
+	if node.Type == "" || node.GeneratesFunctions() {
 		DiffGeneratorArrayRawType(node, ctx, diffCtx)
 		return
 	}
@@ -90,6 +87,7 @@ func DiffGeneratorArrayRawType(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffC
 		ObjectNameToHaveGeneration: node.Name,
 		LeftSideComparison:         "vx",
 		RightSideComparison:        "vy",
+		ObjectKind:                 data.KindToString(node.Kind),
 		Imports:                    node.Imports,
 	}
 	if ctxDiff.Imports == nil {
@@ -100,5 +98,5 @@ func DiffGeneratorArrayRawType(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffC
 	if subNode.Kind != data.Builtin {
 		Generate(subNode, ctxDiff, diffCtx)
 	}
-	data.ApplyTemplateForDiff(node, ctxDiff, diffArrayTemplate)
+	data.ApplyTemplateForDiff(node, ctxDiff, withDiffFuncs(diffArrayTemplate, diffCtx))
 }
@@ -16,17 +16,16 @@ package diff
 import (
 	"fmt"
 	"strings"
-	"text/template"
 
 	"github.com/haproxytech/go-method-gen/internal/data"
 	"github.com/haproxytech/go-method-gen/internal/utils"
 )
 
 var builtinDiffTemplateTxt = `if {{ .LeftSideComparison }}.{{ .FieldName }} != {{ .RightSideComparison }}.{{ .FieldName }} {
-	diff["{{ .FieldName }}"] = []interface{}{ {{ .LeftSideComparison }}.{{ .FieldName }}, {{ .RightSideComparison }}.{{ .FieldName }} }
+	{{ modified (printf "%q" .DiffKey) (printf "%s.%s" .LeftSideComparison .FieldName) (printf "%s.%s" .RightSideComparison .FieldName) }}
 }`
 
-var diffBuiltinTemplate = template.Must(template.New("DiffBuiltinTemplate").Parse(builtinDiffTemplateTxt))
+var diffBuiltinTemplate = newDiffTemplate("DiffBuiltinTemplate", builtinDiffTemplateTxt)
 
 func DiffGeneratorBuiltin(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 	if node.PkgPath == "" {
@@ -80,13 +79,14 @@ func DiffGeneratorDefinedBuiltin(node *data.TypeNode, ctx *data.Ctx, diffCtx Dif
 		name = node.Name
 	}
 	ctxDiff.SubCtxs = append(ctxDiff.SubCtxs, ctxDiffImpl)
-	ctxDiffImpl.DiffImplementation = fmt.Sprintf(`func %s (x, y %s) map[string][]interface{} {
-		diff := make(map[string][]interface{})
+	resultType, resultInit := data.DiffResult(diffCtx.Typed)
+	ctxDiffImpl.DiffImplementation = fmt.Sprintf(`func %s (x, y %s) %s {
+		%s
 		if x != y {
-			diff["%s"] = []interface{}{x, y}
+			%s
 		}
 		return diff
-}`, diffFuncName, parameterType, name)
+}`, diffFuncName, parameterType, resultType, resultInit, diffCtx.modified(`"`+name+`"`, "x", "y"))
 }
 
 func DiffGeneratorBuiltinRaw(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
@@ -95,8 +95,9 @@ func DiffGeneratorBuiltinRaw(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx
 		"LeftSideComparison":  ctx.LeftSideComparison,
 		"RightSideComparison": ctx.RightSideComparison,
 		"FieldName":           node.Name,
+		"DiffKey":             node.DiffKey(),
 	}
-	diffBuiltinTemplate.Execute(&diffImplementation, args)
+	withDiffFuncs(diffBuiltinTemplate, diffCtx).Execute(&diffImplementation, args)
 
 	ctxDiff := &data.Ctx{
 		DiffImplementation:         diffImplementation.String(),
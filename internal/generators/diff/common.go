@@ -14,6 +14,8 @@
 package diff
 
 import (
+	"text/template"
+
 	"github.com/haproxytech/go-method-gen/internal/common"
 	"github.com/haproxytech/go-method-gen/internal/data"
 )
@@ -40,4 +42,153 @@ func DiffGeneratorForNodeWithDiff(node *data.TypeNode, ctx *data.Ctx) bool {
 
 type DiffCtx struct {
 	Overrides map[string]common.OverrideFuncs
+	Typed     bool // Generate []eqdiff.Change results instead of map[string][]interface{}
+}
+
+// resultInit returns the statement declaring an empty diff result.
+func (d DiffCtx) resultInit() string {
+	_, init := data.DiffResult(d.Typed)
+	return init
+}
+
+// change returns the statement recording a change of kind op ("Added",
+// "Removed" or "Modified") at the path key, where key, old and new are Go
+// expressions. Map results only keep the old and new values.
+func (d DiffCtx) change(op, key, old, new string) string {
+	if d.Typed {
+		return "diff = append(diff, eqdiff.Change{Path: " + key + ", Op: eqdiff." + op +
+			", Old: " + old + ", New: " + new + "})"
+	}
+	return "diff[" + key + "] = []interface{}{" + old + ", " + new + "}"
+}
+
+func (d DiffCtx) modified(key, old, new string) string {
+	return d.change("Modified", key, old, new)
+}
+
+func (d DiffCtx) added(key, new string) string {
+	return d.change("Added", key, "nil", new)
+}
+
+func (d DiffCtx) removed(key, old string) string {
+	return d.change("Removed", key, old, "nil")
+}
+
+// addedAt returns the statement recording a value added at the position key
+// of its slice. Positions in the receiver and in the compared object may
+// coincide in map results: a removal and an addition at the same position are
+// then combined into one [old, new] change.
+func (d DiffCtx) addedAt(key, new string) string {
+	if d.Typed {
+		return d.added(key, new)
+	}
+	return "if change, found := diff[" + key + "]; found {\n" +
+		"\tchange[1] = " + new + "\n" +
+		"} else {\n" +
+		"\tdiff[" + key + "] = []interface{}{nil, " + new + "}\n}"
+}
+
+// removedAt returns the statement recording a value removed at the position
+// key of its slice, combined with an addition at the same position in map
+// results as addedAt does.
+func (d DiffCtx) removedAt(key, old string) string {
+	if d.Typed {
+		return d.removed(key, old)
+	}
+	return "if change, found := diff[" + key + "]; found {\n" +
+		"\tchange[0] = " + old + "\n" +
+		"} else {\n" +
+		"\tdiff[" + key + "] = []interface{}{" + old + ", nil}\n}"
+}
+
+// moved returns the statement recording a value moved from the path from to
+// the path key. Moves are only reported by typed results.
+func (d DiffCtx) moved(key, from, old, new string) string {
+	return "diff = append(diff, eqdiff.Change{Path: " + key + ", Op: eqdiff.Moved" +
+		", Old: " + old + ", New: " + new + ", From: " + from + "})"
+}
+
+// nested returns the loop copying the result of the diff call into diff, with
+// each path (and the origin of moves) prefixed by prefix, a Go string
+// expression ending with '+' (or empty for no prefix).
+func (d DiffCtx) nested(prefix, call string) string {
+	if d.Typed {
+		if prefix == "" {
+			return "diff = append(diff, " + call + "...)"
+		}
+		return "for _, change := range " + call + " {\n" +
+			"\tchange.Path = " + prefix + "change.Path\n" +
+			"\tif change.From != \"\" {\n" +
+			"\t\tchange.From = " + prefix + "change.From\n\t}\n" +
+			"\tdiff = append(diff, change)\n}"
+	}
+	return "for diffKey, diffValue := range " + call + " {\n" +
+		"\tdiff[" + prefix + "diffKey] = diffValue\n}"
+}
+
+// nestedElem returns the loop copying the result of the diff call of an
+// element, or pointed value, found on both sides into diff, as nested does.
+// Typed changes of the whole element, such as a nil pointer being set, modify
+// the element rather than add or remove it. Elements which are pointers set
+// to nil get elemNil as new value, the typed nil value of their type, for the
+// change not to be mistaken for one of the value they point to. Paths are not
+// prefixed if prefix is empty, as for the members of flattened embedded
+// structs.
+func (d DiffCtx) nestedElem(prefix, call, elemNil string) string {
+	if !d.Typed {
+		return d.nested(prefix, call)
+	}
+	var typedNil string
+	if elemNil != "" {
+		typedNil = "\t\tif change.New == nil {\n" +
+			"\t\t\tchange.New = " + elemNil + "\n\t\t}\n"
+	}
+	var prefixed string
+	if prefix != "" {
+		prefixed = "\tchange.Path = " + prefix + "change.Path\n" +
+			"\tif change.From != \"\" {\n" +
+			"\t\tchange.From = " + prefix + "change.From\n\t}\n"
+	}
+	return "for _, change := range " + call + " {\n" +
+		"\tif change.Path == \"\" {\n" +
+		"\t\tchange.Op = eqdiff.Modified\n" + typedNil + "\t}\n" +
+		prefixed +
+		"\tdiff = append(diff, change)\n}"
+}
+
+// diffFuncs are the template functions emitting diff results. Templates are
+// parsed with the map flavor and cloned with withDiffFuncs before execution.
+func diffFuncs(d DiffCtx) template.FuncMap {
+	result, init := data.DiffResult(d.Typed)
+	return template.FuncMap{
+		"typed":      func() bool { return d.Typed },
+		"result":     func() string { return result },
+		"init":       func() string { return init },
+		"modified":   d.modified,
+		"added":      d.added,
+		"removed":    d.removed,
+		"addedAt":    d.addedAt,
+		"removedAt":  d.removedAt,
+		"moved":      d.moved,
+		"nested":     d.nested,
+		"nestedElem": d.nestedElem,
+	}
+}
+
+// newDiffTemplate parses a diff template text using the diff template functions.
+func newDiffTemplate(name, text string) *template.Template {
+	return template.Must(template.New(name).Funcs(diffFuncs(DiffCtx{})).Parse(text))
+}
+
+// withDiffFuncs returns a copy of t emitting results as configured in diffCtx.
+func withDiffFuncs(t *template.Template, diffCtx DiffCtx) *template.Template {
+	return template.Must(t.Clone()).Funcs(diffFuncs(diffCtx))
+}
+
+// addImport records imp in the imports of ctx.
+func addImport(ctx *data.Ctx, imp string) {
+	if ctx.Imports == nil {
+		ctx.Imports = make(map[string]struct{})
+	}
+	ctx.Imports[imp] = struct{}{}
 }
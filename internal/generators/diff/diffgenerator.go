@@ -16,6 +16,7 @@ package diff
 import (
 	"strings"
 
+	"github.com/haproxytech/go-method-gen/internal/common"
 	"github.com/haproxytech/go-method-gen/internal/data"
 	"github.com/haproxytech/go-method-gen/internal/utils"
 )
@@ -27,6 +28,19 @@ func Generate(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 	if node.Err {
 		return
 	}
+	if diffCtx.Typed {
+		// Only Diff methods returning []eqdiff.Change can be reused by typed diffs
+		node.HasDiff = node.HasTypedDiff
+	}
+	numSubCtxs := len(ctx.SubCtxs)
+	defer func() {
+		if len(ctx.SubCtxs) > numSubCtxs {
+			ctx.SubCtxs[numSubCtxs].DiffKey = node.DiffKey()
+		}
+		if diffCtx.Typed && len(ctx.SubCtxs) > numSubCtxs {
+			addImport(ctx.SubCtxs[numSubCtxs], utils.EqdiffPkgPath)
+		}
+	}()
 
 	nodeType := node.Type
 	if nodeType == "" {
@@ -36,7 +50,15 @@ func Generate(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 		}
 	}
 	packagedType := node.PkgPath + "." + nodeType
-	override, hasOverride := diffCtx.Overrides[packagedType]
+	override, hasOverride := common.LookupOverride(diffCtx.Overrides, node, packagedType)
+	// Ignored fields are skipped by the functions of their struct
+	if hasOverride && override.Ignore && node.IsForField() {
+		return
+	}
+	// Fields left out of diffs by their diff name tag are skipped as well
+	if node.DiffSkipped && node.IsForField() {
+		return
+	}
 
 	if hasOverride && override.Diff != nil {
 		fn := override.Diff
@@ -52,21 +74,32 @@ func Generate(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 		ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
 		if node.UpNode == nil {
 			ctxDiff.DiffFuncName = fn.Name
-			ctxDiff.DiffImplementation = "for diffKey, diffValue:= range " +
-				utils.ExtractPkg(fn.Pkg) + "." + fn.Name + "(rec, obj)" + "{\n" +
-				"\tdiff[\"" + node.Type + "\"] = diffValue\n}"
+			if diffCtx.Typed {
+				ctxDiff.DiffImplementation = diffCtx.nested("", utils.ExtractPkg(fn.Pkg)+"."+fn.Name+"(rec, obj)")
+			} else {
+				ctxDiff.DiffImplementation = "for diffKey, diffValue:= range " +
+					utils.ExtractPkg(fn.Pkg) + "." + fn.Name + "(rec, obj)" + "{\n" +
+					"\tdiff[\"" + node.Type + "\"] = diffValue\n}"
+			}
 		} else {
 			ctxDiff.DiffFuncName = utils.ExtractPkg(fn.Pkg) + "." + fn.Name
 		}
 
-		if ctxDiff.Imports == nil {
-			ctxDiff.Imports = make(map[string]struct{})
-		}
-		ctxDiff.Imports[fn.Pkg] = struct{}{}
+		addImport(ctxDiff, fn.Pkg)
+		return
+	}
+	// Values of types declared in other packages keep using their own methods
+	if node.Foreign && DiffGeneratorForNodeWithDiff(node, ctx) {
 		return
 	}
 	switch node.Kind {
 	case data.Struct:
+		// Structs with no field accessible from other packages are diffed
+		// as a whole, as type parameters are
+		if node.Opaque {
+			DiffGeneratorTypeParam(node, ctx, diffCtx)
+			break
+		}
 		DiffGeneratorStruct(node, ctx, diffCtx)
 	case data.Builtin:
 		DiffGeneratorBuiltin(node, ctx, diffCtx)
@@ -82,5 +115,11 @@ func Generate(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 		DiffGeneratorPointer(node, ctx, diffCtx)
 	case data.Func:
 		DiffGeneratorFunc(node, ctx, diffCtx)
+	case data.TypeParam:
+		DiffGeneratorTypeParam(node, ctx, diffCtx)
+	}
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
 	}
 }
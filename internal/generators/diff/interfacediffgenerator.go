@@ -13,12 +13,75 @@
 // limitations under the License.
 package diff
 
-import "github.com/haproxytech/go-method-gen/internal/data"
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// Values of interfaces holding the same implementation are dispatched to its
+// Diff method, their keys start with "." to be appended to the key of the
+// value. Other values are compared with reflect and reported under the key of
+// the value, as for builtin values.
+const diffInterfaceTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) {{ result }} {
+	{{ init }}
+	{{- if .Cases }}
+	switch vx := x.(type) {
+	{{- range .Cases }}
+	case {{ .Type }}:
+		{{- if .Builtin }}
+		if vy, ok := y.({{ .Type }}); ok {
+			if {{ .Inequality }} {
+				{{ modified "\"\"" "x" "y" }}
+			}
+			return diff
+		}
+		{{- else if .Pointer }}
+		if vy, ok := y.({{ .Type }}); ok && vx != nil && vy != nil {
+			{{ nested "\".\"+" "vx.Diff(*vy)" }}
+			return diff
+		}
+		{{- else }}
+		if vy, ok := y.({{ .Type }}); ok {
+			{{ nested "\".\"+" "vx.Diff(vy)" }}
+			return diff
+		}
+		{{- end }}
+	{{- end }}
+	}
+	{{- end }}
+	if !reflect.DeepEqual(x, y) {
+		switch {
+		case x == nil:
+			{{ added "\"\"" "y" }}
+		case y == nil:
+			{{ removed "\"\"" "x" }}
+		default:
+			{{ modified "\"\"" "x" "y" }}
+		}
+	}
+	return diff
+}`
+
+var diffInterfaceTemplate = newDiffTemplate("DiffInterfaceTemplate", diffInterfaceTemplateTxt)
+
+// interfaceCase is a case of the type switch of the functions diffing
+// interface values, for one implementation.
+type interfaceCase struct {
+	Type       string // Type of the implementation in the package of the function
+	Builtin    bool   // True for builtin types, compared with Inequality
+	Pointer    bool   // True if values are pointers to the type
+	Inequality string // Expression testing the inequality of builtin vx and vy
+}
 
 func DiffGeneratorInterface(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 	if node.Kind != data.Interface {
 		// TODO log error
 	}
+	if !node.Err {
+		DiffGeneratorInterfaceImplementations(node, ctx, diffCtx)
+		return
+	}
 
 	var equalImplementation, unequalImplementation string
 	if node.IsForType() {
@@ -37,3 +100,41 @@ func DiffGeneratorInterface(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx)
 	}
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
 }
+
+// DiffGeneratorInterfaceImplementations generates the function diffing the
+// values of an interface whose implementations, if any, are registered.
+func DiffGeneratorInterfaceImplementations(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
+	ctxDiff := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		RightSideComparison:        "y",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
+
+	cases := make([]interfaceCase, len(node.Implementations))
+	for i, impl := range node.Implementations {
+		cases[i] = interfaceCase{
+			Type:       impl.TypeIn(node.RefererPkgPath),
+			Builtin:    impl.PkgPath == "",
+			Pointer:    impl.Pointer,
+			Inequality: "vx != vy",
+		}
+		if impl.Pointer {
+			cases[i].Inequality = "vx != vy && (vx == nil || vy == nil || *vx != *vy)"
+		}
+	}
+	args := data.GetTemplateDataFromSubNodeDiff(node, ctxDiff)
+	declName, callName := data.GenericFuncNames(node, args[data.DiffFuncNameDataMap])
+	var sb strings.Builder
+	withDiffFuncs(diffInterfaceTemplate, diffCtx).Execute(&sb, map[string]interface{}{
+		data.DiffFuncNameDataMap:  declName,
+		data.ParameterTypeDataMap: args[data.ParameterTypeDataMap],
+		"Cases":                   cases,
+	})
+	ctxDiff.DiffFuncName = callName
+	ctxDiff.DiffImplementation = sb.String()
+}
@@ -15,13 +15,12 @@ package diff
 
 import (
 	"strings"
-	"text/template"
 
 	"github.com/haproxytech/go-method-gen/internal/data"
 )
 
-const diffMapRawTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) map[string][]interface{}  {
-	diff := make(map[string][]interface{})
+const diffMapRawTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) {{ result }}  {
+	{{ init }}
 ` + diffMapDefinedTemplateTxt + `
 }`
 
@@ -30,51 +29,84 @@ const diffMapDefinedTemplateTxt = `if (x == nil && y == nil) || (len(x) ==0 && l
 	}
 
 	if x == nil {
+		{{ if typed }}
+		{{ added "\"\"" "y" }}
+		return diff
+		{{ else }}
 		return map[string][]interface{}{"": {nil, y}}
+		{{ end }}
 	}
 
 	if y == nil {
+		{{ if typed }}
+		{{ removed "\"\"" "x" }}
+		return diff
+		{{ else }}
 		return map[string][]interface{}{"": {x, nil}}
+		{{ end }}
 	}
-
+	{{ if typed }}
+	for kx,vx := range x {
+		key := fmt.Sprintf("[%v]",kx)
+		vy, found := y[kx]
+		if !found {
+			{{ removed "key" "vx" }}
+			continue
+		}
+		{{ if  (eq .IsBuiltinSubNode "true") }}
+		if {{ .SubInequality }} {
+			{{ modified "key" "vx" "vy" }}
+		}
+		{{ else }}
+		{{ nestedElem .ElemPrefix .DiffElement .ElemNil }}
+		{{ end }}
+	}
+	for ky,vy := range y {
+		if _, found := x[ky]; found {
+			continue
+		}
+		key := fmt.Sprintf("[%v]",ky)
+		{{ added "key" "vy" }}
+	}
+	{{ else }}
 	for kx,vx := range x {
 		key := fmt.Sprintf("[%v]",kx)
 		vy := y[kx]
 		{{ if  (eq .IsBuiltinSubNode "true") }}
-		if vx != vy {
+		if {{ .SubInequality }} {
 			diff[key] = []interface{}{vx, vy}
 		}
 		{{ else }}
 		for diffKey, diffValue := range {{.DiffElement}} {
-			diff[key+"."+diffKey]=diffValue
+			diff[{{ .ElemPrefix }}diffKey]=diffValue
 		}
 		{{ end }}
 
 	}
 	for ky,vy := range y {
-		key := fmt.Sprintf("[%v]",ky)
-		if _,found := diff[key]; found {
+		if _, found := x[ky]; found {
 			continue
 		}
-
+		key := fmt.Sprintf("[%v]",ky)
 		vx := x[ky]
 		{{ if  (eq .IsBuiltinSubNode "true") }}
-		if vx != vy {
+		if {{ .SubInequality }} {
 			diff[key] = []interface{}{vx, vy}
 		}
 		{{ else }}
 		for diffKey, diffValue := range {{.DiffElement}} {
-			diff[key+"."+diffKey]= []interface{}{ diffValue[1], diffValue[0]}
+			diff[{{ .ElemPrefix }}diffKey]=diffValue
 		}
 		{{ end }}
 
 	}
+	{{- end }}
     return diff`
 
-var diffMapRawTemplate = template.Must(template.New("DiffMapRawTemplate").Parse(diffMapRawTemplateTxt))
+var diffMapRawTemplate = newDiffTemplate("DiffMapRawTemplate", diffMapRawTemplateTxt)
 
 func DiffGeneratorMap(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
-	if node.Type == "" {
+	if node.Type == "" || node.GeneratesFunctions() {
 		DiffGeneratorRawMap(node, ctx, diffCtx)
 		return
 	}
@@ -103,7 +135,7 @@ func DiffGeneratorRawMap(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
 	Generate(subNode, ctxDiff, diffCtx)
 	ctxDiff.Err = ctxDiff.SubCtxs[0].Err
-	data.ApplyTemplateForDiff(node, ctxDiff, diffMapRawTemplate)
+	data.ApplyTemplateForDiff(node, ctxDiff, withDiffFuncs(diffMapRawTemplate, diffCtx))
 }
 
 func DiffGeneratorDefinedMap(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
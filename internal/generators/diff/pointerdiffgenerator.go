@@ -15,48 +15,53 @@ package diff
 
 import (
 	"strings"
-	"text/template"
 
 	"github.com/haproxytech/go-method-gen/internal/data"
 )
 
-const diffPointerRawTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) map[string][]interface{}  {
-	diff := make(map[string][]interface{})
+const diffPointerRawTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) {{ result }}  {
+	{{ init }}
 ` + diffPointerDefinedTemplateTxt + `
 }`
 
 const diffPointerDefinedTemplateTxt = `if x == nil && y == nil {
 		return diff
 	}
-	{{ if .NodeName}}
-	key := "{{ .NodeName }}"
+	{{- if or .ElemPrefix (eq .IsBuiltinSubNode "true") (not .NilEmpty) }}
+	key := ""
+	{{- end }}
+	{{ if .NilEmpty }}
+	if x == nil {
+		x = new({{ .ElemType }})
+	}
+	if y == nil {
+		y = new({{ .ElemType }})
+	}
 	{{ else }}
-	key := "*{{ .SubType }}"
-	{{ end }}
 	switch {
 	case x == nil:
-		diff[key] = []interface{}{x, *y}
+		{{ if typed }}{{ added "key" "*y" }}{{ else }}diff[key] = []interface{}{x, *y}{{ end }}
 		return diff
 	case y == nil:
-		diff[key] = []interface{}{*x, y}
+		{{ if typed }}{{ removed "key" "*x" }}{{ else }}diff[key] = []interface{}{*x, y}{{ end }}
 		return diff
 	}
+	{{ end }}
 
 	{{ if  (eq .IsBuiltinSubNode "true") }}
-	if *x != *y{
-		diff[key] = []interface{}{x, y}
+	if {{ .SubInequality }} {
+		{{ if typed }}{{ modified "key" "*x" "*y" }}{{ else }}diff[key] = []interface{}{x, y}{{ end }}
 	}
 	{{ else }}
-	for diffKey, diffValue := range {{.DiffElement}} {
-		diff[key+"."+diffKey]=diffValue
-	}
+	{{ nestedElem .ElemPrefix .DiffElement .ElemNil }}
 	{{ end }}
 	return diff`
 
-var diffPointerRawTemplate = template.Must(template.New("DiffPointerRawTemplate").Parse(diffPointerRawTemplateTxt))
+var diffPointerRawTemplate = newDiffTemplate("DiffPointerRawTemplate", diffPointerRawTemplateTxt)
 
 func DiffGeneratorPointer(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
-	if node.Type == "" {
+	// Pointers compared with options of their field have functions of their own
+	if node.Type == "" || node.GeneratesFunctions() || node.FieldOptions {
 		DiffGeneratorRawPointer(node, ctx, diffCtx)
 		return
 	}
@@ -85,7 +90,7 @@ func DiffGeneratorDefinedPointer(node *data.TypeNode, ctx *data.Ctx, diffCtx Dif
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
 	DiffGeneratorRawPointer(node, ctxDiff, diffCtx)
 	ctxDiff.Err = ctxDiff.SubCtxs[0].Err
-	data.ApplyTemplateForDiff(node, ctxDiff, diffPointerRawTemplate)
+	data.ApplyTemplateForDiff(node, ctxDiff, withDiffFuncs(diffPointerRawTemplate, diffCtx))
 	ctxDiff.DiffImplementation = ctxDiff.SubCtxs[0].DiffFuncName + "(x, y)"
 }
 
@@ -107,5 +112,5 @@ func DiffGeneratorRawPointer(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
 	Generate(subNode, ctxDiff, diffCtx)
 	ctxDiff.Err = ctxDiff.SubCtxs[0].Err
-	data.ApplyTemplateForDiff(node, ctxDiff, diffPointerRawTemplate)
+	data.ApplyTemplateForDiff(node, ctxDiff, withDiffFuncs(diffPointerRawTemplate, diffCtx))
 }
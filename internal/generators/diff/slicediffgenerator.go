@@ -15,13 +15,12 @@ package diff
 
 import (
 	"strings"
-	"text/template"
 
 	"github.com/haproxytech/go-method-gen/internal/data"
 )
 
-var diffSliceRawTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) map[string][]interface{}  {
-	diff := make(map[string][]interface{})
+var diffSliceRawTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) {{ result }}  {
+	{{ init }}
 	lenX := len(x)
 	lenY := len(y)
 
@@ -30,52 +29,304 @@ var diffSliceRawTemplateTxt = `func {{.DiffFuncName}}(x, y {{.ParameterType}}) m
 	}
 
 	if x == nil {
+		{{ if typed }}
+		{{ added "\"\"" "y" }}
+		return diff
+		{{ else }}
 		return map[string][]interface{}{"": {nil, y}}
+		{{ end }}
 	}
 
 	if y == nil {
+		{{ if typed }}
+		{{ removed "\"\"" "x" }}
+		return diff
+		{{ else }}
 		return map[string][]interface{}{"": {x, nil}}
+		{{ end }}
 	}
 
+	{{ if .SliceKey }}
+	keyOf := func(v {{ .ElemType }}) interface{} {
+		{{ if .ElemPointer }}
+		if v == nil {
+			return nil
+		}
+		{{ end }}
+		{{ if .SliceKeyPtr }}
+		// Pointer keys match elements by the values they point to
+		if v.{{ .SliceKey }} == nil {
+			return nil
+		}
+		return *v.{{ .SliceKey }}
+		{{ else }}
+		return v.{{ .SliceKey }}
+		{{ end }}
+	}
+	// Slices holding several elements with the same key are diffed
+	// regardless of the order of their elements
+	unique := true
+	indexX := make(map[interface{}]struct{}, lenX)
+	for _, vx := range x {
+		if _, found := indexX[keyOf(vx)]; found {
+			unique = false
+		}
+		indexX[keyOf(vx)] = struct{}{}
+	}
+	indexY := make(map[interface{}]int, lenY)
+	for j, vy := range y {
+		if _, found := indexY[keyOf(vy)]; found {
+			unique = false
+		}
+		indexY[keyOf(vy)] = j
+	}
+	if !unique {
+		{{- template "set" . }}
+		return diff
+	}
+	matched := make([]bool, lenY)
+	for _, vx := range x {
+		kx := keyOf(vx)
+		key := fmt.Sprintf("[{{ .SliceKeyName }}=%v]", kx)
+		j, found := indexY[kx]
+		if !found || matched[j] {
+			{{ removed "key" "vx" }}
+			continue
+		}
+		matched[j] = true
+		vy := y[j]
+
+		{{ if  (eq .IsBuiltinSubNode "true") }}
+		if {{ .SubInequality }} {
+			{{ modified "key" "vx" "vy" }}
+		}
+		{{ else }}
+		{{ nestedElem .ElemPrefix .DiffElement .ElemNil }}
+		{{ end }}
+	}
+	for j, vy := range y {
+		if !matched[j] {
+			key := fmt.Sprintf("[{{ .SliceKeyName }}=%v]", keyOf(vy))
+			{{ added "key" "vy" }}
+		}
+	}
+	{{ else if .SliceSet }}
+	{{ template "set" . }}
+	{{ else if .SliceLCS }}
+	same := func(vx, vy {{ .ElemType }}) bool {
+		{{ if  (eq .IsBuiltinSubNode "true") }}
+		return !({{ .SubInequality }})
+		{{ else }}
+		return len({{ .DiffElement }}) == 0
+		{{ end }}
+	}
+	// lcs[i][j] is the length of the longest common subsequence of x[i:] and y[j:]
+	lcs := make([][]int, lenX+1)
+	for i := range lcs {
+		lcs[i] = make([]int, lenY+1)
+	}
+	for i := lenX - 1; i >= 0; i-- {
+		for j := lenY - 1; j >= 0; j-- {
+			switch {
+			case same(x[i], y[j]):
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	{{ if and typed .SliceMoves }}
+	var removed []int
+	added := make([]bool, lenY)
+	{{ end }}
+	for i, j := 0, 0; i < lenX || j < lenY; {
+		switch {
+		case i < lenX && j < lenY && same(x[i], y[j]):
+			i, j = i+1, j+1
+		{{- if not (and typed .SliceMoves) }}
+		case i < lenX && j < lenY && lcs[i+1][j+1] == lcs[i][j]:
+			// Elements replaced at the same place in the common subsequence
+			key := fmt.Sprintf("[%d]",j)
+			vx, vy := x[i], y[j]
+			{{ if  (eq .IsBuiltinSubNode "true") }}
+			{{ modified "key" "vx" "vy" }}
+			{{ else }}
+			{{ nestedElem .ElemPrefix .DiffElement .ElemNil }}
+			{{ end }}
+			i, j = i+1, j+1
+		{{- end }}
+		case j < lenY && (i == lenX || lcs[i][j+1] >= lcs[i+1][j]):
+			{{ if and typed .SliceMoves }}
+			added[j] = true
+			{{ else }}
+			key := fmt.Sprintf("[%d]",j)
+			{{ addedAt "key" "y[j]" }}
+			{{ end }}
+			j++
+		default:
+			{{ if and typed .SliceMoves }}
+			removed = append(removed, i)
+			{{ else }}
+			key := fmt.Sprintf("[%d]",i)
+			{{ removedAt "key" "x[i]" }}
+			{{ end }}
+			i++
+		}
+	}
+	{{ if and typed .SliceMoves }}
+	removedBefore := make([]int, lenX+1)
+	for _, i := range removed {
+		removedBefore[i+1] = 1
+	}
+	addedBefore := make([]int, lenY+1)
+	for j := range y {
+		if added[j] {
+			addedBefore[j+1] = 1
+		}
+	}
+	for i := 0; i < lenX; i++ {
+		removedBefore[i+1] += removedBefore[i]
+	}
+	for j := 0; j < lenY; j++ {
+		addedBefore[j+1] += addedBefore[j]
+	}
+	// Removed elements found among the added ones are reported as moved
+	var left []int
+	for _, i := range removed {
+		found := false
+		for j := range y {
+			if added[j] && same(x[i], y[j]) {
+				added[j], found = false, true
+				key := fmt.Sprintf("[%d]",j)
+				{{ moved "key" "fmt.Sprintf(\"[%d]\", i)" "x[i]" "y[j]" }}
+				break
+			}
+		}
+		if !found {
+			left = append(left, i)
+		}
+	}
+	// An element removed at the index of an added one is reported as replaced,
+	// if as many elements are removed and added before it
+	for _, i := range left {
+		key := fmt.Sprintf("[%d]",i)
+		if i < lenY && added[i] && removedBefore[i] == addedBefore[i] {
+			added[i] = false
+			vx, vy := x[i], y[i]
+			{{ if  (eq .IsBuiltinSubNode "true") }}
+			{{ modified "key" "vx" "vy" }}
+			{{ else }}
+			{{ nestedElem .ElemPrefix .DiffElement .ElemNil }}
+			{{ end }}
+			continue
+		}
+		{{ removed "key" "x[i]" }}
+	}
+	for j := range y {
+		if added[j] {
+			key := fmt.Sprintf("[%d]",j)
+			{{ added "key" "y[j]" }}
+		}
+	}
+	{{ end }}
+	{{ else }}
 	for i := 0; i < lenX && i < lenY; i++ {
 		key := fmt.Sprintf("[%d]",i)
 		vx, vy := x[i], y[i]
 
 		{{ if  (eq .IsBuiltinSubNode "true") }}
-		if vx != vy {
-			diff[key] = []interface{}{vx, vy}
+		if {{ .SubInequality }} {
+			{{ modified "key" "vx" "vy" }}
 		}
 		{{ else }}
-		for diffKey, diffValue := range {{.DiffElement}} {
-			diff[key+"."+diffKey]=diffValue
-		}
+		{{ nestedElem .ElemPrefix .DiffElement .ElemNil }}
 		{{ end }}
 	}
 
 	for i := lenY; i < lenX; i++ {
 		key := fmt.Sprintf("[%d]",i)
-		diff[key] = []interface{}{x[i], nil}
+		{{ removed "key" "x[i]" }}
 	}
 
 
 	for i := lenX; i < lenY; i++ {
 		key := fmt.Sprintf("[%d]",i)
-		diff[key] = []interface{}{nil, y[i]}
+		{{ added "key" "y[i]" }}
 	}
+	{{ end }}
 
     return diff
-}`
+}
+{{ define "set" }}
+	matched := make([]bool, lenY)
+	for i, vx := range x {
+		found := false
+		for j, vy := range y {
+			{{ if  (eq .IsBuiltinSubNode "true") }}
+			if matched[j] || {{ .SubInequality }} {
+			{{ else }}
+			if matched[j] || len({{ .DiffElement }}) != 0 {
+			{{ end }}
+				continue
+			}
+			matched[j], found = true, true
+			break
+		}
+		if !found {
+			key := fmt.Sprintf("[%d]",i)
+			{{ removedAt "key" "vx" }}
+		}
+	}
+	for j, vy := range y {
+		if matched[j] {
+			continue
+		}
+		key := fmt.Sprintf("[%d]",j)
+		{{ addedAt "key" "vy" }}
+	}
+{{ end }}`
 
-var diffSliceRawTemplate = template.Must(template.New("DiffSliceRawTemplate").Parse(diffSliceRawTemplateTxt))
+var diffSliceRawTemplate = newDiffTemplate("DiffSliceRawTemplate", diffSliceRawTemplateTxt)
 
 func DiffGeneratorSlice(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
-	if node.Type == "" {
+	if node.DiffScalar {
+		DiffGeneratorSliceScalar(node, ctx, diffCtx)
+		return
+	}
+	// Slices compared or diffed with options of their field have functions of their own
+	if node.Type == "" || node.GeneratesFunctions() || node.FieldOptions {
 		DiffGeneratorSliceRawType(node, ctx, diffCtx)
 		return
 	}
 	DiffGeneratorSliceDefinedType(node, ctx, diffCtx)
 }
 
+// DiffGeneratorSliceScalar diffs slices of bytes as whole values, as
+// encoding/json encodes them as strings. As for builtins, it only produces
+// code for struct fields: containers of such slices compare their elements in
+// their own templates.
+func DiffGeneratorSliceScalar(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
+	if DiffGeneratorForNodeWithDiff(node, ctx) {
+		return
+	}
+	var diffImplementation string
+	if node.IsForField() {
+		x := ctx.LeftSideComparison + "." + node.Name
+		y := ctx.RightSideComparison + "." + node.Name
+		diffImplementation = "if !slices.Equal(" + x + ", " + y + ") {\n" +
+			diffCtx.modified("\""+node.DiffKey()+"\"", x, y) + "\n}"
+	}
+	ctxDiff := &data.Ctx{
+		DiffImplementation:         diffImplementation,
+		ObjectNameToHaveGeneration: node.Name,
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    map[string]struct{}{"slices": {}},
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
+}
+
 func DiffGeneratorSliceDefinedType(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 	if node.Kind != data.Slice {
 		// TODO log error
@@ -129,5 +380,5 @@ func DiffGeneratorSliceRawType(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffC
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
 	Generate(subNode, ctxDiff, diffCtx)
 	ctxDiff.Err = ctxDiff.SubCtxs[0].Err
-	data.ApplyTemplateForDiff(node, ctxDiff, diffSliceRawTemplate)
+	data.ApplyTemplateForDiff(node, ctxDiff, withDiffFuncs(diffSliceRawTemplate, diffCtx))
 }
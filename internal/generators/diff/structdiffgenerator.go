@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
 )
 
 func DiffGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
@@ -36,6 +37,13 @@ func DiffGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 	}
 
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the value as argument is
+	// generated instead.
+	if node.GeneratesFunctions() {
+		ctxDiff.Function = true
+		ctxDiff.LeftSideComparison, ctxDiff.RightSideComparison = "x", "y"
+	}
 	for _, field := range node.Fields {
 		Generate(field, ctxDiff, diffCtx)
 	}
@@ -58,31 +66,44 @@ func DiffGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
 			implementation.WriteString("\n")
 		}
 		keySeparator := "."
+		// Diff keys of container, interface and pointer values start with their own separator
 		if subCtx.ObjectKind == data.KindToString(data.Slice) ||
-			subCtx.ObjectKind == data.KindToString(data.Map) {
+			subCtx.ObjectKind == data.KindToString(data.Array) ||
+			subCtx.ObjectKind == data.KindToString(data.Map) ||
+			subCtx.ObjectKind == data.KindToString(data.Interface) ||
+			subCtx.ObjectKind == data.KindToString(data.Pointer) {
 			keySeparator = ""
 		}
-		key := "diffKey"
-		if subCtx.ObjectNameToHaveGeneration != "" {
-			key = "\"" + subCtx.ObjectNameToHaveGeneration + keySeparator + `"+` + key
+		var prefix string
+		if subCtx.DiffKey != "" {
+			prefix = "\"" + subCtx.DiffKey + keySeparator + `"+`
 		}
 		switch {
 		case subCtx.DiffFuncName == "Diff":
-			implementation.WriteString("for diffKey, diffValue:= range " + ctxDiff.LeftSideComparison + "." +
-				subCtx.ObjectNameToHaveGeneration + "." + subCtx.DiffFuncName + "(" +
-				ctxDiff.RightSideComparison + "." + subCtx.ObjectNameToHaveGeneration + ") {\n" +
-				"\tdiff[" + key + "] = diffValue\n}")
+			implementation.WriteString(diffCtx.nested(prefix, ctxDiff.LeftSideComparison+"."+
+				subCtx.ObjectNameToHaveGeneration+"."+subCtx.DiffFuncName+"("+
+				ctxDiff.RightSideComparison+"."+subCtx.ObjectNameToHaveGeneration+")"))
 		// case subCtx.DiffFuncName != "" && node.HasDiff:
 		case subCtx.DiffFuncName != "":
-
-			implementation.WriteString("for diffKey, diffValue:= range " + subCtx.DiffFuncName + "(" + ctxDiff.LeftSideComparison + "." +
-				subCtx.ObjectNameToHaveGeneration + "," +
-				ctxDiff.RightSideComparison + "." + subCtx.ObjectNameToHaveGeneration + ") {\n" +
-				"\tdiff[" + key + "] = diffValue\n}")
+			implementation.WriteString(diffCtx.nested(prefix, subCtx.DiffFuncName+"("+ctxDiff.LeftSideComparison+"."+
+				subCtx.ObjectNameToHaveGeneration+","+
+				ctxDiff.RightSideComparison+"."+subCtx.ObjectNameToHaveGeneration+")"))
 		default:
 			implementation.WriteString(subCtx.DiffImplementation)
 		}
 
 	}
 	ctxDiff.DiffImplementation = implementation.String()
+	if node.GeneratesFunctions() {
+		declName, callName := data.GenericFuncNames(node, utils.DiffFuncName(data.FuncNameType(node)))
+		ctxDiff.DiffFuncName = callName
+		if ctxDiff.DiffImplementation != "" {
+			resultType, resultInit := data.DiffResult(diffCtx.Typed)
+			ctxDiff.DiffImplementation = "func " + declName + "(x, y " + data.GetTypeFromNode(node) + ") " + resultType + " {\n" +
+				resultInit + "\n" + ctxDiff.DiffImplementation + "\nreturn diff\n}"
+		}
+		for imp, marker := range node.Imports {
+			ctxDiff.Imports[imp] = marker
+		}
+	}
 }
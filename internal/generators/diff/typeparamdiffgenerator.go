@@ -0,0 +1,38 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package diff
+
+import "github.com/haproxytech/go-method-gen/internal/data"
+
+// DiffGeneratorTypeParam only produces code for struct fields: containers of
+// type parameter values compare their elements in their own templates, as for
+// builtins.
+func DiffGeneratorTypeParam(node *data.TypeNode, ctx *data.Ctx, diffCtx DiffCtx) {
+	if DiffGeneratorForNodeWithDiff(node, ctx) {
+		return
+	}
+	var diffImplementation string
+	if node.IsForField() {
+		x := ctx.LeftSideComparison + "." + node.Name
+		y := ctx.RightSideComparison + "." + node.Name
+		diffImplementation = "if " + data.Inequality(node, x, y) + " {\n" +
+			diffCtx.modified("\""+node.DiffKey()+"\"", x, y) + "\n}"
+	}
+	ctxDiff := &data.Ctx{
+		DiffImplementation:         diffImplementation,
+		ObjectNameToHaveGeneration: node.Name,
+		ObjectKind:                 data.KindToString(node.Kind),
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxDiff)
+}
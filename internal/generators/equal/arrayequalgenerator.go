@@ -32,7 +32,7 @@ var equalArrayTemplateTxt = `func {{.EqualFuncName}}(x, y {{.ParameterType}}) bo
 var equalArrayTemplate = template.Must(template.New("EqualArrayTemplate").Parse(equalArrayTemplateTxt))
 
 func EqualGeneratorArray(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
-	if node.Type == "" {
+	if node.Type == "" || node.GeneratesFunctions() {
 		EqualGeneratorRawArray(node, ctx, equalCtx)
 		return
 	}
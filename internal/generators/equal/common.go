@@ -41,6 +41,32 @@ func EqualGeneratorForNodeWithEqual(node *data.TypeNode, ctx *data.Ctx) bool {
 	return true
 }
 
+// FieldEquality returns the expression testing the equality of the values of
+// the field of subCtx in the structs compared by ctx.
+func FieldEquality(ctx, subCtx *data.Ctx) string {
+	left := ctx.LeftSideComparison + "." + subCtx.ObjectNameToHaveGeneration
+	right := ctx.RightSideComparison + "." + subCtx.ObjectNameToHaveGeneration
+	switch {
+	case subCtx.EqualFuncName == "Equal":
+		return left + "." + subCtx.EqualFuncName + "(" + right + ")"
+	case subCtx.EqualFuncName != "":
+		return subCtx.EqualFuncName + "(" + left + "," + right + ")"
+	}
+	return subCtx.EqualImplementation
+}
+
+// FieldInequality returns the expression testing the inequality of the values
+// of the field of subCtx in the structs compared by ctx.
+func FieldInequality(ctx, subCtx *data.Ctx) string {
+	switch {
+	case subCtx.EqualFuncName != "":
+		return "!" + FieldEquality(ctx, subCtx)
+	case subCtx.InequalImplementation != "":
+		return subCtx.InequalImplementation
+	}
+	return "!(" + subCtx.EqualImplementation + ")"
+}
+
 type EqualCtx struct {
 	Overrides map[string]common.OverrideFuncs
 }
@@ -16,6 +16,7 @@ package equal
 import (
 	"strings"
 
+	"github.com/haproxytech/go-method-gen/internal/common"
 	"github.com/haproxytech/go-method-gen/internal/data"
 	"github.com/haproxytech/go-method-gen/internal/utils"
 )
@@ -36,7 +37,11 @@ func Generate(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
 		}
 	}
 	packagedType := node.PkgPath + "." + nodeType
-	override, hasOverride := equalCtx.Overrides[packagedType]
+	override, hasOverride := common.LookupOverride(equalCtx.Overrides, node, packagedType)
+	// Ignored fields are skipped by the functions of their struct
+	if hasOverride && override.Ignore && node.IsForField() {
+		return
+	}
 	if hasOverride && override.Equal != nil {
 		fn := override.Equal
 		ctxEqual := &data.Ctx{
@@ -63,6 +68,10 @@ func Generate(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
 		return
 	}
 
+	// Values of types declared in other packages keep using their own methods
+	if node.Foreign && EqualGeneratorForNodeWithEqual(node, ctx) {
+		return
+	}
 	switch node.Kind {
 	case data.Struct:
 		EqualGeneratorStruct(node, ctx, equalCtx)
@@ -80,5 +89,11 @@ func Generate(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
 		EqualGeneratorPointer(node, ctx, equalCtx)
 	case data.Func:
 		EqualGeneratorFunc(node, ctx, equalCtx)
+	case data.TypeParam:
+		EqualGeneratorTypeParam(node, ctx, equalCtx)
+	}
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
 	}
 }
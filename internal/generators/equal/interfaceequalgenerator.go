@@ -13,12 +13,45 @@
 // limitations under the License.
 package equal
 
-import "github.com/haproxytech/go-method-gen/internal/data"
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// Values of interfaces are dispatched to the Equal methods of their
+// registered implementations, other values are compared with reflect.
+const equalInterfaceTemplateTxt = `func {{.EqualFuncName}}(x, y {{.ParameterType}}) bool {
+	{{- if .Cases }}
+	switch vx := x.(type) {
+	{{- range .Cases }}
+	case {{ .Type }}:
+		vy, ok := y.({{ .Type }})
+		return ok && {{ .Equality }}
+	{{- end }}
+	}
+	{{- end }}
+	return reflect.DeepEqual(x, y)
+}`
+
+var equalInterfaceTemplate = template.Must(template.New("EqualInterfaceTemplate").Parse(equalInterfaceTemplateTxt))
+
+// interfaceCase is a case of the type switch of the functions comparing
+// interface values, for one implementation.
+type interfaceCase struct {
+	Type     string // Type of the implementation in the package of the function
+	Equality string // Expression testing the equality of vx and vy
+}
 
 func EqualGeneratorInterface(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
 	if node.Kind != data.Interface {
 		// TODO log error
 	}
+	if !node.Err {
+		EqualGeneratorInterfaceImplementations(node, ctx, equalCtx)
+		return
+	}
 
 	var equalImplementation, unequalImplementation string
 	if node.IsForType() {
@@ -37,3 +70,44 @@ func EqualGeneratorInterface(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualC
 	}
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxEqual)
 }
+
+// EqualGeneratorInterfaceImplementations generates the function comparing the
+// values of an interface whose implementations, if any, are registered.
+func EqualGeneratorInterfaceImplementations(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
+	ctxEqual := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		RightSideComparison:        "y",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxEqual)
+
+	cases := make([]interfaceCase, len(node.Implementations))
+	for i, impl := range node.Implementations {
+		equality := "vx.Equal(vy)"
+		if impl.PkgPath == "" {
+			equality = "vx == vy"
+		}
+		if impl.Pointer {
+			equality = "vx.Equal(*vy)"
+			if impl.PkgPath == "" {
+				equality = "*vx == *vy"
+			}
+			equality = "(vx == vy || vx != nil && vy != nil && " + equality + ")"
+		}
+		cases[i] = interfaceCase{Type: impl.TypeIn(node.RefererPkgPath), Equality: equality}
+	}
+	args := data.GetTemplateDataFromSubNodeEqual(node, ctxEqual)
+	declName, callName := data.GenericFuncNames(node, args[data.EqualFuncNameDataMap])
+	var sb strings.Builder
+	equalInterfaceTemplate.Execute(&sb, map[string]interface{}{
+		data.EqualFuncNameDataMap: declName,
+		data.ParameterTypeDataMap: args[data.ParameterTypeDataMap],
+		"Cases":                   cases,
+	})
+	ctxEqual.EqualFuncName = callName
+	ctxEqual.EqualImplementation = sb.String()
+}
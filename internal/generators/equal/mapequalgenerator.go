@@ -37,7 +37,7 @@ const equalMapRawTemplateTxt = `func {{.EqualFuncName}}(x, y {{.ParameterType}})
 var equalMapRawTemplate = template.Must(template.New("EqualMapRawTemplate").Parse(equalMapRawTemplateTxt))
 
 func EqualGeneratorMap(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
-	if node.Type == "" {
+	if node.Type == "" || node.GeneratesFunctions() {
 		EqualGeneratorRawMap(node, ctx, equalCtx)
 		return
 	}
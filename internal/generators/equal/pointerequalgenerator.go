@@ -21,16 +21,26 @@ import (
 )
 
 var equalPointerTemplateTxt = `func {{.EqualFuncName}}(x, y {{.ParameterType}}) bool {
+	{{- if .NilEmpty }}
+	if x == nil {
+		x = new({{ .ElemType }})
+	}
+	if y == nil {
+		y = new({{ .ElemType }})
+	}
+	{{- else }}
 	if x == nil || y == nil {
 		return x == y
 	}
+	{{- end }}
 	return {{.EqualityTest}}
 }`
 
 var equalPointerTemplate = template.Must(template.New("EqualPointerTemplate").Parse(equalPointerTemplateTxt))
 
 func EqualGeneratorPointer(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
-	if node.Type == "" {
+	// Pointers compared with options of their field have functions of their own
+	if node.Type == "" || node.GeneratesFunctions() || node.FieldOptions {
 		EqualGeneratorPointerRawType(node, ctx, equalCtx)
 		return
 	}
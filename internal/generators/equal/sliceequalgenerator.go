@@ -24,6 +24,63 @@ var equalSliceRawTemplateTxt = `func {{.EqualFuncName}}(x, y {{.ParameterType}})
 	if len(x) != len(y) {
 		return false
 	}
+	{{- if .SliceKey }}
+
+	keyOf := func(v {{ .ElemType }}) interface{} {
+		{{- if .ElemPointer }}
+		if v == nil {
+			return nil
+		}
+		{{- end }}
+		{{- if .SliceKeyPtr }}
+		// Pointer keys match elements by the values they point to
+		if v.{{ .SliceKey }} == nil {
+			return nil
+		}
+		return *v.{{ .SliceKey }}
+		{{- else }}
+		return v.{{ .SliceKey }}
+		{{- end }}
+	}
+	// Elements are matched among the ones having their key, which are
+	// compared regardless of their order if several have it
+	indexY := make(map[interface{}][]int, len(y))
+	for j, vy := range y {
+		indexY[keyOf(vy)] = append(indexY[keyOf(vy)], j)
+	}
+	for _, vx := range x {
+		kx := keyOf(vx)
+		found := false
+		for n, j := range indexY[kx] {
+			vy := y[j]
+			if {{.InequalityTest}} {
+				continue
+			}
+			indexY[kx] = append(indexY[kx][:n], indexY[kx][n+1:]...)
+			found = true
+			break
+		}
+		if !found {
+			return false
+		}
+	}
+	{{- else if .SliceSet }}
+
+	matched := make([]bool, len(y))
+	for _, vx := range x {
+		found := false
+		for j, vy := range y {
+			if matched[j] || {{.InequalityTest}} {
+				continue
+			}
+			matched[j], found = true, true
+			break
+		}
+		if !found {
+			return false
+		}
+	}
+	{{- else }}
 
 	for i, vx := range x {
 		vy := y[i]
@@ -31,6 +88,7 @@ var equalSliceRawTemplateTxt = `func {{.EqualFuncName}}(x, y {{.ParameterType}})
 			return false
 		}
 	}
+	{{- end }}
 
 	return true
 }`
@@ -38,7 +96,8 @@ var equalSliceRawTemplateTxt = `func {{.EqualFuncName}}(x, y {{.ParameterType}})
 var equalSliceRawTemplate = template.Must(template.New("EqualSliceRawTemplate").Parse(equalSliceRawTemplateTxt))
 
 func EqualGeneratorSlice(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
-	if node.Type == "" {
+	// Slices compared with options of their field have functions of their own
+	if node.Type == "" || node.GeneratesFunctions() || node.FieldOptions {
 		EqualGeneratorSliceRawType(node, ctx, equalCtx)
 		return
 	}
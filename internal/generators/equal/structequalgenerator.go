@@ -17,6 +17,7 @@ import (
 	"strings"
 
 	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
 )
 
 func EqualGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
@@ -35,6 +36,13 @@ func EqualGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx)
 		Type:                       node.Type,
 	}
 	ctx.SubCtxs = append(ctx.SubCtxs, ctxEqual)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the value as argument is
+	// generated instead.
+	if node.GeneratesFunctions() {
+		ctxEqual.Function = true
+		ctxEqual.LeftSideComparison, ctxEqual.RightSideComparison = "x", "y"
+	}
 
 	for _, field := range node.Fields {
 		Generate(field, ctxEqual, equalCtx)
@@ -57,20 +65,18 @@ func EqualGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx)
 		if i != 0 && i < numSubCtxs {
 			implementation.WriteString(" && \n")
 		}
-		switch {
-		case subCtx.EqualFuncName == "Equal":
-			implementation.WriteString(ctxEqual.LeftSideComparison + "." +
-				subCtx.ObjectNameToHaveGeneration + "." + subCtx.EqualFuncName + "(" +
-				ctxEqual.RightSideComparison + "." + subCtx.ObjectNameToHaveGeneration + ")")
-		// case subCtx.EqualFuncName != "" && node.HasEqual:
-		case subCtx.EqualFuncName != "":
-			implementation.WriteString(subCtx.EqualFuncName + "(" + ctxEqual.LeftSideComparison + "." +
-				subCtx.ObjectNameToHaveGeneration + "," +
-				ctxEqual.RightSideComparison + "." + subCtx.ObjectNameToHaveGeneration + ")")
-		default:
-			implementation.WriteString(subCtx.EqualImplementation)
-		}
-
+		implementation.WriteString(FieldEquality(ctxEqual, subCtx))
 	}
 	ctxEqual.EqualImplementation = implementation.String()
+	if node.GeneratesFunctions() {
+		declName, callName := data.GenericFuncNames(node, utils.EqualFuncName(data.FuncNameType(node)))
+		ctxEqual.EqualFuncName = callName
+		if ctxEqual.EqualImplementation != "" {
+			ctxEqual.EqualImplementation = "func " + declName + "(x, y " + data.GetTypeFromNode(node) + ") bool {\n" +
+				"\treturn " + ctxEqual.EqualImplementation + "\n}"
+		}
+		for imp, marker := range node.Imports {
+			ctxEqual.Imports[imp] = marker
+		}
+	}
 }
@@ -0,0 +1,35 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package equal
+
+import "github.com/haproxytech/go-method-gen/internal/data"
+
+// EqualGeneratorTypeParam compares the values of a type parameter of a generic
+// type declaration, see data.Equality.
+func EqualGeneratorTypeParam(node *data.TypeNode, ctx *data.Ctx, equalCtx EqualCtx) {
+	if EqualGeneratorForNodeWithEqual(node, ctx) {
+		return
+	}
+	x, y := ctx.LeftSideComparison, ctx.RightSideComparison
+	if node.IsForField() {
+		x, y = x+"."+node.Name, y+"."+node.Name
+	}
+	ctxEqual := &data.Ctx{
+		EqualImplementation:        data.Equality(node, x, y),
+		InequalImplementation:      data.Inequality(node, x, y),
+		ObjectNameToHaveGeneration: node.Name,
+		ObjectKind:                 data.KindToString(node.Kind),
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxEqual)
+}
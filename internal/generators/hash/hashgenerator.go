@@ -0,0 +1,204 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hash
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/generators/equal"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+type HashCtx struct {
+	Overrides map[string]common.OverrideFuncs
+}
+
+// Generate generates the Hash method of a struct node, writing the values of
+// the fields compared by its Equal method to a hash.Hash64, and the ones of
+// the structs found in its fields. Values of other types are written with the
+// functions of the eqdiff package, so that values equal as their Equal
+// functions compare them write the same data.
+func Generate(node *data.TypeNode, ctx *data.Ctx, hashCtx HashCtx) {
+	if node == nil || node.Err || node.Kind != data.Struct {
+		return
+	}
+	HashGeneratorStruct(node, ctx, hashCtx)
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
+	}
+}
+
+// isGenerated reports whether the Hash function of a struct node is
+// generated.
+func isGenerated(node *data.TypeNode, hashCtx HashCtx) bool {
+	return node != nil && node.Kind == data.Struct && !node.Err && node.Type != "" &&
+		!node.HasHash && !node.HasEqual && !hasEqualOverride(node, hashCtx)
+}
+
+// hasEqualOverride reports whether the values of a node are compared with an
+// override.
+func hasEqualOverride(node *data.TypeNode, hashCtx HashCtx) bool {
+	nodeType := node.Type
+	if nodeType == "" {
+		if pkgAndType := strings.SplitN(node.PackagedType, ".", 2); len(pkgAndType) > 1 {
+			nodeType = pkgAndType[0]
+		}
+	}
+	override, found := common.LookupOverride(hashCtx.Overrides, node, node.PkgPath+"."+nodeType)
+	return found && override.Equal != nil
+}
+
+// isComparedByEqual reports whether the Equal function of the struct holding
+// a field compares it: ignored fields and fields which cannot be compared are
+// skipped.
+func isComparedByEqual(field *data.TypeNode, hashCtx HashCtx) bool {
+	ctxField := &data.Ctx{}
+	equal.Generate(field, ctxField, equal.EqualCtx{Overrides: hashCtx.Overrides})
+	return len(ctxField.SubCtxs) == 1 && !ctxField.SubCtxs[0].Err
+}
+
+// hashStatement returns the statement writing the value v of a node to the
+// hash h, or "" if it cannot be written consistently with its Equal function:
+// values compared with an override or with a hand-written Equal method, and
+// values compared with reflect.DeepEqual, are not written.
+func hashStatement(node *data.TypeNode, v string, ctx *data.Ctx, hashCtx HashCtx) string {
+	for imp, marker := range node.Imports {
+		ctx.Imports[imp] = marker
+	}
+	switch {
+	case hasEqualOverride(node, hashCtx):
+		return ""
+	case node.HasHash:
+		return v + ".Hash(h)"
+	case node.HasEqual:
+		return ""
+	}
+	switch node.Kind {
+	case data.Builtin:
+		return builtinHashStatement(node, v)
+	case data.Struct:
+		if !isGenerated(node, hashCtx) {
+			return ""
+		}
+		if !node.GeneratesFunctions() {
+			return v + ".Hash(h)"
+		}
+		_, callName := data.GenericFuncNames(node, utils.HashFuncName(data.FuncNameType(node)))
+		return callName + "(h, " + v + ")"
+	case data.Pointer:
+		if node.SubNode == nil {
+			return ""
+		}
+		// Nil pointers equal pointers to the zero value with the nilempty option
+		if node.NilEmpty {
+			return "eqdiff.HashNilEmpty(h, " + v + ", " + hashFunc(node.SubNode, ctx, hashCtx) + ")"
+		}
+		return "eqdiff.HashPointer(h, " + v + ", " + hashFunc(node.SubNode, ctx, hashCtx) + ")"
+	case data.Slice:
+		if node.SubNode == nil {
+			return ""
+		}
+		// Slices compared regardless of order are hashed regardless of order
+		if node.SliceSet || node.SliceKey != "" {
+			return "eqdiff.HashSet(h, " + v + ", " + hashFunc(node.SubNode, ctx, hashCtx) + ")"
+		}
+		return "eqdiff.HashSlice(h, " + v + ", " + hashFunc(node.SubNode, ctx, hashCtx) + ")"
+	case data.Array:
+		if node.SubNode == nil {
+			return ""
+		}
+		return "eqdiff.HashSlice(h, " + v + "[:], " + hashFunc(node.SubNode, ctx, hashCtx) + ")"
+	case data.Map:
+		if node.SubNode == nil {
+			return ""
+		}
+		return "eqdiff.HashMap(h, " + v + ", " + hashFunc(node.SubNode, ctx, hashCtx) + ")"
+	case data.Interface:
+		return interfaceHashStatement(node, v)
+	case data.TypeParam:
+		if node.IsComparable {
+			return "eqdiff.HashComparable(h, " + v + ")"
+		}
+	}
+	return ""
+}
+
+// builtinHashStatement returns the statement writing the value v of a builtin
+// node to the hash h, with the function of the eqdiff package matching its
+// underlying kind.
+func builtinHashStatement(node *data.TypeNode, v string) string {
+	switch node.BuiltinKind {
+	case "bool":
+		return "eqdiff.HashBool(h, " + v + ")"
+	case "int", "int8", "int16", "int32", "int64", "rune":
+		return "eqdiff.HashInt(h, " + v + ")"
+	case "uint", "uint8", "uint16", "uint32", "uint64", "uintptr", "byte":
+		return "eqdiff.HashUint(h, " + v + ")"
+	case "float32", "float64":
+		return "eqdiff.HashFloat(h, " + v + ")"
+	case "complex64", "complex128":
+		return "eqdiff.HashComplex(h, " + v + ")"
+	case "string":
+		return "eqdiff.HashString(h, " + v + ")"
+	}
+	if node.IsComparable {
+		return "eqdiff.HashComparable(h, " + v + ")"
+	}
+	return ""
+}
+
+// interfaceHashStatement returns the statement writing the value v of an
+// interface node to the hash h. As Equal functions compare the values of
+// implementations with their Equal methods, their Hash methods write them, if
+// they have one. Builtin implementations are compared with the == operator.
+func interfaceHashStatement(node *data.TypeNode, v string) string {
+	if len(node.Implementations) == 0 {
+		return ""
+	}
+	statement := strings.Builder{}
+	statement.WriteString("switch v := " + v + ".(type) {\n")
+	for _, impl := range node.Implementations {
+		implType := impl.TypeIn(node.RefererPkgPath)
+		write := "eqdiff.HashMethod"
+		if impl.PkgPath == "" {
+			write = "eqdiff.HashComparable"
+		}
+		statement.WriteString("case " + implType + ":\n")
+		if impl.Pointer {
+			statement.WriteString("eqdiff.HashPointer(h, v, " + write + "[" + strings.TrimPrefix(implType, "*") + "])\n")
+			continue
+		}
+		statement.WriteString(write + "(h, v)\n")
+	}
+	statement.WriteString("}")
+	return statement.String()
+}
+
+// hashFunc returns the function writing the values of a node to a hash,
+// passed to the functions of the eqdiff package writing their elements, or
+// nil if they cannot be written.
+func hashFunc(node *data.TypeNode, ctx *data.Ctx, hashCtx HashCtx) string {
+	statement := hashStatement(node, "v", ctx, hashCtx)
+	if statement == "" {
+		return "nil"
+	}
+	if node.Kind == data.Struct && node.GeneratesFunctions() {
+		_, callName := data.GenericFuncNames(node, utils.HashFuncName(data.FuncNameType(node)))
+		return callName
+	}
+	return "func(h hash.Hash64, v " + data.GetTypeFromNode(node) + ") {\n" + statement + "\n}"
+}
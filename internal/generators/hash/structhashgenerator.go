@@ -0,0 +1,98 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package hash
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func HashGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, hashCtx HashCtx) {
+	if node.HasHash {
+		return
+	}
+
+	ctxHash := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "rec",
+		HashFuncName:               "Hash",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxHash)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the value as argument is
+	// generated instead.
+	if node.GeneratesFunctions() {
+		ctxHash.Function = true
+		ctxHash.LeftSideComparison = "x"
+		_, ctxHash.HashFuncName = data.GenericFuncNames(node, utils.HashFuncName(data.FuncNameType(node)))
+	}
+
+	// Types already visited have no fields: their Hash method is generated
+	// where they were first parsed.
+	if len(node.Fields) == 0 {
+		return
+	}
+
+	ctxHash.Imports = map[string]struct{}{utils.EqdiffPkgPath: {}, "hash": {}}
+	implementation := strings.Builder{}
+	for _, field := range node.Fields {
+		generateNested(field, ctxHash, hashCtx)
+		// Fields skipped by Equal are skipped too, for equal values to write
+		// the same data
+		if field.Err || !isComparedByEqual(field, hashCtx) {
+			continue
+		}
+		statement := hashStatement(field, ctxHash.LeftSideComparison+"."+field.Name, ctxHash, hashCtx)
+		if statement != "" {
+			implementation.WriteString(statement + "\n")
+		}
+	}
+	ctxHash.HashImplementation = strings.TrimSuffix(implementation.String(), "\n")
+	// The method is declared even if no field is written, as the functions
+	// of the structs holding the type call it
+	if ctxHash.HashImplementation == "" {
+		ctxHash.HashImplementation = "// No field can be written consistently with Equal"
+	}
+	if node.GeneratesFunctions() {
+		declName, _ := data.GenericFuncNames(node, utils.HashFuncName(data.FuncNameType(node)))
+		ctxHash.HashImplementation = "func " + declName + "(h hash.Hash64, x " + data.GetTypeFromNode(node) + ") {\n" +
+			ctxHash.HashImplementation + "\n}"
+		for imp, marker := range node.Imports {
+			ctxHash.Imports[imp] = marker
+		}
+	}
+	for _, subCtx := range ctxHash.SubCtxs {
+		for imp, marker := range subCtx.Imports {
+			ctxHash.Imports[imp] = marker
+		}
+	}
+}
+
+// generateNested generates the Hash functions of the structs held by a
+// field. They are generated for any struct having fields, even the ones only
+// found in slices, as the first node of a type met by the parser is the only
+// one holding its fields.
+func generateNested(field *data.TypeNode, ctx *data.Ctx, hashCtx HashCtx) {
+	for node := field; node != nil; node = node.SubNode {
+		if node.Kind == data.Struct && len(node.Fields) > 0 && isGenerated(node, hashCtx) {
+			HashGeneratorStruct(node, ctx, hashCtx)
+		}
+	}
+}
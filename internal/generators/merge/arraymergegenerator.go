@@ -0,0 +1,90 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+var mergeArrayTemplateTxt = `func {{.MergeFuncName}}(x, y {{.ParameterType}}) {{.ParameterType}} {
+	for i := range x {
+		{{ if  (eq .IsBuiltinSubNode "true") }}
+		if y[i] != {{.SubZeroValue}} {
+			x[i] = y[i]
+		}
+		{{ else }}
+		x[i] = {{.MergeElement}}
+		{{ end }}
+	}
+	return x
+}`
+
+var mergeArrayTemplate = template.Must(template.New("MergeArrayTemplate").Parse(mergeArrayTemplateTxt))
+
+func MergeGeneratorArray(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Type == "" || node.GeneratesFunctions() {
+		MergeGeneratorRawArray(node, ctx, mergeCtx)
+		return
+	}
+	MergeGeneratorDefinedArray(node, ctx, mergeCtx)
+}
+
+func MergeGeneratorDefinedArray(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Array {
+		// TODO log error
+	}
+	if MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+
+	ctxMerge := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x[i]",
+		RightSideComparison:        "y[i]",
+		MergeFuncName:              "Merge",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	MergeGeneratorRawArray(node, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	ctxMerge.MergeImplementation = ctxMerge.SubCtxs[0].MergeFuncName + "(x, y)"
+}
+
+func MergeGeneratorRawArray(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Array {
+		// TODO log error
+	}
+	subNode := node.SubNode
+	if subNode == nil {
+		// TODO log error
+	}
+	ctxMerge := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x[i]",
+		RightSideComparison:        "y[i]",
+		Imports:                    node.Imports,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	Generate(subNode, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	data.ApplyTemplateForMerge(node, ctxMerge, mergeArrayTemplate)
+}
@@ -0,0 +1,111 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+const mergeBuiltinDefinedTemplateTxt = `func {{.MergeFuncName}}(x, y {{.ParameterType}}) {{.ParameterType}} {
+	if y == {{.ZeroValue}} {
+		return x
+	}
+	return y
+}`
+
+var mergeBuiltinDefinedTemplate = template.Must(template.New("MergeBuiltinDefinedTemplate").Parse(mergeBuiltinDefinedTemplateTxt))
+
+var mergeBuiltinRawTemplateTxt = `if {{ .RightSideComparison }}.{{ .FieldName }} != {{ .ZeroValue }} {
+	{{ .LeftSideComparison }}.{{ .FieldName }} = {{ .RightSideComparison }}.{{ .FieldName }}
+}`
+
+var mergeBuiltinRawTemplate = template.Must(template.New("MergeBuiltinRawTemplate").Parse(mergeBuiltinRawTemplateTxt))
+
+func MergeGeneratorBuiltin(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.PkgPath == "" {
+		MergeGeneratorBuiltinRaw(node, ctx, mergeCtx)
+		return
+	}
+	MergeGeneratorBuiltinDefined(node, ctx, mergeCtx)
+}
+
+func MergeGeneratorBuiltinDefined(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Builtin {
+		// TODO log error
+	}
+	if MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+	ctxMerge := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		RightSideComparison:        "y",
+		MergeFuncName:              "Merge",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+	parameterType := data.GetTypeFromNode(node)
+	mergeFuncName := utils.MergeFuncName(parameterType)
+	ctxMergeImpl := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		RightSideComparison:        "y",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		MergeFuncName:              mergeFuncName,
+	}
+
+	var sb strings.Builder
+	mergeBuiltinDefinedTemplate.Execute(&sb, map[string]string{
+		data.MergeFuncNameDataMap: mergeFuncName,
+		data.ParameterTypeDataMap: parameterType,
+		"ZeroValue":               data.ZeroValue(node),
+	})
+	ctxMergeImpl.MergeImplementation = sb.String()
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	ctxMerge.SubCtxs = append(ctxMerge.SubCtxs, ctxMergeImpl)
+	ctxMerge.MergeImplementation = ctxMerge.SubCtxs[0].MergeFuncName + "(" + ctxMerge.LeftSideComparison + ", " + ctxMerge.RightSideComparison + ")"
+}
+
+// MergeGeneratorBuiltinRaw only produces code for struct fields: containers of
+// builtin values merge their elements directly in their own templates.
+func MergeGeneratorBuiltinRaw(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	var mergeImplementation string
+	if node.IsForField() {
+		sb := strings.Builder{}
+		mergeBuiltinRawTemplate.Execute(&sb, map[string]string{
+			"LeftSideComparison":  ctx.LeftSideComparison,
+			"RightSideComparison": ctx.RightSideComparison,
+			"FieldName":           node.Name,
+			"ZeroValue":           data.ZeroValue(node),
+		})
+		mergeImplementation = sb.String()
+	}
+	ctxMerge := &data.Ctx{
+		MergeImplementation:        mergeImplementation,
+		ObjectNameToHaveGeneration: node.Name,
+		ObjectKind:                 data.KindToString(node.Kind),
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+}
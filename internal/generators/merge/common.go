@@ -0,0 +1,45 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+func MergeGeneratorForNodeWithMerge(node *data.TypeNode, ctx *data.Ctx) bool {
+	if !node.HasMerge {
+		return false
+	}
+	var mergeImplementation string
+	if node.IsForField() {
+		mergeImplementation = ctx.LeftSideComparison + "." + node.Name + " = " +
+			ctx.LeftSideComparison + "." + node.Name + ".Merge(" + ctx.RightSideComparison + "." + node.Name + ")"
+	} else {
+		mergeImplementation = ctx.LeftSideComparison + ".Merge(" + ctx.RightSideComparison + ")"
+	}
+	ctxMerge := &data.Ctx{
+		MergeImplementation:        mergeImplementation,
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		Imports:                    node.Imports,
+		DefinedType:                true,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	return true
+}
+
+type MergeCtx struct {
+	Overrides map[string]common.OverrideFuncs
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+const mergeMapRawTemplateTxt = `func {{.MergeFuncName}}(x, y {{.ParameterType}}) {{.ParameterType}} {
+	if len(y) == 0 {
+		return x
+	}
+	if len(x) == 0 {
+		return y
+	}
+
+	merged := make({{.ParameterType}}, len(x)+len(y))
+	for kx, vx := range x {
+		merged[kx] = vx
+	}
+	for ky, vy := range y {
+		{{ if  (eq .IsBuiltinSubNode "true") }}
+		merged[ky] = vy
+		{{ else }}
+		vx, exists := merged[ky]
+		if !exists {
+			merged[ky] = vy
+			continue
+		}
+		merged[ky] = {{.MergeElement}}
+		{{ end }}
+	}
+
+	return merged
+}`
+
+var mergeMapRawTemplate = template.Must(template.New("MergeMapRawTemplate").Parse(mergeMapRawTemplateTxt))
+
+func MergeGeneratorMap(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Type == "" || node.GeneratesFunctions() {
+		MergeGeneratorRawMap(node, ctx, mergeCtx)
+		return
+	}
+	MergeGeneratorDefinedMap(node, ctx, mergeCtx)
+}
+
+func MergeGeneratorRawMap(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Map {
+		// TODO log error
+	}
+	subNode := node.SubNode
+	if subNode == nil {
+		// TODO log error
+	}
+	ctxMerge := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "vx",
+		RightSideComparison:        "vy",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+		Type:                       node.Type,
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	Generate(subNode, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	data.ApplyTemplateForMerge(node, ctxMerge, mergeMapRawTemplate)
+}
+
+func MergeGeneratorDefinedMap(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Map {
+		// TODO log error
+	}
+	if MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+	ctxMerge := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		RightSideComparison:        "y",
+		MergeFuncName:              "Merge",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	MergeGeneratorRawMap(node, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	ctxMerge.MergeImplementation = ctxMerge.SubCtxs[0].MergeFuncName + "(x, y)"
+}
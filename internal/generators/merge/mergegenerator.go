@@ -0,0 +1,106 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func Generate(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node == nil {
+		return
+	}
+	if node.Err {
+		return
+	}
+
+	nodeType := node.Type
+	if nodeType == "" {
+		pkgAndType := strings.SplitN(node.PackagedType, ".", 2)
+		if len(pkgAndType) > 1 {
+			nodeType = pkgAndType[0]
+		}
+	}
+	packagedType := node.PkgPath + "." + nodeType
+	override, hasOverride := common.LookupOverride(mergeCtx.Overrides, node, packagedType)
+	// Ignored fields are skipped by the functions of their struct
+	if hasOverride && override.Ignore && node.IsForField() {
+		return
+	}
+	if hasOverride && override.Merge != nil {
+		fn := override.Merge
+		ctxMerge := &data.Ctx{
+			ObjectKind:                 data.KindToString(node.Kind),
+			ObjectNameToHaveGeneration: node.Name,
+			LeftSideComparison:         "x",
+			RightSideComparison:        "y",
+			PkgPath:                    node.PkgPath,
+			Pkg:                        strings.Split(node.PackagedType, ".")[0],
+			Type:                       node.Type,
+		}
+		ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+		if node.UpNode == nil {
+			ctxMerge.MergeFuncName = fn.Name
+			ctxMerge.DefinedType = true
+			ctxMerge.MergeImplementation = utils.ExtractPkg(fn.Pkg) + "." + fn.Name + "(x, y)"
+		} else {
+			ctxMerge.MergeFuncName = utils.ExtractPkg(fn.Pkg) + "." + fn.Name
+		}
+
+		if ctxMerge.Imports == nil {
+			ctxMerge.Imports = make(map[string]struct{})
+		}
+		ctxMerge.Imports[fn.Pkg] = struct{}{}
+		return
+	}
+
+	// Values of types declared in other packages keep using their own methods
+	if node.Foreign && MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+	switch node.Kind {
+	case data.Struct:
+		// Structs with no field accessible from other packages are merged
+		// as a whole, as type parameters are
+		if node.Opaque {
+			MergeGeneratorTypeParam(node, ctx, mergeCtx)
+			break
+		}
+		MergeGeneratorStruct(node, ctx, mergeCtx)
+	case data.Builtin:
+		MergeGeneratorBuiltin(node, ctx, mergeCtx)
+	case data.Array:
+		MergeGeneratorArray(node, ctx, mergeCtx)
+	case data.Slice:
+		MergeGeneratorSlice(node, ctx, mergeCtx)
+	case data.Map:
+		MergeGeneratorMap(node, ctx, mergeCtx)
+	case data.Interface:
+		MergeGeneratorInterface(node, ctx, mergeCtx)
+	case data.Pointer:
+		MergeGeneratorPointer(node, ctx, mergeCtx)
+	case data.Func:
+		MergeGeneratorFunc(node, ctx, mergeCtx)
+	case data.TypeParam:
+		MergeGeneratorTypeParam(node, ctx, mergeCtx)
+	}
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
+	}
+}
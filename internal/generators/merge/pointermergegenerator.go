@@ -0,0 +1,99 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// A non-nil pointer to a builtin value replaces the original one, even when it
+// points to a zero value: setting the pointer is what marks the value as set.
+var mergePointerTemplateTxt = `func {{.MergeFuncName}}(x, y {{.ParameterType}}) {{.ParameterType}} {
+	if y == nil {
+		return x
+	}
+	{{ if  (eq .IsBuiltinSubNode "true") }}
+	return y
+	{{ else }}
+	if x == nil {
+		return y
+	}
+	merged := {{.MergeElement}}
+	return &merged
+	{{ end }}
+}`
+
+var mergePointerTemplate = template.Must(template.New("MergePointerTemplate").Parse(mergePointerTemplateTxt))
+
+func MergeGeneratorPointer(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Type == "" || node.GeneratesFunctions() {
+		MergeGeneratorPointerRawType(node, ctx, mergeCtx)
+		return
+	}
+	MergeGeneratorPointerDefinedType(node, ctx, mergeCtx)
+}
+
+func MergeGeneratorPointerDefinedType(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Pointer {
+		// TODO log error
+	}
+
+	if MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+
+	ctxMerge := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "*x",
+		RightSideComparison:        "*y",
+		MergeFuncName:              "Merge",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	MergeGeneratorPointerRawType(node, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	ctxMerge.MergeImplementation = ctxMerge.SubCtxs[0].MergeFuncName + "(x, y)"
+}
+
+func MergeGeneratorPointerRawType(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Pointer {
+		// TODO log error
+	}
+	subNode := node.SubNode
+	if subNode == nil {
+		// TODO log error
+	}
+	ctxMerge := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "*x",
+		RightSideComparison:        "*y",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+		Type:                       node.Type,
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	Generate(subNode, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	data.ApplyTemplateForMerge(node, ctxMerge, mergePointerTemplate)
+}
@@ -0,0 +1,93 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// Slices are not merged element by element: a non-empty slice replaces the
+// original one as a whole.
+var mergeSliceRawTemplateTxt = `func {{.MergeFuncName}}(x, y {{.ParameterType}}) {{.ParameterType}} {
+	if len(y) == 0 {
+		return x
+	}
+	return y
+}`
+
+var mergeSliceRawTemplate = template.Must(template.New("MergeSliceRawTemplate").Parse(mergeSliceRawTemplateTxt))
+
+func MergeGeneratorSlice(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Type == "" || node.GeneratesFunctions() {
+		MergeGeneratorSliceRawType(node, ctx, mergeCtx)
+		return
+	}
+	MergeGeneratorSliceDefinedType(node, ctx, mergeCtx)
+}
+
+func MergeGeneratorSliceRawType(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Slice {
+		// TODO log error
+	}
+	subNode := node.SubNode
+	if subNode == nil {
+		// TODO log error
+	}
+	ctxMerge := &data.Ctx{
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "vx",
+		RightSideComparison:        "vy",
+		ObjectKind:                 data.KindToString(node.Kind),
+		Imports:                    node.Imports,
+		Type:                       node.Type,
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	// Elements are not merged, but walking them still generates the Merge
+	// methods of the element types.
+	Generate(subNode, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	data.ApplyTemplateForMerge(node, ctxMerge, mergeSliceRawTemplate)
+}
+
+func MergeGeneratorSliceDefinedType(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if node.Kind != data.Slice {
+		// TODO log error
+	}
+	if MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+
+	ctxMerge := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		RightSideComparison:        "y",
+		MergeFuncName:              "Merge",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+		DefinedType:                true,
+		Imports:                    node.Imports,
+	}
+
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	MergeGeneratorSliceRawType(node, ctxMerge, mergeCtx)
+	ctxMerge.Err = ctxMerge.SubCtxs[0].Err
+	ctxMerge.MergeImplementation = ctxMerge.SubCtxs[0].MergeFuncName + "(x, y)"
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func MergeGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+
+	ctxMerge := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "rec",
+		RightSideComparison:        "obj",
+		MergeFuncName:              "Merge",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the value as argument is
+	// generated instead.
+	if node.GeneratesFunctions() {
+		ctxMerge.Function = true
+		ctxMerge.LeftSideComparison, ctxMerge.RightSideComparison = "x", "y"
+	}
+
+	for _, field := range node.Fields {
+		Generate(field, ctxMerge, mergeCtx)
+	}
+
+	ctxMerge.Imports = map[string]struct{}{}
+
+	implementation := strings.Builder{}
+
+	for i, subCtx := range ctxMerge.SubCtxs {
+		if subCtx.Err {
+			continue
+		}
+
+		for imp, marker := range subCtx.Imports {
+			ctxMerge.Imports[imp] = marker
+		}
+
+		if i != 0 {
+			implementation.WriteString("\n")
+		}
+		field := ctxMerge.LeftSideComparison + "." + subCtx.ObjectNameToHaveGeneration
+		switch {
+		case subCtx.MergeFuncName == "Merge":
+			implementation.WriteString(field + " = " + field + "." + subCtx.MergeFuncName + "(" +
+				ctxMerge.RightSideComparison + "." + subCtx.ObjectNameToHaveGeneration + ")")
+		case subCtx.MergeFuncName != "":
+			implementation.WriteString(field + " = " + subCtx.MergeFuncName + "(" + field + "," +
+				ctxMerge.RightSideComparison + "." + subCtx.ObjectNameToHaveGeneration + ")")
+		default:
+			implementation.WriteString(subCtx.MergeImplementation)
+		}
+	}
+	ctxMerge.MergeImplementation = implementation.String()
+	if node.GeneratesFunctions() {
+		declName, callName := data.GenericFuncNames(node, utils.MergeFuncName(data.FuncNameType(node)))
+		parameterType := data.GetTypeFromNode(node)
+		ctxMerge.MergeFuncName = callName
+		if ctxMerge.MergeImplementation != "" {
+			ctxMerge.MergeImplementation = "func " + declName + "(x, y " + parameterType + ") " + parameterType + " {\n" +
+				ctxMerge.MergeImplementation + "\nreturn x\n}"
+		}
+		for imp, marker := range node.Imports {
+			ctxMerge.Imports[imp] = marker
+		}
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+const mergeTypeParamTemplateTxt = `func {{.MergeFuncName}}(x, y {{.ParameterType}}) {{.ParameterType}} {
+	{{ if (eq .IsComparable "true") }}
+	var zero {{.ParameterType}}
+	if y == zero {
+	{{ else }}
+	if reflect.ValueOf(&y).Elem().IsZero() {
+	{{ end }}
+		return x
+	}
+	return y
+}`
+
+var mergeTypeParamTemplate = template.Must(template.New("MergeTypeParamTemplate").Parse(mergeTypeParamTemplateTxt))
+
+// MergeGeneratorTypeParam generates a function replacing a type parameter
+// value by the argument unless it is the zero value, as for builtins.
+func MergeGeneratorTypeParam(node *data.TypeNode, ctx *data.Ctx, mergeCtx MergeCtx) {
+	if MergeGeneratorForNodeWithMerge(node, ctx) {
+		return
+	}
+	parameterType := data.GetTypeFromNode(node)
+	declName, callName := data.GenericFuncNames(node, utils.MergeFuncName(data.FuncNameType(node)))
+	isComparable := "false"
+	if node.IsComparable {
+		isComparable = "true"
+	}
+	var sb strings.Builder
+	mergeTypeParamTemplate.Execute(&sb, map[string]string{
+		data.MergeFuncNameDataMap: declName,
+		data.ParameterTypeDataMap: parameterType,
+		"IsComparable":            isComparable,
+	})
+	ctxMerge := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "x",
+		RightSideComparison:        "y",
+		MergeFuncName:              callName,
+		MergeImplementation:        sb.String(),
+		Function:                   true,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge)
+}
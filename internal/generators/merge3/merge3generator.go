@@ -0,0 +1,205 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge3
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/generators/equal"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+type Merge3Ctx struct {
+	Overrides map[string]common.OverrideFuncs
+}
+
+// Generate generates the Merge3 method of a struct node, merging the changes
+// made to the receiver by two sides into a new value, and the ones of the
+// structs found in its fields. Values of other types are merged with the
+// functions of the eqdiff package, and compared with the expressions of their
+// Equal functions, which must be generated too.
+func Generate(node *data.TypeNode, ctx *data.Ctx, merge3Ctx Merge3Ctx) {
+	if node == nil || node.Err || node.Kind != data.Struct {
+		return
+	}
+	Merge3GeneratorStruct(node, ctx, merge3Ctx)
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
+	}
+}
+
+// isReplaced reports whether the values of a node are merged as a whole,
+// rather than member by member. Types with their own Equal method but no
+// Merge3 method, and values compared with an override, are merged as a
+// whole, as are builtin and interface values, anonymous structs, slices
+// compared regardless of order, diffed as insertions and deletions or as
+// whole values, and pointers whose nil value equals a pointer to the zero
+// value.
+func isReplaced(node *data.TypeNode, merge3Ctx Merge3Ctx) bool {
+	if node.HasMerge3 {
+		return false
+	}
+	if node.HasEqual || hasEqualOverride(node, merge3Ctx) {
+		return true
+	}
+	switch node.Kind {
+	case data.Slice:
+		return node.SubNode == nil || (node.SliceSet && node.SliceKey == "") ||
+			node.SliceLCS || node.SliceMoves || node.DiffScalar
+	case data.Pointer:
+		return node.SubNode == nil || node.NilEmpty
+	case data.Array, data.Map:
+		return node.SubNode == nil
+	case data.Struct:
+		return node.Type == ""
+	}
+	return true
+}
+
+// isGenerated reports whether the Merge3 function of a struct node is
+// generated.
+func isGenerated(node *data.TypeNode, merge3Ctx Merge3Ctx) bool {
+	return node != nil && node.Kind == data.Struct && !node.Err &&
+		!node.HasMerge3 && !isReplaced(node, merge3Ctx)
+}
+
+// hasEqualOverride reports whether the values of a node are compared with an
+// override.
+func hasEqualOverride(node *data.TypeNode, merge3Ctx Merge3Ctx) bool {
+	nodeType := node.Type
+	if nodeType == "" {
+		if pkgAndType := strings.SplitN(node.PackagedType, ".", 2); len(pkgAndType) > 1 {
+			nodeType = pkgAndType[0]
+		}
+	}
+	override, found := common.LookupOverride(merge3Ctx.Overrides, node, node.PkgPath+"."+nodeType)
+	return found && override.Equal != nil
+}
+
+// merge3Call returns the call of the merge function of a node which is not
+// merged as a whole, merging the values base, ours and theirs.
+func merge3Call(node *data.TypeNode, base, ours, theirs string, ctx *data.Ctx, merge3Ctx Merge3Ctx) string {
+	var merge3Helper string
+	switch node.Kind {
+	case data.Slice:
+		merge3Helper = "eqdiff.Merge3Slice"
+	case data.Map:
+		merge3Helper = "eqdiff.Merge3Map"
+	case data.Pointer:
+		merge3Helper = "eqdiff.Merge3Pointer"
+	}
+	if merge3Helper != "" && !node.HasMerge3 {
+		args := base + ", " + ours + ", " + theirs + ", " +
+			merge3Func(node.SubNode, ctx, merge3Ctx) + ", " + equalFunc(node.SubNode, ctx, merge3Ctx)
+		// Elements of slices diffed with the key option are matched by key
+		if node.Kind == data.Slice && node.SliceKey != "" {
+			return "eqdiff.Merge3KeyedSlice(" + args + ", " + strconv.Quote(node.SliceKeyName) + ", " + keyFunc(node, ctx) + ")"
+		}
+		return merge3Helper + "(" + args + ")"
+	}
+	if node.HasMerge3 || !node.GeneratesFunctions() {
+		return base + ".Merge3(" + ours + ", " + theirs + ")"
+	}
+	_, callName := data.GenericFuncNames(node, utils.Merge3FuncName(data.FuncNameType(node)))
+	return callName + "(" + base + ", " + ours + ", " + theirs + ")"
+}
+
+// arrayMerge3Call returns the call of eqdiff.Merge3Array merging the arrays
+// base, ours and theirs of a node into the addressable array merged.
+func arrayMerge3Call(node *data.TypeNode, merged, base, ours, theirs string, ctx *data.Ctx, merge3Ctx Merge3Ctx) string {
+	return "eqdiff.Merge3Array(" + merged + "[:], " + base + "[:], " + ours + "[:], " + theirs + "[:], " +
+		merge3Func(node.SubNode, ctx, merge3Ctx) + ", " + equalFunc(node.SubNode, ctx, merge3Ctx) + ")"
+}
+
+// merge3Func returns the function merging the values of a node, passed to the
+// functions of the eqdiff package merging their elements, or nil if they are
+// merged as a whole.
+func merge3Func(node *data.TypeNode, ctx *data.Ctx, merge3Ctx Merge3Ctx) string {
+	parameterType := data.GetTypeFromNode(node)
+	switch {
+	case isReplaced(node, merge3Ctx):
+		return "nil"
+	case node.Kind == data.Struct && (node.HasMerge3 || !node.GeneratesFunctions()):
+		// Method expression, taking the receiver as first argument
+		return parameterType + ".Merge3"
+	case node.Kind == data.Struct:
+		_, callName := data.GenericFuncNames(node, utils.Merge3FuncName(data.FuncNameType(node)))
+		return callName
+	}
+	signature := "func(base, ours, theirs " + parameterType + ") (" + parameterType + ", []eqdiff.Conflict) {\n"
+	if node.Kind == data.Array {
+		return signature + "merged := ours\n" +
+			"conflicts := " + arrayMerge3Call(node, "merged", "base", "ours", "theirs", ctx, merge3Ctx) + "\n" +
+			"return merged, conflicts\n}"
+	}
+	return signature + "return " + merge3Call(node, "base", "ours", "theirs", ctx, merge3Ctx) + "\n}"
+}
+
+// equalFunc returns the function testing the equality of the values of a
+// node, as their Equal function does.
+func equalFunc(node *data.TypeNode, ctx *data.Ctx, merge3Ctx Merge3Ctx) string {
+	equality := valueEquality(node, "x", "y", ctx, merge3Ctx)
+	if equality == "" {
+		ctx.Imports["reflect"] = struct{}{}
+		equality = "reflect.DeepEqual(x, y)"
+	}
+	parameterType := data.GetTypeFromNode(node)
+	return "func(x, y " + parameterType + ") bool {\nreturn " + equality + "\n}"
+}
+
+// keyFunc returns the function formatting the keys of the elements of a slice
+// node diffed with the key option, as its Diff function does.
+func keyFunc(node *data.TypeNode, ctx *data.Ctx) string {
+	ctx.Imports["fmt"] = struct{}{}
+	var nilCheck string
+	if node.SubNode.Kind == data.Pointer {
+		nilCheck = "if v == nil {\nreturn \"<nil>\"\n}\n"
+	}
+	key := "v." + node.SliceKey
+	// Pointer keys match elements by the values they point to
+	if node.SliceKeyPointer {
+		nilCheck += "if " + key + " == nil {\nreturn \"<nil>\"\n}\n"
+		key = "*" + key
+	}
+	return "func(v " + data.GetTypeFromNode(node.SubNode) + ") string {\n" +
+		nilCheck + "return fmt.Sprint(" + key + ")\n}"
+}
+
+// valueEquality returns the expression testing the equality of the values x
+// and y of a node which is not a field, or "" if they cannot be compared.
+func valueEquality(node *data.TypeNode, x, y string, ctx *data.Ctx, merge3Ctx Merge3Ctx) string {
+	ctxValue := &data.Ctx{
+		LeftSideComparison:  x,
+		RightSideComparison: y,
+	}
+	equal.Generate(node, ctxValue, equal.EqualCtx{Overrides: merge3Ctx.Overrides})
+	if len(ctxValue.SubCtxs) != 1 || ctxValue.SubCtxs[0].Err {
+		return ""
+	}
+	subCtx := ctxValue.SubCtxs[0]
+	for imp, marker := range subCtx.Imports {
+		ctx.Imports[imp] = marker
+	}
+	switch {
+	case subCtx.EqualFuncName == "Equal":
+		return x + ".Equal(" + y + ")"
+	case subCtx.EqualFuncName != "":
+		return subCtx.EqualFuncName + "(" + x + ", " + y + ")"
+	}
+	return subCtx.EqualImplementation
+}
@@ -0,0 +1,145 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package merge3
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/generators/equal"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func Merge3GeneratorStruct(node *data.TypeNode, ctx *data.Ctx, merge3Ctx Merge3Ctx) {
+	if node.HasMerge3 {
+		return
+	}
+
+	ctxMerge3 := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "base",
+		Merge3FuncName:             "Merge3",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMerge3)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the values as arguments
+	// is generated instead.
+	if node.GeneratesFunctions() {
+		ctxMerge3.Function = true
+		_, ctxMerge3.Merge3FuncName = data.GenericFuncNames(node, utils.Merge3FuncName(data.FuncNameType(node)))
+	}
+
+	// Types already visited have no fields: their Merge3 method is generated
+	// where they were first parsed.
+	if len(node.Fields) == 0 {
+		return
+	}
+
+	ctxMerge3.Imports = map[string]struct{}{utils.EqdiffPkgPath: {}}
+	base := ctxMerge3.LeftSideComparison
+	fields := strings.Builder{}
+	var hasNested bool
+	for _, field := range node.Fields {
+		generateNested(field, ctxMerge3, merge3Ctx)
+		if field.Err {
+			continue
+		}
+		// Values are compared with their Equal functions, ignored fields
+		// and fields which cannot be compared are skipped
+		unchangedOurs := fieldEquality(field, base, "ours", ctxMerge3, merge3Ctx)
+		if unchangedOurs == "" {
+			continue
+		}
+
+		key := strconv.Quote(field.DiffKey())
+		b, o, t, m := base+"."+field.Name, "ours."+field.Name, "theirs."+field.Name, "merged."+field.Name
+		switch {
+		case isReplaced(field, merge3Ctx):
+			fields.WriteString("switch {\n" +
+				"case " + unchangedOurs + ":\n" +
+				m + " = " + t + "\n" +
+				"case " + fieldEquality(field, base, "theirs", ctxMerge3, merge3Ctx) + ", " +
+				fieldEquality(field, "ours", "theirs", ctxMerge3, merge3Ctx) + ":\n" +
+				"default:\n" +
+				"conflicts = append(conflicts, eqdiff.Conflict{Path: " + key + ", Base: " + b + ", Ours: " + o + ", Theirs: " + t + "})\n" +
+				"}\n")
+			continue
+		case field.Kind == data.Array:
+			fields.WriteString("nested = " + arrayMerge3Call(field, m, b, o, t, ctxMerge3, merge3Ctx) + "\n")
+		default:
+			fields.WriteString(m + ", nested = " + merge3Call(field, b, o, t, ctxMerge3, merge3Ctx) + "\n")
+		}
+		fields.WriteString("conflicts = eqdiff.AppendConflicts(conflicts, " + key + ", nested)\n")
+		hasNested = true
+	}
+
+	implementation := strings.Builder{}
+	implementation.WriteString("merged := ours\n")
+	if hasNested {
+		implementation.WriteString("var conflicts, nested []eqdiff.Conflict\n")
+	} else {
+		implementation.WriteString("var conflicts []eqdiff.Conflict\n")
+	}
+	implementation.WriteString(fields.String())
+	ctxMerge3.Merge3Implementation = strings.TrimSuffix(implementation.String(), "\n")
+	if node.GeneratesFunctions() {
+		declName, _ := data.GenericFuncNames(node, utils.Merge3FuncName(data.FuncNameType(node)))
+		parameterType := data.GetTypeFromNode(node)
+		ctxMerge3.Merge3Implementation = "func " + declName + "(base, ours, theirs " + parameterType + ") (" +
+			parameterType + ", []eqdiff.Conflict) {\n" +
+			ctxMerge3.Merge3Implementation + "\nreturn merged, conflicts\n}"
+		for imp, marker := range node.Imports {
+			ctxMerge3.Imports[imp] = marker
+		}
+	}
+	for _, subCtx := range ctxMerge3.SubCtxs {
+		for imp, marker := range subCtx.Imports {
+			ctxMerge3.Imports[imp] = marker
+		}
+	}
+}
+
+// fieldEquality returns the expression testing the equality of the values of
+// a field in the structs x and y, or "" if they cannot be compared.
+func fieldEquality(field *data.TypeNode, x, y string, ctx *data.Ctx, merge3Ctx Merge3Ctx) string {
+	ctxField := &data.Ctx{
+		LeftSideComparison:  x,
+		RightSideComparison: y,
+	}
+	equal.Generate(field, ctxField, equal.EqualCtx{Overrides: merge3Ctx.Overrides})
+	if len(ctxField.SubCtxs) != 1 || ctxField.SubCtxs[0].Err {
+		return ""
+	}
+	for imp, marker := range ctxField.SubCtxs[0].Imports {
+		ctx.Imports[imp] = marker
+	}
+	return equal.FieldEquality(ctxField, ctxField.SubCtxs[0])
+}
+
+// generateNested generates the Merge3 functions of the structs held by a
+// field. They are generated for any struct having fields, even the ones only
+// found in slices, as the first node of a type met by the parser is the only
+// one holding its fields.
+func generateNested(field *data.TypeNode, ctx *data.Ctx, merge3Ctx Merge3Ctx) {
+	for node := field; node != nil; node = node.SubNode {
+		if node.Kind == data.Struct && len(node.Fields) > 0 && isGenerated(node, merge3Ctx) {
+			Merge3GeneratorStruct(node, ctx, merge3Ctx)
+		}
+	}
+}
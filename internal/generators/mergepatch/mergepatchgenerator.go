@@ -0,0 +1,133 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package mergepatch
+
+import (
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+type MergePatchCtx struct {
+	Overrides map[string]common.OverrideFuncs
+}
+
+// Generate generates the MergePatch method of a struct node, returning the
+// JSON merge patch (RFC 7396) turning the receiver into the argument, and the
+// ones of the structs found in its fields. Values of other types are compared
+// with the expressions of their Equal functions, which must be generated too.
+func Generate(node *data.TypeNode, ctx *data.Ctx, mergePatchCtx MergePatchCtx) {
+	if node == nil || node.Err || node.Kind != data.Struct {
+		return
+	}
+	MergePatchGeneratorStruct(node, ctx, mergePatchCtx)
+	// Methods of generic types are declared on the type with its parameters
+	if node.IsGenericDeclaration() && len(ctx.SubCtxs) > 0 {
+		ctx.SubCtxs[len(ctx.SubCtxs)-1].TypeArgs = node.TypeArgs
+	}
+}
+
+// jsonField returns the name of the member encoding a field in JSON objects
+// and whether it is omitted when empty, as encoding/json does. Fields which
+// are not encoded have no name, embedded fields without a name in their json
+// tag are named after their type.
+func jsonField(field *data.TypeNode) (string, bool) {
+	tag := reflect.StructTag(field.StructTag).Get("json")
+	if tag == "-" || (!token.IsExported(field.Name) && !field.Embedded) {
+		return "", false
+	}
+	name, options, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = field.Name
+	}
+	return name, slices.Contains(strings.Split(options, ","), "omitempty")
+}
+
+// isFlattened reports whether the members of an embedded field are members of
+// the JSON object of its struct, as encoding/json does with embedded structs
+// and pointers to structs.
+func isFlattened(field *data.TypeNode) bool {
+	name, _, _ := strings.Cut(reflect.StructTag(field.StructTag).Get("json"), ",")
+	if !field.Embedded || name != "" {
+		return false
+	}
+	return field.Kind == data.Struct ||
+		(field.Kind == data.Pointer && field.SubNode != nil && field.SubNode.Kind == data.Struct)
+}
+
+// isPatched reports whether the values of a node are patched member by member
+// with their MergePatch function, rather than replaced as a whole. Types with
+// their own Equal method or JSON encoding but no MergePatch method, and
+// fields compared with an override, are replaced as a whole, as are anonymous
+// structs.
+func isPatched(node *data.TypeNode, mergePatchCtx MergePatchCtx) bool {
+	if node == nil || node.Kind != data.Struct || node.Err || node.Type == "" {
+		return false
+	}
+	if node.HasMergePatch {
+		return true
+	}
+	return !node.HasEqual && !node.HasJSONMarshaler && !hasEqualOverride(node, mergePatchCtx)
+}
+
+// isPatchedMap reports whether the maps of a node are patched entry by entry,
+// rather than replaced as a whole. Entries are compared with the functions
+// generated in the package of the patched struct, pkgPath: maps declared in
+// other packages are replaced as a whole, as are maps with their own Equal
+// method or JSON encoding, or compared with an override.
+func isPatchedMap(node *data.TypeNode, pkgPath string, mergePatchCtx MergePatchCtx) bool {
+	if node == nil || node.Kind != data.Map || node.Err || node.SubNode == nil {
+		return false
+	}
+	if node.Type != "" && node.PkgPath != pkgPath {
+		return false
+	}
+	return !node.HasEqual && !node.HasJSONMarshaler && !hasEqualOverride(node, mergePatchCtx)
+}
+
+// hasEqualOverride reports whether the values of a node are compared with an
+// override.
+func hasEqualOverride(node *data.TypeNode, mergePatchCtx MergePatchCtx) bool {
+	nodeType := node.Type
+	if nodeType == "" {
+		if pkgAndType := strings.SplitN(node.PackagedType, ".", 2); len(pkgAndType) > 1 {
+			nodeType = pkgAndType[0]
+		}
+	}
+	override, found := common.LookupOverride(mergePatchCtx.Overrides, node, node.PkgPath+"."+nodeType)
+	return found && override.Equal != nil
+}
+
+// emptyTests returns the expressions testing whether the value x of a node is
+// omitted by encoding/json from the members of its struct with the omitempty
+// option and whether it is not, or "" if it never is.
+func emptyTests(node *data.TypeNode, x string) (string, string) {
+	switch node.Kind {
+	case data.Builtin:
+		if node.BuiltinKind == "bool" {
+			return "!" + x, x
+		}
+		zero := data.ZeroValue(node)
+		return x + " == " + zero, x + " != " + zero
+	case data.Pointer, data.Interface:
+		return x + " == nil", x + " != nil"
+	case data.Slice, data.Map:
+		return "len(" + x + ") == 0", "len(" + x + ") != 0"
+	}
+	return "", ""
+}
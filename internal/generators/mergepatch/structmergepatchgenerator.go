@@ -0,0 +1,247 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package mergepatch
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/generators/equal"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+func MergePatchGeneratorStruct(node *data.TypeNode, ctx *data.Ctx, mergePatchCtx MergePatchCtx) {
+	if node.HasMergePatch {
+		return
+	}
+
+	ctxMergePatch := &data.Ctx{
+		ObjectKind:                 data.KindToString(node.Kind),
+		ObjectNameToHaveGeneration: node.Name,
+		LeftSideComparison:         "rec",
+		RightSideComparison:        "obj",
+		MergePatchFuncName:         "MergePatch",
+		PkgPath:                    node.PkgPath,
+		Pkg:                        strings.Split(node.PackagedType, ".")[0],
+		Type:                       node.Type,
+	}
+	ctx.SubCtxs = append(ctx.SubCtxs, ctxMergePatch)
+	// Methods cannot be declared on instances of generic types, nor be
+	// dedicated to a field path: a function taking the values as arguments
+	// is generated instead.
+	if node.GeneratesFunctions() {
+		ctxMergePatch.Function = true
+		ctxMergePatch.LeftSideComparison, ctxMergePatch.RightSideComparison = "x", "y"
+		_, ctxMergePatch.MergePatchFuncName = data.GenericFuncNames(node, utils.MergePatchFuncName(data.FuncNameType(node)))
+	}
+
+	// Types already visited have no fields: their MergePatch method is
+	// generated where they were first parsed.
+	if len(node.Fields) == 0 {
+		return
+	}
+
+	ctxMergePatch.Imports = map[string]struct{}{"encoding/json": {}}
+	// Structs encoded with their own method, such as the ones embedding a
+	// type implementing json.Marshaler, are replaced as a whole
+	if node.HasJSONMarshaler {
+		ctxMergePatch.MergePatchImplementation = "var patch interface{} = map[string]interface{}{}\n" +
+			"if !" + ctxMergePatch.LeftSideComparison + ".Equal(" + ctxMergePatch.RightSideComparison + ") {\n" +
+			"patch = " + ctxMergePatch.RightSideComparison + "\n}"
+		return
+	}
+	// Members of embedded structs are patched first, for the fields of the
+	// struct to take precedence over them.
+	embedded := strings.Builder{}
+	members := strings.Builder{}
+	for _, field := range node.Fields {
+		generateNested(field, ctxMergePatch, mergePatchCtx)
+
+		name, omitEmpty := jsonField(field)
+		if name == "" || field.Err {
+			continue
+		}
+		// Values are compared with their Equal functions, ignored fields
+		// and fields which cannot be compared are skipped
+		ctxField := &data.Ctx{
+			LeftSideComparison:  ctxMergePatch.LeftSideComparison,
+			RightSideComparison: ctxMergePatch.RightSideComparison,
+		}
+		equal.Generate(field, ctxField, equal.EqualCtx{Overrides: mergePatchCtx.Overrides})
+		if len(ctxField.SubCtxs) != 1 || ctxField.SubCtxs[0].Err {
+			continue
+		}
+		subCtx := ctxField.SubCtxs[0]
+		for imp, marker := range subCtx.Imports {
+			ctxMergePatch.Imports[imp] = marker
+		}
+
+		x := ctxMergePatch.LeftSideComparison + "." + field.Name
+		y := ctxMergePatch.RightSideComparison + "." + field.Name
+		inequality := equal.FieldInequality(ctxField, subCtx)
+		if isFlattened(field) {
+			ctxMergePatch.Imports[utils.EqdiffPkgPath] = struct{}{}
+			embedded.WriteString(embeddedPatch(field, x, y, inequality, mergePatchCtx))
+			continue
+		}
+		key := "patch[" + strconv.Quote(name) + "]"
+		switch {
+		case isPatched(field, mergePatchCtx):
+			members.WriteString(structPatch(field, key, x, y))
+		case field.Kind == data.Pointer && isPatched(field.SubNode, mergePatchCtx):
+			members.WriteString("if " + x + " == nil || " + y + " == nil {\n" +
+				"if " + x + " != nil || " + y + " != nil {\n" + key + " = " + y + "\n}\n" +
+				"} else " + structPatch(field.SubNode, key, "*"+x, "*"+y))
+		case isPatchedMap(field, node.PkgPath, mergePatchCtx):
+			ctxMergePatch.Imports[utils.EqdiffPkgPath] = struct{}{}
+			// Empty maps are omitted rather than encoded as empty objects
+			if omitEmpty {
+				members.WriteString("if len(" + y + ") == 0 {\n" +
+					"if len(" + x + ") != 0 {\n" + key + " = nil\n}\n" +
+					"} else if len(" + x + ") == 0 {\n" + key + " = " + y + "\n" +
+					"} else ")
+			}
+			members.WriteString("if fieldPatch, changed, err := eqdiff.MergePatchMap(" + x + ", " + y + ", " +
+				entryPatchFunc(field, ctxMergePatch, mergePatchCtx) + "); err != nil {\n" +
+				"return nil, err\n" +
+				"} else if changed {\n" + key + " = fieldPatch\n}\n")
+		default:
+			// Empty values are omitted rather than encoded
+			if empty, _ := emptyTests(field, y); omitEmpty && empty != "" {
+				_, notEmpty := emptyTests(field, x)
+				members.WriteString("if " + empty + " {\n" +
+					"if " + notEmpty + " {\n" + key + " = nil\n}\n" +
+					"} else ")
+			}
+			members.WriteString("if " + inequality + " {\n" + key + " = " + y + "\n}\n")
+		}
+	}
+
+	implementation := strings.Builder{}
+	implementation.WriteString("patch := map[string]interface{}{}\n")
+	implementation.WriteString(embedded.String())
+	implementation.WriteString(members.String())
+	ctxMergePatch.MergePatchImplementation = strings.TrimSuffix(implementation.String(), "\n")
+	if node.GeneratesFunctions() {
+		declName, _ := data.GenericFuncNames(node, utils.MergePatchFuncName(data.FuncNameType(node)))
+		parameterType := data.GetTypeFromNode(node)
+		ctxMergePatch.MergePatchImplementation = "func " + declName + "(x, y " + parameterType + ") ([]byte, error) {\n" +
+			ctxMergePatch.MergePatchImplementation + "\nreturn json.Marshal(patch)\n}"
+		for imp, marker := range node.Imports {
+			ctxMergePatch.Imports[imp] = marker
+		}
+	}
+	for _, subCtx := range ctxMergePatch.SubCtxs {
+		for imp, marker := range subCtx.Imports {
+			ctxMergePatch.Imports[imp] = marker
+		}
+	}
+}
+
+// generateNested generates the MergePatch functions of the structs held by a
+// field. They are generated for any struct having fields, even the ones only
+// found in slices, as the first node of a type met by the parser is the only
+// one holding its fields.
+func generateNested(field *data.TypeNode, ctx *data.Ctx, mergePatchCtx MergePatchCtx) {
+	for node := field; node != nil; node = node.SubNode {
+		if node.Kind == data.Struct && len(node.Fields) > 0 && isPatched(node, mergePatchCtx) {
+			MergePatchGeneratorStruct(node, ctx, mergePatchCtx)
+		}
+	}
+}
+
+// mergePatchCall returns the call of the MergePatch function of a patched
+// struct node, turning x into y.
+func mergePatchCall(node *data.TypeNode, x, y string) string {
+	if node.HasMergePatch || !node.GeneratesFunctions() {
+		if strings.HasPrefix(x, "*") {
+			x = "(" + x + ")"
+		}
+		return x + ".MergePatch(" + y + ")"
+	}
+	_, callName := data.GenericFuncNames(node, utils.MergePatchFuncName(data.FuncNameType(node)))
+	return callName + "(" + x + ", " + y + ")"
+}
+
+// structPatch returns the statement setting key to the merge patch of the
+// values x and y of a patched struct node, unless it is empty.
+func structPatch(node *data.TypeNode, key, x, y string) string {
+	return "if fieldPatch, err := " + mergePatchCall(node, x, y) + "; err != nil {\n" +
+		"return nil, err\n" +
+		"} else if string(fieldPatch) != \"{}\" {\n" + key + " = json.RawMessage(fieldPatch)\n}\n"
+}
+
+// embeddedPatch returns the statement adding to the patch the members of the
+// values x and y of an embedded field flattened into its struct.
+func embeddedPatch(field *data.TypeNode, x, y, inequality string, mergePatchCtx MergePatchCtx) string {
+	structNode := field
+	if field.Kind == data.Pointer {
+		structNode = field.SubNode
+	}
+	if !isPatched(structNode, mergePatchCtx) {
+		if field.Kind == data.Struct {
+			x, y = "&"+x, "&"+y
+		}
+		return "if " + inequality + " {\n" +
+			"if err := eqdiff.MergePatchEmbedded(patch, " + x + ", " + y + ", nil); err != nil {\n" +
+			"return nil, err\n}\n}\n"
+	}
+	call := mergePatchCall(structNode, x, y)
+	if field.Kind == data.Struct {
+		x, y = "&"+x, "&"+y
+	} else {
+		call = mergePatchCall(structNode, "*"+x, "*"+y)
+	}
+	return "if err := eqdiff.MergePatchEmbedded(patch, " + x + ", " + y + ", func() ([]byte, error) {\n" +
+		"return " + call + "\n" +
+		"}); err != nil {\n" +
+		"return nil, err\n}\n"
+}
+
+// entryPatchFunc returns the function literal passed to eqdiff.MergePatchMap
+// to patch the entries found in both maps of a patched map node.
+func entryPatchFunc(node *data.TypeNode, ctx *data.Ctx, mergePatchCtx MergePatchCtx) string {
+	subNode := node.SubNode
+	var body string
+	switch {
+	case isPatched(subNode, mergePatchCtx):
+		body = "entryPatch, err := " + mergePatchCall(subNode, "vx", "vy") + "\n" +
+			"return json.RawMessage(entryPatch), string(entryPatch) != \"{}\", err"
+	case subNode.Kind == data.Pointer && isPatched(subNode.SubNode, mergePatchCtx):
+		body = "if vx == nil || vy == nil {\n" +
+			"return vy, vx != nil || vy != nil, nil\n}\n" +
+			"entryPatch, err := " + mergePatchCall(subNode.SubNode, "*vx", "*vy") + "\n" +
+			"return json.RawMessage(entryPatch), string(entryPatch) != \"{}\", err"
+	case isPatchedMap(subNode, ctx.PkgPath, mergePatchCtx):
+		body = "return eqdiff.MergePatchMap(vx, vy, " + entryPatchFunc(subNode, ctx, mergePatchCtx) + ")"
+	default:
+		// Entries are compared as the Equal function of the map does
+		ctxEntry := &data.Ctx{
+			LeftSideComparison:  "vx",
+			RightSideComparison: "vy",
+		}
+		equal.Generate(subNode, ctxEntry, equal.EqualCtx{Overrides: mergePatchCtx.Overrides})
+		inequality := data.GetTemplateDataFromSubNodeEqual(node, ctxEntry)[data.InequalityTestDataMap]
+		if len(ctxEntry.SubCtxs) != 1 || ctxEntry.SubCtxs[0].Err || inequality == "" {
+			inequality = "true"
+		} else {
+			for imp, marker := range ctxEntry.SubCtxs[0].Imports {
+				ctx.Imports[imp] = marker
+			}
+		}
+		body = "return vy, " + inequality + ", nil"
+	}
+	return "func(vx, vy " + data.GetTypeFromNode(subNode) + ") (interface{}, bool, error) {\n" + body + "\n}"
+}
@@ -0,0 +1,545 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package parser
+
+import (
+	"fmt"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/utils"
+)
+
+// The functions of this file populate the same TypeNode trees as their reflect
+// based counterparts, from the go/types information of loaded packages. They
+// allow generating code without compiling and running a program holding the
+// reflect.Type values.
+
+// goTypeName returns the name of a named, builtin or type parameter type, like
+// reflect.Type.Name. Instances of generic types are named after their generic
+// type, see goTypeInstanceName.
+func goTypeName(typ types.Type) string {
+	switch t := types.Unalias(typ).(type) {
+	case *types.Named:
+		return t.Obj().Name()
+	case *types.Basic:
+		return types.Typ[t.Kind()].Name()
+	case *types.TypeParam:
+		return t.Obj().Name()
+	}
+	return ""
+}
+
+// goTypeInstance returns the named type if typ is an instance of a generic
+// type. The generic type referenced in its own declaration, with its own type
+// parameters as arguments, is not considered as an instance.
+func goTypeInstance(typ types.Type) *types.Named {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.TypeArgs().Len() == 0 {
+		return nil
+	}
+	typeParams := named.Origin().TypeParams()
+	for i := 0; i < named.TypeArgs().Len(); i++ {
+		if named.TypeArgs().At(i) != typeParams.At(i) {
+			return named
+		}
+	}
+	return nil
+}
+
+// goTypeInstanceName returns the name of an instance of a generic type with
+// its type arguments qualified for the package pkg (e.g., "Pair[string, *Backend]"),
+// as utils.InstanceTypeName does for reflect. The import paths of the type
+// arguments are added to imports.
+func goTypeInstanceName(named *types.Named, pkg string, imports map[string]struct{}) string {
+	qualifier := utils.GoTypeRelativeQualifier(pkg, imports)
+	args := make([]string, named.TypeArgs().Len())
+	for i := range args {
+		args[i] = types.TypeString(named.TypeArgs().At(i), qualifier)
+	}
+	return named.Obj().Name() + "[" + strings.Join(args, ", ") + "]"
+}
+
+// goTypeGenericDeclaration returns the named type if typ is a generic type
+// declaration, which is not instantiated.
+func goTypeGenericDeclaration(typ types.Type) *types.Named {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 || goTypeInstance(named) != nil {
+		return nil
+	}
+	return named
+}
+
+// goTypeHasTypeParam returns true if the type is a type parameter or is built
+// from type parameters.
+func goTypeHasTypeParam(typ types.Type) bool {
+	switch t := types.Unalias(typ).(type) {
+	case *types.TypeParam:
+		return true
+	case *types.Named:
+		if goTypeGenericDeclaration(t) != nil {
+			return true
+		}
+		for i := 0; i < t.TypeArgs().Len(); i++ {
+			if goTypeHasTypeParam(t.TypeArgs().At(i)) {
+				return true
+			}
+		}
+	case *types.Pointer:
+		return goTypeHasTypeParam(t.Elem())
+	case *types.Slice:
+		return goTypeHasTypeParam(t.Elem())
+	case *types.Array:
+		return goTypeHasTypeParam(t.Elem())
+	case *types.Map:
+		return goTypeHasTypeParam(t.Key()) || goTypeHasTypeParam(t.Elem())
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if goTypeHasTypeParam(t.Field(i).Type()) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// goTypePkgPath returns the package path of a named type, like reflect.Type.PkgPath.
+func goTypePkgPath(typ types.Type) string {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return named.Obj().Pkg().Path()
+}
+
+// goTypeString returns the type qualified with package names, like reflect.Type.String.
+func goTypeString(typ types.Type) string {
+	if basic, ok := types.Unalias(typ).(*types.Basic); ok {
+		return types.Typ[basic.Kind()].Name()
+	}
+	return types.TypeString(typ, utils.GoTypeQualifier)
+}
+
+// GoTypeAlreadyVisited checks if a type has already been processed in the current parsing context.
+// It is the go/types counterpart of TypeAlreadyVisited.
+func GoTypeAlreadyVisited(typ types.Type, pkg string, fqnTypesProcessed map[string]struct{}) bool {
+	if _, ok := types.Unalias(typ).(*types.Named); !ok {
+		return false
+	}
+	// Build the fully-qualified name
+	fqnType := goTypePkgPath(typ) + "." + goTypeName(typ)
+	// Functions of generic type instances are generated in the package of their referer
+	if named := goTypeInstance(typ); named != nil {
+		fqnType = goTypePkgPath(typ) + "." + goTypeInstanceName(named, "", nil) + "@" + pkg
+	}
+
+	// If found, it has already been visited
+	if _, found := fqnTypesProcessed[fqnType]; found {
+		return true
+	}
+
+	// Otherwise, mark it as visited
+	fqnTypesProcessed[fqnType] = struct{}{}
+	return false
+}
+
+// ParseGoType recursively analyzes the given types.Type and fills the provided TypeNode
+// with its structural and metadata information.
+//
+// node: the current TypeNode to populate
+// typ: the types.Type being parsed
+// pkg: the package of the parent referer
+// fqnTypesProcessed: map to track already parsed types (avoiding recursion loops)
+func ParseGoType(node *data.TypeNode, typ types.Type, pkg string, fqnTypesProcessed map[string]struct{}) {
+	typ = types.Unalias(typ)
+	// The underlying type of a type parameter is its constraint
+	if _, ok := typ.(*types.TypeParam); ok {
+		ParseGoTypeTypeParam(node, typ, pkg)
+		return
+	}
+	switch underlying := typ.Underlying().(type) {
+	case *types.Array:
+		ParseGoTypeArray(node, typ, pkg, fqnTypesProcessed)
+	case *types.Slice:
+		ParseGoTypeSlice(node, typ, pkg, fqnTypesProcessed)
+	case *types.Pointer:
+		ParseGoTypePointer(node, typ, pkg, fqnTypesProcessed)
+	case *types.Struct:
+		ParseGoTypeStructure(node, typ, pkg, fqnTypesProcessed)
+	case *types.Map:
+		ParseGoTypeMap(node, typ, pkg, fqnTypesProcessed)
+	case *types.Interface:
+		ParseGoTypeInterface(node, typ, pkg, fqnTypesProcessed)
+	case *types.Signature:
+		ParseGoTypeFunc(node, typ, pkg)
+	case *types.Chan:
+		ParseGoTypeUnsupported(node, typ, pkg)
+	case *types.Basic:
+		switch {
+		case underlying.Kind() == types.UnsafePointer:
+			ParseGoTypeUnsupported(node, typ, pkg)
+		case underlying.Info()&types.IsUntyped == 0:
+			ParseGoTypeBuiltin(node, pkg, typ)
+		}
+	}
+}
+
+// ParseGoTypeBuiltin handles built-in Go types (string, int, bool, etc.).
+// It sets the node kind to Builtin and determines if the type belongs to the same package.
+func ParseGoTypeBuiltin(node *data.TypeNode, pkg string, typ types.Type) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Builtin
+	node.BuiltinKind = goTypeName(typ.Underlying())
+	node.SamePkgAsReferer = true
+	if node.PkgPath != "" {
+		node.SamePkgAsReferer = pkg == node.PkgPath
+	}
+}
+
+// ParseGoTypeTypeParam handles the type parameters of generic type declarations.
+// Their values are compared and merged according to their constraint.
+func ParseGoTypeTypeParam(node *data.TypeNode, typ types.Type, pkg string) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.TypeParam
+	node.SamePkgAsReferer = true
+}
+
+// ParseGoTypeInterface handles interface types.
+// It marks the node as an interface, sets SamePkgAsReferer, and flags Err=true
+// (unsupported for equality) until its implementations, if any, are
+// registered, see DiscoverImplementations.
+func ParseGoTypeInterface(node *data.TypeNode, typ types.Type, pkg string, typesProcessed map[string]struct{}) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Interface
+	node.SamePkgAsReferer = pkg == node.PkgPath
+	node.InterfaceGoType = typ
+	node.RefererPkgPath = pkg
+	node.Err = true
+}
+
+// ParseGoTypeStructure handles struct types and parses their fields.
+func ParseGoTypeStructure(node *data.TypeNode, typ types.Type, pkg string, typesProcessed map[string]struct{}) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Struct
+	node.SamePkgAsReferer = pkg == node.PkgPath
+	referer := pkg
+	// Functions of generic type instances are generated in the package of
+	// their referer, other fields are generated in the package of the struct
+	if !node.IsInstantiated() {
+		pkg = node.PkgPath
+	}
+	// Initialize imports set
+	if node.Imports == nil {
+		node.Imports = map[string]struct{}{}
+	}
+	if !node.SamePkgAsReferer {
+		node.Imports[node.PkgPath] = struct{}{}
+	}
+
+	node.KeyFields = goTypeKeyFields(typ)
+	node.Opaque = node.Type != "" && !node.SamePkgAsReferer && !goTypeHasExportedField(typ)
+
+	// Avoid re-parsing types we've already seen
+	if GoTypeAlreadyVisited(typ, referer, typesProcessed) {
+		return
+	}
+	// Only parse fields if the struct has no custom Equal method
+	if !node.HasEqual {
+		StructFieldsEqualGoType(node, typ, pkg, typesProcessed)
+	}
+	// Err will be true only if all fields have Err set to true
+	node.Err = !node.HasEqual
+	for _, field := range node.Fields {
+		node.Err = node.Err && field.Err
+	}
+}
+
+// goTypeHasExportedField reports whether a struct has exported fields, which
+// functions of other packages may access. It is the go/types counterpart of
+// hasExportedField.
+func goTypeHasExportedField(typ types.Type) bool {
+	structType := typ.Underlying().(*types.Struct)
+	for i := 0; i < structType.NumFields(); i++ {
+		if structType.Field(i).Exported() {
+			return true
+		}
+	}
+	return false
+}
+
+// goTypeKeyFields returns the fields of a struct, promoted ones included, by
+// which slice elements may be matched. It is the go/types counterpart of
+// keyFields: fields hidden by other fields, or ambiguous, are left out.
+func goTypeKeyFields(typ types.Type) map[string]data.KeyField {
+	candidates := map[string]*types.Package{}
+	visited := map[types.Type]struct{}{}
+	var collect func(typ types.Type)
+	collect = func(typ types.Type) {
+		if pointer, ok := types.Unalias(typ).(*types.Pointer); ok {
+			typ = pointer.Elem()
+		}
+		structType, ok := typ.Underlying().(*types.Struct)
+		if _, seen := visited[typ]; seen || !ok {
+			return
+		}
+		visited[typ] = struct{}{}
+		for i := 0; i < structType.NumFields(); i++ {
+			field := structType.Field(i)
+			candidates[field.Name()] = field.Pkg()
+			if field.Embedded() {
+				collect(field.Type())
+			}
+		}
+	}
+	collect(typ)
+	fields := map[string]data.KeyField{}
+	for name, pkg := range candidates {
+		field, index, _ := types.LookupFieldOrMethod(typ, false, pkg, name)
+		if v, ok := field.(*types.Var); ok && v.IsField() {
+			fieldType := v.Type()
+			var keyField data.KeyField
+			if pointer, ok := fieldType.Underlying().(*types.Pointer); ok {
+				keyField.Pointer = true
+				fieldType = pointer.Elem()
+			}
+			keyField.Comparable = types.Comparable(fieldType)
+			keyField.Tag = goTypeFieldTag(typ, index)
+			fields[name] = keyField
+		}
+	}
+	return fields
+}
+
+// goTypeFieldTag returns the struct tag of the field of a struct found at
+// index, as returned by types.LookupFieldOrMethod, through embedded structs.
+func goTypeFieldTag(typ types.Type, index []int) string {
+	for i, fieldIndex := range index {
+		if pointer, ok := types.Unalias(typ).(*types.Pointer); ok {
+			typ = pointer.Elem()
+		}
+		structType, ok := typ.Underlying().(*types.Struct)
+		if !ok {
+			return ""
+		}
+		if i == len(index)-1 {
+			return structType.Tag(fieldIndex)
+		}
+		typ = structType.Field(fieldIndex).Type()
+	}
+	return ""
+}
+
+// ParseGoTypeFunc handles function types.
+// It marks them as unsupported (Err=true).
+func ParseGoTypeFunc(node *data.TypeNode, typ types.Type, pkg string) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Func
+	node.Err = true
+}
+
+// ParseGoTypeUnsupported handles types whose values cannot be compared, such
+// as channels. It marks them as unsupported (Err=true).
+func ParseGoTypeUnsupported(node *data.TypeNode, typ types.Type, pkg string) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	if basic, ok := typ.(*types.Basic); ok && basic.Kind() == types.UnsafePointer {
+		// Named "Pointer" by go/types, as it is declared in package unsafe
+		node.PackagedType = "unsafe.Pointer"
+	}
+	node.Kind = data.Unknown
+	node.Err = true
+}
+
+// ParseGoTypeMap handles map types.
+// It parses both the key type and value type recursively and merges their imports.
+func ParseGoTypeMap(node *data.TypeNode, typ types.Type, pkg string, typesProcessed map[string]struct{}) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Map
+	mapType := typ.Underlying().(*types.Map)
+	node.MapKeyType = goTypeName(mapType.Key())
+	// Parse the map value type
+	mapNode := &data.TypeNode{
+		UpNode: node,
+	}
+	node.SubNode = mapNode
+	ParseGoType(mapNode, mapType.Elem(), pkg, typesProcessed)
+	// Update PkgPath depending on whether the type is named or anonymous
+	node.PkgPath = goTypePkgPath(mapType.Key())
+	if node.Type != "" {
+		node.PkgPath = goTypePkgPath(typ)
+	}
+	node.PackagedType = goTypeString(mapType.Key())
+	node.SamePkgAsReferer = pkg == node.PkgPath
+	// Merge imports from the value type (SubNode) and key type
+	node.Imports = map[string]struct{}{}
+	for subNodeImport := range node.SubNode.Imports {
+		node.Imports[subNodeImport] = struct{}{}
+	}
+	if !node.SamePkgAsReferer && node.PkgPath != "" {
+		node.Imports[node.PkgPath] = struct{}{}
+	}
+	node.Err = mapNode.Err
+}
+
+// ParseGoTypeArray handles fixed-length array types.
+// It parses the element type recursively.
+func ParseGoTypeArray(node *data.TypeNode, typ types.Type, pkg string, typesProcessed map[string]struct{}) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Array
+	arrayType := typ.Underlying().(*types.Array)
+	node.Len = int(arrayType.Len())
+	arrayNode := &data.TypeNode{
+		UpNode: node,
+	}
+	node.SubNode = arrayNode
+	ParseGoType(arrayNode, arrayType.Elem(), pkg, typesProcessed)
+	// Propagate PkgPath and imports from the element type
+	node.PkgPath = node.SubNode.PkgPath
+	if node.Type != "" {
+		node.PkgPath = goTypePkgPath(typ)
+	}
+	node.Imports = node.SubNode.Imports
+	node.Err = arrayNode.Err
+}
+
+// ParseGoTypeSlice handles slice types.
+// It parses the element type recursively and tracks whether it's in the same package.
+func ParseGoTypeSlice(node *data.TypeNode, typ types.Type, pkg string, typesProcessed map[string]struct{}) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Slice
+	sliceType := typ.Underlying().(*types.Slice)
+	sliceNode := &data.TypeNode{
+		UpNode:           node,
+		SamePkgAsReferer: node.Type != "",
+	}
+	node.SubNode = sliceNode
+	if node.Type != "" && !node.IsInstantiated() {
+		// Named slice type → same package as referer
+		pkg = node.PkgPath
+		node.SamePkgAsReferer = true
+	}
+	ParseGoType(sliceNode, sliceType.Elem(), pkg, typesProcessed)
+	// If package path not yet set, inherit from element type
+	if node.PkgPath == "" {
+		node.PkgPath = node.SubNode.PkgPath
+	}
+	node.Imports = node.SubNode.Imports
+	node.Err = sliceNode.Err
+}
+
+// ParseGoTypePointer handles pointer types.
+// It parses the pointed-to type recursively and inherits its packaged type and imports.
+func ParseGoTypePointer(node *data.TypeNode, typ types.Type, pkg string, typesProcessed map[string]struct{}) {
+	DefaultGoTypeParsing(node, typ, pkg)
+	node.Kind = data.Pointer
+	pointerType := typ.Underlying().(*types.Pointer)
+	pointerNode := &data.TypeNode{
+		UpNode: node,
+	}
+	node.SubNode = pointerNode
+	ParseGoType(pointerNode, pointerType.Elem(), pkg, typesProcessed)
+	if node.Type == "" {
+		node.PkgPath = node.SubNode.PkgPath
+	}
+	node.PackagedType = node.SubNode.PackagedType
+	node.Imports = node.SubNode.Imports
+	node.Err = pointerNode.Err
+}
+
+// StructFieldsEqualGoType parses the fields of a struct for equality/diff generation.
+// It skips certain predefined meta types and fields tagged `gmg:"-"`, and parses
+// each remaining field recursively with the options of its gmg tag.
+func StructFieldsEqualGoType(node *data.TypeNode, typ types.Type, pkg string, typesProcessed map[string]struct{}) {
+	structType := typ.Underlying().(*types.Struct)
+	for i := 0; i < structType.NumFields(); i++ {
+		fieldType := structType.Field(i)
+		// Skip predefined meta types (e.g., Kubernetes ObjectMeta)
+		_, toSkip := typesToSkip[goTypeString(fieldType.Type())]
+		fieldTag, tagErr := ParseFieldTag(reflect.StructTag(structType.Tag(i)))
+		if toSkip || fieldTag.Skip {
+			continue
+		}
+		equalNode := &data.TypeNode{
+			Name:      fieldType.Name(),
+			StructTag: structType.Tag(i),
+			Embedded:  fieldType.Embedded(),
+			UpNode:    node,
+		}
+		node.Fields = append(node.Fields, equalNode)
+		ParseGoType(equalNode, fieldType.Type(), pkg, typesProcessed)
+		fieldTag.Apply(equalNode)
+		if tagErr != nil {
+			equalNode.TagError = tagErr.Error()
+		}
+	}
+}
+
+// DefaultGoTypeParsing sets the common metadata for a TypeNode from a types.Type.
+// It is the go/types counterpart of DefaultParsing. Generic type declarations
+// additionally set the type parameters of the generated functions, which are
+// inherited by the nodes whose types are built from them.
+func DefaultGoTypeParsing(node *data.TypeNode, typ types.Type, pkg string) {
+	node.Type = goTypeName(typ)
+	node.PkgPath = goTypePkgPath(typ)
+	node.PackagedType = goTypeString(typ)
+	if named := goTypeInstance(typ); named != nil {
+		node.Imports = map[string]struct{}{}
+		node.Type = goTypeInstanceName(named, pkg, node.Imports)
+		node.PackagedType = named.Obj().Pkg().Name() + "." + node.Type
+	}
+	if named := goTypeGenericDeclaration(typ); named != nil {
+		// The package name must not be followed by the type parameters list
+		node.PackagedType = named.Obj().Pkg().Name() + "." + node.Type
+		qualifier := utils.GoTypeRelativeQualifier(pkg, nil)
+		typeParams := named.Origin().TypeParams()
+		params := make([]string, typeParams.Len())
+		args := make([]string, typeParams.Len())
+		for i := range params {
+			args[i] = typeParams.At(i).Obj().Name()
+			params[i] = args[i] + " " + types.TypeString(typeParams.At(i).Constraint(), qualifier)
+		}
+		node.TypeParams = "[" + strings.Join(params, ", ") + "]"
+		node.TypeArgs = "[" + strings.Join(args, ", ") + "]"
+	} else if goTypeHasTypeParam(typ) {
+		for upNode := node.UpNode; upNode != nil; upNode = upNode.UpNode {
+			if upNode.TypeParams != "" {
+				node.TypeParams, node.TypeArgs = upNode.TypeParams, upNode.TypeArgs
+				break
+			}
+		}
+	}
+	node.IsComparable = types.Comparable(typ)
+	node.HasEqual = utils.HasEqualForGoType(typ)
+	node.HasDiff = utils.HasDiffForGoType(typ)
+	node.HasTypedDiff = utils.HasTypedDiffForGoType(typ)
+	node.HasMerge = utils.HasMergeForGoType(typ)
+	node.HasClone = utils.HasCloneForGoType(typ)
+	node.HasMergePatch = utils.HasMergePatchForGoType(typ)
+	node.HasTypedApplyDiff = utils.HasTypedApplyDiffForGoType(typ)
+	node.HasMerge3 = utils.HasMerge3ForGoType(typ)
+	node.HasHash = utils.HasHashForGoType(typ)
+	node.HasCompare = utils.HasCompareForGoType(typ)
+	node.HasJSONMarshaler = utils.HasJSONMarshalerForGoType(typ)
+	// Extract package name from the full type string
+	pkgAndType := strings.SplitN(node.PackagedType, ".", 2)
+	// If there is a package alias, apply it to the packaged type
+	if PkgAlias := utils.AliasPkg(pkgAndType[0]); PkgAlias != "" {
+		node.PkgAlias = PkgAlias
+	}
+	if node.PkgAlias != "" {
+		typName := pkgAndType[1]
+		node.PackagedType = fmt.Sprintf("%s.%s", node.PkgAlias, typName)
+	}
+}
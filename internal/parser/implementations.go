@@ -0,0 +1,111 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package parser
+
+import (
+	"go/types"
+	"reflect"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// Values of interfaces are compared by dispatching them to the Equal and Diff
+// methods of their concrete types. The implementations of an interface are
+// discovered among the types functions are generated for: a type T is
+// registered if it implements the interface, *T if only its pointer does.
+// Empty interfaces are implemented by every type, their implementations must
+// be registered with overrides. Values of types which are not registered,
+// including the values of interfaces without implementations, are compared
+// with reflect.DeepEqual.
+
+// DiscoverImplementations registers the implementations found among candidates
+// for the interfaces held by the trees rooted at roots, parsed with reflect.
+func DiscoverImplementations(roots []*data.TypeNode, candidates []reflect.Type) {
+	implementationsOf := func(node *data.TypeNode) []data.Implementation {
+		iface := node.InterfaceType
+		if iface == nil || iface.NumMethod() == 0 {
+			return nil
+		}
+		var implementations []data.Implementation
+		for _, typ := range candidates {
+			if typ.Name() == "" || typ.Kind() == reflect.Interface || strings.Contains(typ.Name(), "[") {
+				continue
+			}
+			impl := data.Implementation{PkgPath: typ.PkgPath(), Type: typ.Name()}
+			switch {
+			case typ.Implements(iface):
+			case reflect.PointerTo(typ).Implements(iface):
+				impl.Pointer = true
+			default:
+				continue
+			}
+			implementations = append(implementations, impl)
+		}
+		return implementations
+	}
+	for _, root := range roots {
+		discoverImplementations(root, implementationsOf)
+	}
+}
+
+// DiscoverGoTypeImplementations registers the implementations found among
+// candidates for the interfaces held by the trees rooted at roots, parsed with
+// go/types.
+func DiscoverGoTypeImplementations(roots []*data.TypeNode, candidates []types.Type) {
+	implementationsOf := func(node *data.TypeNode) []data.Implementation {
+		if node.InterfaceGoType == nil {
+			return nil
+		}
+		iface, ok := node.InterfaceGoType.Underlying().(*types.Interface)
+		if !ok || iface.Empty() {
+			return nil
+		}
+		var implementations []data.Implementation
+		for _, typ := range candidates {
+			named, ok := types.Unalias(typ).(*types.Named)
+			if !ok || named.Obj().Pkg() == nil || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+				continue
+			}
+			impl := data.Implementation{PkgPath: named.Obj().Pkg().Path(), Type: named.Obj().Name()}
+			switch {
+			case types.Implements(named, iface):
+			case types.Implements(types.NewPointer(named), iface):
+				impl.Pointer = true
+			default:
+				continue
+			}
+			implementations = append(implementations, impl)
+		}
+		return implementations
+	}
+	for _, root := range roots {
+		discoverImplementations(root, implementationsOf)
+	}
+}
+
+// discoverImplementations registers the implementations of the interface
+// nodes below node. Interfaces at the root of a tree are not compared: there
+// is no type to declare methods on.
+func discoverImplementations(node *data.TypeNode, implementationsOf func(*data.TypeNode) []data.Implementation) {
+	if node.SubNode != nil {
+		discoverImplementations(node.SubNode, implementationsOf)
+	}
+	for _, field := range node.Fields {
+		discoverImplementations(field, implementationsOf)
+	}
+	if node.Kind == data.Interface && node.UpNode != nil {
+		node.SetImplementations(implementationsOf(node))
+	}
+}
@@ -35,14 +35,19 @@ var typesToSkip = map[string]struct{}{
 // It prevents infinite recursion when parsing self-referential or cyclic types.
 //
 // typ: the reflect.Type to check
+// pkg: the package of the referer
 // fqnTypesProcessed: a map storing fully-qualified names (package + type) of already parsed types
 // returns true if the type was already visited, false otherwise
-func TypeAlreadyVisited(typ reflect.Type, fqnTypesProcessed map[string]struct{}) bool {
+func TypeAlreadyVisited(typ reflect.Type, pkg string, fqnTypesProcessed map[string]struct{}) bool {
 	if typ.Name() == "" {
 		return false
 	}
 	// Build the fully-qualified name
 	fqnType := typ.PkgPath() + "." + typ.Name()
+	// Functions of generic type instances are generated in the package of their referer
+	if strings.Contains(typ.Name(), "[") {
+		fqnType += "@" + pkg
+	}
 
 	// If found, it has already been visited
 	if _, found := fqnTypesProcessed[fqnType]; found {
@@ -78,6 +83,8 @@ func Parse(node *data.TypeNode, typ reflect.Type, pkg string, fqnTypesProcessed
 		ParseInterface(node, typ, pkg, fqnTypesProcessed)
 	case reflect.Func:
 		ParseFunc(node, typ, pkg)
+	case reflect.Chan, reflect.UnsafePointer:
+		ParseUnsupported(node, typ, pkg)
 	}
 	if kind == reflect.String || (kind > reflect.Invalid && kind <= reflect.Complex128) {
 		ParseBuiltin(node, pkg, typ)
@@ -87,8 +94,9 @@ func Parse(node *data.TypeNode, typ reflect.Type, pkg string, fqnTypesProcessed
 // ParseBuiltin handles built-in Go types (string, int, bool, etc.).
 // It sets the node kind to Builtin and determines if the type belongs to the same package.
 func ParseBuiltin(node *data.TypeNode, pkg string, typ reflect.Type) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Builtin
+	node.BuiltinKind = typ.Kind().String()
 	node.SamePkgAsReferer = true
 	if node.PkgPath != "" {
 		node.SamePkgAsReferer = pkg == node.PkgPath
@@ -96,29 +104,43 @@ func ParseBuiltin(node *data.TypeNode, pkg string, typ reflect.Type) {
 }
 
 // ParseInterface handles interface types.
-// It marks the node as an interface, sets SamePkgAsReferer, and flags Err=true (unsupported for equality).
+// It marks the node as an interface, sets SamePkgAsReferer, and flags Err=true
+// (unsupported for equality) until its implementations, if any, are
+// registered, see DiscoverImplementations.
 func ParseInterface(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed map[string]struct{}) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Interface
 	node.SamePkgAsReferer = pkg == node.PkgPath
+	node.InterfaceType = typ
+	node.RefererPkgPath = pkg
 	node.Err = true
 }
 
 // ParseInterface handles interface types.
 // It marks the node as an interface, sets SamePkgAsReferer, and flags Err=true (unsupported for equality).
 func ParseStructure(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed map[string]struct{}) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Struct
 	node.SamePkgAsReferer = pkg == node.PkgPath
-	pkg = node.PkgPath
+	referer := pkg
+	// Functions of generic type instances are generated in the package of
+	// their referer, other fields are generated in the package of the struct
+	if !node.IsInstantiated() {
+		pkg = node.PkgPath
+	}
 	// Initialize imports set
-	node.Imports = map[string]struct{}{}
+	if node.Imports == nil {
+		node.Imports = map[string]struct{}{}
+	}
 	if !node.SamePkgAsReferer {
 		node.Imports[node.PkgPath] = struct{}{}
 	}
 
+	node.KeyFields = keyFields(typ)
+	node.Opaque = node.Type != "" && !node.SamePkgAsReferer && !hasExportedField(typ)
+
 	// Avoid re-parsing types we've already seen
-	if TypeAlreadyVisited(typ, typesProcessed) {
+	if TypeAlreadyVisited(typ, referer, typesProcessed) {
 		return
 	}
 	// Only parse fields if the struct has no custom Equal method
@@ -132,18 +154,55 @@ func ParseStructure(node *data.TypeNode, typ reflect.Type, pkg string, typesProc
 	}
 }
 
+// hasExportedField reports whether a struct has exported fields, which
+// functions of other packages may access.
+func hasExportedField(typ reflect.Type) bool {
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			return true
+		}
+	}
+	return false
+}
+
+// keyFields returns the fields of a struct, promoted ones included, by which
+// slice elements may be matched.
+func keyFields(typ reflect.Type) map[string]data.KeyField {
+	fields := map[string]data.KeyField{}
+	for _, field := range reflect.VisibleFields(typ) {
+		fieldType := field.Type
+		var keyField data.KeyField
+		if fieldType.Kind() == reflect.Ptr {
+			keyField.Pointer = true
+			fieldType = fieldType.Elem()
+		}
+		keyField.Comparable = fieldType.Comparable()
+		keyField.Tag = string(field.Tag)
+		fields[field.Name] = keyField
+	}
+	return fields
+}
+
 // ParseFunc handles function types.
 // It marks them as unsupported (Err=true).
 func ParseFunc(node *data.TypeNode, typ reflect.Type, pkg string) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Func
 	node.Err = true
 }
 
+// ParseUnsupported handles types whose values cannot be compared, such as
+// channels. It marks them as unsupported (Err=true).
+func ParseUnsupported(node *data.TypeNode, typ reflect.Type, pkg string) {
+	DefaultParsing(node, typ, pkg)
+	node.Kind = data.Unknown
+	node.Err = true
+}
+
 // ParseMap handles map types.
 // It parses both the key type and value type recursively and merges their imports.
 func ParseMap(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed map[string]struct{}) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Map
 	node.MapKeyType = typ.Key().Name()
 	// Parse the map value type
@@ -174,7 +233,7 @@ func ParseMap(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed
 // ParseArray handles fixed-length array types.
 // It parses the element type recursively.
 func ParseArray(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed map[string]struct{}) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Array
 	node.Len = typ.Len()
 	arrayType := typ.Elem()
@@ -195,7 +254,7 @@ func ParseArray(node *data.TypeNode, typ reflect.Type, pkg string, typesProcesse
 // ParseSlice handles slice types.
 // It parses the element type recursively and tracks whether it's in the same package.
 func ParseSlice(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed map[string]struct{}) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Slice
 	sliceType := typ.Elem()
 	sliceNode := &data.TypeNode{
@@ -203,7 +262,7 @@ func ParseSlice(node *data.TypeNode, typ reflect.Type, pkg string, typesProcesse
 		SamePkgAsReferer: node.Type != "",
 	}
 	node.SubNode = sliceNode
-	if node.Type != "" {
+	if node.Type != "" && !node.IsInstantiated() {
 		// Named slice type → same package as referer
 		pkg = node.PkgPath
 		node.SamePkgAsReferer = true
@@ -220,7 +279,7 @@ func ParseSlice(node *data.TypeNode, typ reflect.Type, pkg string, typesProcesse
 // ParsePointer handles pointer types.
 // It parses the pointed-to type recursively and inherits its packaged type and imports.
 func ParsePointer(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed map[string]struct{}) {
-	DefaultParsing(node, typ)
+	DefaultParsing(node, typ, pkg)
 	node.Kind = data.Pointer
 	pointerType := typ.Elem()
 	pointerNode := &data.TypeNode{
@@ -237,39 +296,65 @@ func ParsePointer(node *data.TypeNode, typ reflect.Type, pkg string, typesProces
 }
 
 // StructFieldsEqual parses the fields of a struct for equality/diff generation.
-// It skips certain predefined meta types and parses each remaining field recursively.
+// It skips certain predefined meta types and fields tagged `gmg:"-"`, and parses
+// each remaining field recursively with the options of its gmg tag.
 func StructFieldsEqual(node *data.TypeNode, typ reflect.Type, pkg string, typesProcessed map[string]struct{}) {
 	for i := 0; i < typ.NumField(); i++ {
 		fieldType := typ.Field(i)
 		// Skip predefined meta types (e.g., Kubernetes ObjectMeta)
 		_, toSkip := typesToSkip[fieldType.Type.String()]
-		if toSkip {
+		fieldTag, tagErr := ParseFieldTag(fieldType.Tag)
+		if toSkip || fieldTag.Skip {
 			continue
 		}
 		equalNode := &data.TypeNode{
-			Name:   fieldType.Name,
-			UpNode: node,
+			Name:      fieldType.Name,
+			StructTag: string(fieldType.Tag),
+			Embedded:  fieldType.Anonymous,
+			UpNode:    node,
 		}
 		node.Fields = append(node.Fields, equalNode)
 		Parse(equalNode, fieldType.Type, pkg, typesProcessed)
+		fieldTag.Apply(equalNode)
+		if tagErr != nil {
+			equalNode.TagError = tagErr.Error()
+		}
 	}
 }
 
 // DefaultParsing sets the common metadata for a TypeNode from a reflect.Type.
 // This includes type name, package path, packaged type string, comparability,
-// availability of Equal/Diff methods, and package alias handling.
-func DefaultParsing(node *data.TypeNode, typ reflect.Type) {
+// availability of Equal/Diff/Merge/Clone methods, and package alias handling.
+// Names of generic type instances are rewritten for the package pkg of the referer.
+func DefaultParsing(node *data.TypeNode, typ reflect.Type, pkg string) {
 	node.Type = typ.Name()
 	node.PkgPath = typ.PkgPath()
 	node.PackagedType = typ.String()
+	if strings.Contains(node.Type, "[") {
+		var imports []string
+		node.Type, imports = utils.InstanceTypeName(node.Type, pkg)
+		node.PackagedType = strings.SplitN(node.PackagedType, ".", 2)[0] + "." + node.Type
+		node.Imports = map[string]struct{}{}
+		for _, imp := range imports {
+			node.Imports[imp] = struct{}{}
+		}
+	}
 	node.IsComparable = typ.Comparable()
 	node.HasEqual = utils.HasEqualFor(typ)
 	node.HasDiff = utils.HasDiffFor(typ)
+	node.HasTypedDiff = utils.HasTypedDiffFor(typ)
+	node.HasMerge = utils.HasMergeFor(typ)
+	node.HasClone = utils.HasCloneFor(typ)
+	node.HasMergePatch = utils.HasMergePatchFor(typ)
+	node.HasTypedApplyDiff = utils.HasTypedApplyDiffFor(typ)
+	node.HasMerge3 = utils.HasMerge3For(typ)
+	node.HasHash = utils.HasHashFor(typ)
+	node.HasCompare = utils.HasCompareFor(typ)
+	node.HasJSONMarshaler = utils.HasJSONMarshalerFor(typ)
 	// Extract package name from the full type string
 	pkgAndType := strings.SplitN(node.PackagedType, ".", 2)
-	pkg := pkgAndType[0]
 	// If there is a package alias, apply it to the packaged type
-	if PkgAlias := utils.AliasPkg(pkg); PkgAlias != "" {
+	if PkgAlias := utils.AliasPkg(pkgAndType[0]); PkgAlias != "" {
 		node.PkgAlias = PkgAlias
 	}
 	if node.PkgAlias != "" {
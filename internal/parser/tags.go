@@ -0,0 +1,159 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package parser
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// FieldTagKey is the key of the struct tag holding the options of a field,
+// e.g. `gmg:"key=Name,name=servers"`.
+const FieldTagKey = "gmg"
+
+// FieldTag holds the options of a field set by its gmg struct tag.
+type FieldTag struct {
+	Skip     bool   // "-": the field is skipped by all generated functions
+	Set      bool   // "set": slices are compared regardless of order
+	Key      string // "key=Name": slice elements are matched by their Name field
+	LCS      bool   // "lcs": slices are diffed as insertions and deletions of elements
+	Moves    bool   // "moves": slices are diffed as insertions, deletions and moves
+	NilEmpty bool   // "nilempty": nil pointers equal pointers to zero values
+	DiffName string // "name=...": name of the field in diff keys
+}
+
+// ParseFieldTag returns the options of the gmg key of a struct tag, or an
+// error if an option is unknown, lacks its value or has one it does not take.
+func ParseFieldTag(tag reflect.StructTag) (FieldTag, error) {
+	var fieldTag FieldTag
+	value, found := tag.Lookup(FieldTagKey)
+	if !found {
+		return fieldTag, nil
+	}
+	if value == "-" {
+		fieldTag.Skip = true
+		return fieldTag, nil
+	}
+	for _, option := range strings.Split(value, ",") {
+		name, arg, hasArg := strings.Cut(strings.TrimSpace(option), "=")
+		switch name {
+		case "key", "name":
+			if arg == "" {
+				return fieldTag, fmt.Errorf("%s option %s requires a value, e.g. %s=Name", FieldTagKey, name, name)
+			}
+		case "set", "lcs", "moves", "nilempty":
+			if hasArg {
+				return fieldTag, fmt.Errorf("%s option %s takes no value", FieldTagKey, name)
+			}
+		default:
+			return fieldTag, fmt.Errorf("unknown %s option %q, expected set, key=Field, lcs, moves, nilempty or name=name", FieldTagKey, name)
+		}
+		switch name {
+		case "set":
+			fieldTag.Set = true
+		case "key":
+			fieldTag.Key = arg
+		case "lcs":
+			fieldTag.LCS = true
+		case "moves":
+			fieldTag.LCS, fieldTag.Moves = true, true
+		case "nilempty":
+			fieldTag.NilEmpty = true
+		case "name":
+			fieldTag.DiffName = arg
+		}
+	}
+	return fieldTag, nil
+}
+
+// FieldDiffName returns the name in diff keys of the field name with the
+// struct tag tag, as ApplyDiffNameTag names fields: the name option of its
+// gmg tag, else the name of its key tag, e.g. "json", else its Go name.
+func FieldDiffName(name string, tag reflect.StructTag, key string) string {
+	if fieldTag, err := ParseFieldTag(tag); err == nil && fieldTag.DiffName != "" {
+		return fieldTag.DiffName
+	}
+	if key != "" {
+		value, _ := tag.Lookup(key)
+		if tagName, _, _ := strings.Cut(value, ","); tagName != "" && tagName != "-" {
+			return tagName
+		}
+	}
+	return name
+}
+
+// ApplyDiffNameTag names the fields of the tree rooted at node in diff keys
+// after their key struct tag, e.g. "json", as encoding/json does: the name is
+// the part of the tag before the first comma. Fields named by the name option
+// of their gmg tag, and fields without a name in their key tag, keep their
+// name. As in encoding/json documents, fields named "-" are left out of
+// diffs, the members of embedded structs without a name are members of their
+// struct, and slices of bytes are diffed as whole values.
+func ApplyDiffNameTag(node *data.TypeNode, key string) {
+	if node == nil || key == "" {
+		return
+	}
+	if node.Kind == data.Slice && node.SubNode != nil && node.SubNode.Kind == data.Builtin &&
+		(node.SubNode.BuiltinKind == "uint8" || node.SubNode.BuiltinKind == "byte") {
+		node.DiffScalar = true
+	}
+	for _, field := range node.Fields {
+		if field.DiffName == "" {
+			value, _ := reflect.StructTag(field.StructTag).Lookup(key)
+			name, _, _ := strings.Cut(value, ",")
+			switch {
+			case value == "-":
+				field.DiffSkipped = true
+			case name != "":
+				field.DiffName = name
+			case field.Embedded && isStruct(field):
+				field.DiffFlattened = true
+			}
+		}
+		ApplyDiffNameTag(field, key)
+	}
+	ApplyDiffNameTag(node.SubNode, key)
+}
+
+// isStruct reports whether the values of a node are structs or pointers to
+// structs.
+func isStruct(node *data.TypeNode) bool {
+	if node.Kind == data.Pointer && node.SubNode != nil {
+		node = node.SubNode
+	}
+	return node.Kind == data.Struct
+}
+
+// Apply sets the options on a parsed field node. Options changing how values
+// are compared also apply to the values pointed by the field.
+func (t FieldTag) Apply(node *data.TypeNode) {
+	node.DiffName = t.DiffName
+	if !t.Set && t.Key == "" && !t.LCS && !t.NilEmpty {
+		return
+	}
+	for ; node != nil; node = node.SubNode {
+		node.SliceSet = t.Set
+		node.SliceKey = t.Key
+		node.SliceLCS = t.LCS
+		node.SliceMoves = t.Moves
+		node.NilEmpty = t.NilEmpty
+		node.FieldOptions = true
+		if node.Kind != data.Pointer {
+			return
+		}
+	}
+}
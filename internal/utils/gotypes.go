@@ -0,0 +1,237 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package utils
+
+import (
+	"go/types"
+)
+
+// lookupGoTypeMethod returns the signature of the method name in the method set
+// of typ, the methods callable on a value of the type. Only named types and
+// type parameters are considered, as for the reflect based checks.
+func lookupGoTypeMethod(typ types.Type, name string) *types.Signature {
+	switch t := types.Unalias(typ).(type) {
+	case *types.Named:
+	case *types.TypeParam:
+		// Methods of type parameters are the methods of their constraint
+		typ = t.Underlying()
+	default:
+		return nil
+	}
+	sel := types.NewMethodSet(typ).Lookup(nil, name)
+	if sel == nil {
+		return nil
+	}
+	sig, _ := sel.Type().(*types.Signature)
+	return sig
+}
+
+// lookupGoTypePointerMethod returns the signature of the method called name
+// of pointers to the named type typ, which includes the methods declared
+// with pointer receivers, or nil if there is none.
+func lookupGoTypePointerMethod(typ types.Type, name string) *types.Signature {
+	if _, ok := types.Unalias(typ).(*types.Named); !ok {
+		return nil
+	}
+	sel := types.NewMethodSet(types.NewPointer(typ)).Lookup(nil, name)
+	if sel == nil {
+		return nil
+	}
+	sig, _ := sel.Type().(*types.Signature)
+	return sig
+}
+
+// HasEqualForGoType checks whether a given type defines an Equal method
+// with the exact signature: func (T) Equal(T) bool.
+func HasEqualForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Equal")
+	if sig == nil || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	basic, ok := sig.Results().At(0).Type().Underlying().(*types.Basic)
+	return ok && basic.Kind() == types.Bool
+}
+
+// HasDiffForGoType checks whether a given type defines a Diff method
+// with the exact signature: func (T) Diff(T) map[string][]interface{}.
+func HasDiffForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Diff")
+	if sig == nil || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	// Check that return type is map[string][]interface{}
+	return isMapDiffGoType(sig.Results().At(0).Type())
+}
+
+// HasTypedDiffForGoType checks whether a given type defines a Diff method
+// with the exact signature: func (T) Diff(T) []eqdiff.Change.
+func HasTypedDiffForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Diff")
+	if sig == nil || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	// Check that return type is []eqdiff.Change
+	return isTypedDiffGoType(sig.Results().At(0).Type())
+}
+
+// isMapDiffGoType reports whether typ is map[string][]interface{}.
+func isMapDiffGoType(typ types.Type) bool {
+	mapType, ok := typ.Underlying().(*types.Map)
+	if !ok {
+		return false
+	}
+	keyType, ok := mapType.Key().Underlying().(*types.Basic)
+	if !ok || keyType.Kind() != types.String {
+		return false
+	}
+	valueType, ok := mapType.Elem().Underlying().(*types.Slice)
+	if !ok {
+		return false
+	}
+	_, ok = valueType.Elem().Underlying().(*types.Interface)
+	return ok
+}
+
+// isTypedDiffGoType reports whether typ is []eqdiff.Change.
+func isTypedDiffGoType(typ types.Type) bool {
+	sliceType, ok := typ.(*types.Slice)
+	if !ok {
+		return false
+	}
+	elem, ok := types.Unalias(sliceType.Elem()).(*types.Named)
+	return ok && elem.Obj().Name() == "Change" &&
+		elem.Obj().Pkg() != nil && elem.Obj().Pkg().Path() == EqdiffPkgPath
+}
+
+// HasMergeForGoType checks whether a given type defines a Merge method
+// with the exact signature: func (T) Merge(T) T.
+func HasMergeForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Merge")
+	return sig != nil && sig.Params().Len() == 1 && // method has exactly one argument
+		types.Identical(sig.Params().At(0).Type(), typ) && // argument is the same type
+		sig.Results().Len() == 1 && // exactly one return value
+		types.Identical(sig.Results().At(0).Type(), typ) // returns the merged value
+}
+
+// HasCloneForGoType checks whether a given type defines a Clone method
+// with the exact signature: func (T) Clone() T.
+func HasCloneForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Clone")
+	return sig != nil && sig.Params().Len() == 0 && // method has no argument
+		sig.Results().Len() == 1 && // exactly one return value
+		types.Identical(sig.Results().At(0).Type(), typ) // returns the cloned value
+}
+
+// HasMergePatchForGoType checks whether a given type defines a MergePatch
+// method with the exact signature: func (T) MergePatch(T) ([]byte, error).
+func HasMergePatchForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "MergePatch")
+	return sig != nil && sig.Params().Len() == 1 && // method has exactly one argument
+		types.Identical(sig.Params().At(0).Type(), typ) && // argument is the same type
+		sig.Results().Len() == 2 && // exactly two return values
+		types.Identical(sig.Results().At(0).Type(), types.NewSlice(types.Typ[types.Byte])) && // returns the patch
+		types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type()) // and an error
+}
+
+// HasTypedApplyDiffForGoType checks whether pointers to a given type define
+// an ApplyDiff method with the exact signature:
+// func (*T) ApplyDiff([]eqdiff.Change) error.
+func HasTypedApplyDiffForGoType(typ types.Type) bool {
+	sig := lookupGoTypePointerMethod(typ, "ApplyDiff")
+	return sig != nil && sig.Params().Len() == 1 && // method has exactly one argument
+		isTypedDiffGoType(sig.Params().At(0).Type()) && // argument is a typed diff
+		sig.Results().Len() == 1 && // exactly one return value
+		types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type()) // returns an error
+}
+
+// HasMerge3ForGoType checks whether a given type defines a Merge3 method
+// with the exact signature: func (T) Merge3(T, T) (T, []eqdiff.Conflict).
+func HasMerge3ForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Merge3")
+	if sig == nil || sig.Params().Len() != 2 || sig.Results().Len() != 2 {
+		return false
+	}
+	conflicts, ok := sig.Results().At(1).Type().(*types.Slice)
+	if !ok {
+		return false
+	}
+	elem, ok := types.Unalias(conflicts.Elem()).(*types.Named)
+	return types.Identical(sig.Params().At(0).Type(), typ) && // arguments are the same type
+		types.Identical(sig.Params().At(1).Type(), typ) &&
+		types.Identical(sig.Results().At(0).Type(), typ) && // returns the merged value
+		ok && elem.Obj().Name() == "Conflict" && // and the conflicts
+		elem.Obj().Pkg() != nil && elem.Obj().Pkg().Path() == EqdiffPkgPath
+}
+
+// HasHashForGoType checks whether a given type defines a Hash method with
+// the exact signature: func (T) Hash(hash.Hash64).
+func HasHashForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Hash")
+	if sig == nil || sig.Params().Len() != 1 || sig.Results().Len() != 0 {
+		return false
+	}
+	param, ok := types.Unalias(sig.Params().At(0).Type()).(*types.Named)
+	return ok && param.Obj().Name() == "Hash64" && // argument is the hash
+		param.Obj().Pkg() != nil && param.Obj().Pkg().Path() == "hash"
+}
+
+// HasCompareForGoType checks whether a given type defines a Compare method
+// with the exact signature: func (T) Compare(T) int.
+func HasCompareForGoType(typ types.Type) bool {
+	sig := lookupGoTypeMethod(typ, "Compare")
+	return sig != nil && sig.Params().Len() == 1 && // method has exactly one argument
+		types.Identical(sig.Params().At(0).Type(), typ) && // argument is the same type
+		sig.Results().Len() == 1 && // exactly one return value
+		types.Identical(sig.Results().At(0).Type(), types.Typ[types.Int]) // returns the order
+}
+
+// HasJSONMarshalerForGoType checks whether a given named type, or a pointer
+// to it, implements json.Marshaler or encoding.TextMarshaler, with a
+// MarshalJSON or MarshalText method with the exact signature:
+// func (T) MarshalJSON() ([]byte, error). Interfaces are left out.
+func HasJSONMarshalerForGoType(typ types.Type) bool {
+	if types.IsInterface(typ) {
+		return false
+	}
+	for _, name := range []string{"MarshalJSON", "MarshalText"} {
+		sig := lookupGoTypePointerMethod(typ, name)
+		if sig != nil && sig.Params().Len() == 0 && sig.Results().Len() == 2 && // method has no argument
+			types.Identical(sig.Results().At(0).Type(), types.NewSlice(types.Typ[types.Byte])) && // returns the encoding
+			types.Identical(sig.Results().At(1).Type(), types.Universe.Lookup("error").Type()) { // and an error
+			return true
+		}
+	}
+	return false
+}
+
+// GoTypeQualifier qualifies package members by their package name, the way
+// reflect.Type.String does.
+func GoTypeQualifier(pkg *types.Package) string {
+	return pkg.Name()
+}
+
+// GoTypeRelativeQualifier qualifies package members by their package name,
+// except for the members of the package pkg. The import paths of the other
+// packages are added to imports when it is not nil.
+func GoTypeRelativeQualifier(pkg string, imports map[string]struct{}) types.Qualifier {
+	return func(p *types.Package) string {
+		if p.Path() == pkg {
+			return ""
+		}
+		if imports != nil {
+			imports[p.Path()] = struct{}{}
+		}
+		return p.Name()
+	}
+}
@@ -14,7 +14,9 @@
 package utils
 
 import (
+	"encoding"
 	"encoding/json"
+	"hash"
 	"log"
 	"path"
 	"reflect"
@@ -24,6 +26,10 @@ import (
 	"unicode"
 )
 
+// EqdiffPkgPath is the import path of the runtime package holding the types
+// used by generated code, such as eqdiff.Change.
+const EqdiffPkgPath = "github.com/haproxytech/go-method-gen/pkg/eqdiff"
+
 // Fqn generates a "Fully Qualified Name"-like string from a Go type name,
 // transforming it into a form suitable for use in generated function names.
 // It handles slices, arrays, and pointers by replacing symbols with
@@ -55,6 +61,42 @@ func Fqn(packagedName string) string {
 	return strings.Join(words, "")
 }
 
+// qualifiedIdentRegexp matches the identifiers of type names, qualified with
+// their import path or not (e.g., "int", "example.com/models.Backend").
+var qualifiedIdentRegexp = regexp.MustCompile(`[\w./~-]+`)
+
+// InstanceTypeName rewrites the name of an instance of a generic type, as
+// returned by reflect.Type.Name (e.g., "Pair[string,*example.com/models.Backend]"),
+// into a valid Go type for the package pkg: type arguments are qualified with
+// their package name, unless they belong to pkg (e.g., "Pair[string, *Backend]"
+// in example.com/models). The import paths of the type arguments are returned
+// along with the name.
+func InstanceTypeName(name, pkg string) (string, []string) {
+	i := strings.Index(name, "[")
+	if i == -1 {
+		return name, nil
+	}
+	var imports []string
+	args := qualifiedIdentRegexp.ReplaceAllStringFunc(name[i:], func(ident string) string {
+		lastDot := strings.LastIndex(ident, ".")
+		if lastDot == -1 {
+			return ident
+		}
+		importPath := ident[:lastDot]
+		if importPath == pkg {
+			return ident[lastDot+1:]
+		}
+		imports = append(imports, importPath)
+		pkgName := ExtractPkg(importPath)
+		if alias := AliasPkg(pkgName); alias != "" {
+			pkgName = alias
+		}
+		return pkgName + "." + ident[lastDot+1:]
+	})
+	args = strings.ReplaceAll(args, ",", ", ")
+	return name[:i] + args, imports
+}
+
 // EqualFuncName returns the generated Equal function name for a given type name.
 func EqualFuncName(input string) string {
 	return "Equal" + Fqn(input)
@@ -65,6 +107,41 @@ func DiffFuncName(input string) string {
 	return "Diff" + Fqn(input)
 }
 
+// MergeFuncName returns the generated Merge function name for a given type name.
+func MergeFuncName(input string) string {
+	return "Merge" + Fqn(input)
+}
+
+// CloneFuncName returns the generated Clone function name for a given type name.
+func CloneFuncName(input string) string {
+	return "Clone" + Fqn(input)
+}
+
+// MergePatchFuncName returns the generated MergePatch function name for a given type name.
+func MergePatchFuncName(input string) string {
+	return "MergePatch" + Fqn(input)
+}
+
+// ApplyDiffFuncName returns the generated ApplyDiff function name for a given type name.
+func ApplyDiffFuncName(input string) string {
+	return "ApplyDiff" + Fqn(input)
+}
+
+// Merge3FuncName returns the generated Merge3 function name for a given type name.
+func Merge3FuncName(input string) string {
+	return "Merge3" + Fqn(input)
+}
+
+// HashFuncName returns the generated Hash function name for a given type name.
+func HashFuncName(input string) string {
+	return "Hash" + Fqn(input)
+}
+
+// CompareFuncName returns the generated Compare function name for a given type name.
+func CompareFuncName(input string) string {
+	return "Compare" + Fqn(input)
+}
+
 // capitalize returns the input string with its first character in uppercase.
 func capitalize(s string) string {
 	if s == "" {
@@ -125,6 +202,152 @@ func HasDiffFor(typ reflect.Type) bool {
 		correctReturnType
 }
 
+// HasTypedDiffFor checks whether a given type defines a Diff method
+// with the exact signature: func (T) Diff(T) []eqdiff.Change.
+func HasTypedDiffFor(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := typ.MethodByName("Diff")
+	if !found || method.Type.NumIn() != 2 || method.Type.NumOut() != 1 ||
+		!method.Type.In(0).AssignableTo(typ) {
+		return false
+	}
+	// Check that return type is []eqdiff.Change
+	outType := method.Type.Out(0)
+	return outType.Kind() == reflect.Slice &&
+		outType.Elem().Name() == "Change" &&
+		outType.Elem().PkgPath() == EqdiffPkgPath
+}
+
+// HasMergeFor checks whether a given type defines a Merge method
+// with the exact signature: func (T) Merge(T) T.
+func HasMergeFor(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := typ.MethodByName("Merge")
+	return found && method.Type.NumIn() == 2 && // method has exactly one argument (plus the receiver)
+		method.Type.In(0).AssignableTo(typ) && // receiver matches the given type
+		method.Type.In(1) == typ && // argument is the same type
+		method.Type.NumOut() == 1 && // exactly one return value
+		method.Type.Out(0) == typ // returns the merged value
+}
+
+// HasCloneFor checks whether a given type defines a Clone method
+// with the exact signature: func (T) Clone() T.
+func HasCloneFor(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := typ.MethodByName("Clone")
+	return found && method.Type.NumIn() == 1 && // method has no argument (only the receiver)
+		method.Type.In(0).AssignableTo(typ) && // receiver matches the given type
+		method.Type.NumOut() == 1 && // exactly one return value
+		method.Type.Out(0) == typ // returns the cloned value
+}
+
+// HasMergePatchFor checks whether a given type defines a MergePatch method
+// with the exact signature: func (T) MergePatch(T) ([]byte, error).
+func HasMergePatchFor(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := typ.MethodByName("MergePatch")
+	return found && method.Type.NumIn() == 2 && // method has exactly one argument (plus the receiver)
+		method.Type.In(0).AssignableTo(typ) && // receiver matches the given type
+		method.Type.In(1) == typ && // argument is the same type
+		method.Type.NumOut() == 2 && // exactly two return values
+		method.Type.Out(0) == reflect.TypeOf([]byte(nil)) && // returns the patch
+		method.Type.Out(1) == reflect.TypeOf((*error)(nil)).Elem() // and an error
+}
+
+// HasTypedApplyDiffFor checks whether pointers to a given type define an
+// ApplyDiff method with the exact signature:
+// func (*T) ApplyDiff([]eqdiff.Change) error.
+func HasTypedApplyDiffFor(typ reflect.Type) bool {
+	return hasApplyDiffFor(typ, func(inType reflect.Type) bool {
+		return inType.Kind() == reflect.Slice &&
+			inType.Elem().Name() == "Change" &&
+			inType.Elem().PkgPath() == EqdiffPkgPath
+	})
+}
+
+// hasApplyDiffFor checks whether pointers to a given type define an
+// ApplyDiff method taking a diff, as reported by isDiff, and returning an
+// error. ApplyDiff methods change their receiver, which is a pointer.
+func hasApplyDiffFor(typ reflect.Type, isDiff func(reflect.Type) bool) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := reflect.PointerTo(typ).MethodByName("ApplyDiff")
+	return found && method.Type.NumIn() == 2 && // method has exactly one argument (plus the receiver)
+		isDiff(method.Type.In(1)) && // argument is the diff
+		method.Type.NumOut() == 1 && // exactly one return value
+		method.Type.Out(0) == reflect.TypeOf((*error)(nil)).Elem() // returns an error
+}
+
+// HasMerge3For checks whether a given type defines a Merge3 method with the
+// exact signature: func (T) Merge3(T, T) (T, []eqdiff.Conflict).
+func HasMerge3For(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := typ.MethodByName("Merge3")
+	if !found || method.Type.NumIn() != 3 || method.Type.NumOut() != 2 {
+		return false
+	}
+	conflicts := method.Type.Out(1)
+	return method.Type.In(0).AssignableTo(typ) && // receiver matches the given type
+		method.Type.In(1) == typ && method.Type.In(2) == typ && // arguments are the same type
+		method.Type.Out(0) == typ && // returns the merged value
+		conflicts.Kind() == reflect.Slice && // and the conflicts
+		conflicts.Elem().Name() == "Conflict" &&
+		conflicts.Elem().PkgPath() == EqdiffPkgPath
+}
+
+// HasHashFor checks whether a given type defines a Hash method with the
+// exact signature: func (T) Hash(hash.Hash64).
+func HasHashFor(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := typ.MethodByName("Hash")
+	return found && method.Type.NumIn() == 2 && // method has exactly one argument (plus the receiver)
+		method.Type.In(0).AssignableTo(typ) && // receiver matches the given type
+		method.Type.In(1) == reflect.TypeOf((*hash.Hash64)(nil)).Elem() && // argument is the hash
+		method.Type.NumOut() == 0 // no return value
+}
+
+// HasCompareFor checks whether a given type defines a Compare method with the
+// exact signature: func (T) Compare(T) int.
+func HasCompareFor(typ reflect.Type) bool {
+	if typ.PkgPath() == "" {
+		return false
+	}
+	method, found := typ.MethodByName("Compare")
+	return found && method.Type.NumIn() == 2 && // method has exactly one argument (plus the receiver)
+		method.Type.In(0).AssignableTo(typ) && // receiver matches the given type
+		method.Type.In(1) == typ && // argument is the same type
+		method.Type.NumOut() == 1 && // exactly one return value
+		method.Type.Out(0) == reflect.TypeOf(0) // returns the order
+}
+
+// HasJSONMarshalerFor checks whether a given type, or a pointer to it,
+// implements json.Marshaler or encoding.TextMarshaler: encoding/json encodes
+// its values with their own method. Interfaces are left out, their values are
+// encoded as their dynamic types are.
+func HasJSONMarshalerFor(typ reflect.Type) bool {
+	if typ.PkgPath() == "" || typ.Kind() == reflect.Interface {
+		return false
+	}
+	marshaler := reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshaler := reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	ptr := reflect.PointerTo(typ)
+	return typ.Implements(marshaler) || typ.Implements(textMarshaler) ||
+		ptr.Implements(marshaler) || ptr.Implements(textMarshaler)
+}
+
 // ExtractPkg returns the last element of a full Go import path,
 // which corresponds to the package name (e.g., "github.com/foo/bar" -> "bar").
 func ExtractPkg(fullpkg string) string {
@@ -0,0 +1,123 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package writer
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// cloneTemplateRawTxt defines the Go function template for generating a Clone method
+// when the type is a struct. The implementation declares a shallow copy of the receiver
+// and clones the fields sharing memory with it.
+const cloneTemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Clone() {{.Type}} {
+	{{.CloneImplementation}}
+	return {{.RightSideComparison}}
+}
+`
+
+// cloneTemplateDefinedTxt defines the Go function template for generating a Clone method
+// for defined types (type aliases). The implementation is expected to return the cloned
+// value directly.
+const cloneTemplateDefinedTxt = `func ({{.LeftSideComparison}} {{.Type}}) Clone() {{.Type}} {
+	return {{.CloneImplementation}}
+}
+`
+
+// cloneTemplateRaw is the parsed template object for struct-based Clone generation.
+var cloneTemplateRaw = template.Must(template.New("CloneTemplate").Parse(cloneTemplateRawTxt))
+
+// cloneTemplateDefined is the parsed template object for defined-type Clone generation.
+var cloneTemplateDefined = template.Must(template.New("CloneTemplateDefined").Parse(cloneTemplateDefinedTxt))
+
+// WriteCloneFiles generates Go files containing Clone methods based on the provided
+// code generation context (`ctx`). It organizes generated code by output file and package.
+//
+// Parameters:
+//   - dir: Base directory where files will be written
+//   - file: Initial target file path (may be overridden based on type and package)
+//   - files: Map of file paths to a map of code sections ("Package", "Imports", "Clone")
+//   - ctx: Code generation context containing metadata and generated implementations
+//
+// Behavior:
+//   - Skips generation if Clone function name or implementation is empty, or if there was an error.
+//   - For struct types or defined types, generates a dedicated Go file with the full Clone function.
+//   - For other cases, appends the Clone implementation to an existing entry in the `files` map.
+//   - Recursively processes any sub-contexts to handle nested or related types.
+func WriteCloneFiles(dir, file string, files map[string]map[string]string, ctx data.Ctx) error {
+	if ctx.CloneFuncName == "" {
+		return nil
+	}
+	if ctx.CloneImplementation == "" {
+		return nil
+	}
+	if ctx.Err {
+		return nil
+	}
+
+	if ctx.HasMethod() {
+		file = filepath.Join(dir, ctx.PkgPath, strings.ToLower(ctx.Type)+"_clone_generated.go")
+
+		args := map[string]string{
+			"LeftSideComparison":  ctx.LeftSideComparison,
+			"RightSideComparison": ctx.RightSideComparison,
+			"Type":                ctx.Type + ctx.TypeArgs,
+			"CloneImplementation": ctx.CloneImplementation,
+		}
+
+		contents := bytes.Buffer{}
+		cloneTemplate := cloneTemplateRaw
+		if ctx.DefinedType {
+			cloneTemplate = cloneTemplateDefined
+		}
+		err := cloneTemplate.Execute(&contents, args)
+		if err != nil {
+			return err
+		}
+
+		var importsClause string
+		if len(ctx.Imports) > 0 {
+			imports := bytes.Buffer{}
+			for imp := range ctx.Imports {
+				imports.WriteString("\"" + imp + "\"\n")
+			}
+			importsClause = "import (\n" + imports.String() + ")"
+		}
+		files[file] = map[string]string{
+			"Package": "package " + ctx.Pkg,
+			"Imports": importsClause,
+			"Clone":   contents.String(),
+		}
+		for _, subCtx := range ctx.SubCtxs {
+			WriteCloneFiles(dir, file, files, *subCtx)
+		}
+		return nil
+	}
+
+	implementations := files[file]
+	if implementations == nil {
+		implementations = map[string]string{}
+		files[file] = implementations
+	}
+	implementations[ctx.CloneFuncName] = ctx.CloneImplementation
+
+	for _, subCtx := range ctx.SubCtxs {
+		WriteCloneFiles(dir, file, files, *subCtx)
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package writer
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// compareTemplateRawTxt defines the Go function template for generating a Compare
+// method when the type is a struct. The implementation returns the order of the
+// first fields found unequal, in their declaration order.
+const compareTemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Compare({{.RightSideComparison}} {{.Type}}) int {
+	{{.CompareImplementation}}
+	return 0
+}
+`
+
+// compareTemplateRaw is the parsed template object for struct-based Compare generation.
+var compareTemplateRaw = template.Must(template.New("CompareTemplate").Parse(compareTemplateRawTxt))
+
+// WriteCompareFiles generates Go files containing Compare methods based on the
+// provided code generation context (`ctx`). It organizes generated code by output file
+// and package.
+//
+// Parameters:
+//   - dir: Base directory where files will be written
+//   - file: Initial target file path (may be overridden based on type and package)
+//   - files: Map of file paths to a map of code sections ("Package", "Imports", "Compare")
+//   - ctx: Code generation context containing metadata and generated implementations
+//
+// Behavior:
+//   - Skips generation if Compare function name or implementation is empty, or if there was an error.
+//   - For struct types, generates a dedicated Go file with the full Compare method.
+//   - For other cases, appends the Compare function to an existing entry in the `files` map.
+//   - Recursively processes any sub-contexts to handle nested or related types.
+func WriteCompareFiles(dir, file string, files map[string]map[string]string, ctx data.Ctx) error {
+	if ctx.CompareFuncName == "" {
+		return nil
+	}
+	if ctx.CompareImplementation == "" {
+		return nil
+	}
+	if ctx.Err {
+		return nil
+	}
+
+	if ctx.HasMethod() {
+		file = filepath.Join(dir, ctx.PkgPath, strings.ToLower(ctx.Type)+"_compare_generated.go")
+
+		args := map[string]string{
+			"LeftSideComparison":    ctx.LeftSideComparison,
+			"RightSideComparison":   ctx.RightSideComparison,
+			"Type":                  ctx.Type + ctx.TypeArgs,
+			"CompareImplementation": ctx.CompareImplementation,
+		}
+
+		contents := bytes.Buffer{}
+		err := compareTemplateRaw.Execute(&contents, args)
+		if err != nil {
+			return err
+		}
+
+		var importsClause string
+		if len(ctx.Imports) > 0 {
+			imports := bytes.Buffer{}
+			for imp := range ctx.Imports {
+				imports.WriteString("\"" + imp + "\"\n")
+			}
+			importsClause = "import (\n" + imports.String() + ")"
+		}
+		files[file] = map[string]string{
+			"Package": "package " + ctx.Pkg,
+			"Imports": importsClause,
+			"Compare": contents.String(),
+		}
+		for _, subCtx := range ctx.SubCtxs {
+			WriteCompareFiles(dir, file, files, *subCtx)
+		}
+		return nil
+	}
+
+	implementations := files[file]
+	if implementations == nil {
+		implementations = map[string]string{}
+		files[file] = implementations
+	}
+	implementations[ctx.CompareFuncName] = ctx.CompareImplementation
+
+	for _, subCtx := range ctx.SubCtxs {
+		WriteCompareFiles(dir, file, files, *subCtx)
+	}
+	return nil
+}
@@ -25,8 +25,8 @@ import (
 // diffTemplateRawTxt defines the Go function template for generating a Diff method
 // when the type is a struct. The generated function builds a diff map by executing
 // the provided implementation code inside it.
-const diffTemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Diff({{.RightSideComparison}} {{.Type}}) map[string][]interface{} {
-	diff := make(map[string][]interface{})
+const diffTemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Diff({{.RightSideComparison}} {{.Type}}) {{.DiffResultType}} {
+	{{.DiffResultInit}}
 	{{.DiffImplementation}}
 	return diff
 }
@@ -35,7 +35,7 @@ const diffTemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Diff({{.Rig
 // diffTemplateDefinedTxt defines the Go function template for generating a Diff method
 // for defined types (type aliases). In this case, the implementation is expected to
 // return the diff map directly, so no initialization code is included.
-const diffTemplateDefinedTxt = `func ({{.LeftSideComparison}} {{.Type}}) Diff({{.RightSideComparison}} {{.Type}}) map[string][]interface{} {
+const diffTemplateDefinedTxt = `func ({{.LeftSideComparison}} {{.Type}}) Diff({{.RightSideComparison}} {{.Type}}) {{.DiffResultType}} {
 	return {{.DiffImplementation}}
 }
 `
@@ -54,13 +54,14 @@ var diffTemplateDefined = template.Must(template.New("DiffTemplateDefined").Pars
 //   - file: Initial target file path (may be overridden based on type and package)
 //   - files: Map of file paths to a map of code sections ("Package", "Imports", "Diff")
 //   - ctx: Code generation context containing metadata and generated implementations
+//   - typed: Whether Diff methods return []eqdiff.Change instead of map[string][]interface{}
 //
 // Behavior:
 //   - Skips generation if Diff function name or implementation is empty, or if there was an error.
 //   - For struct types or defined types, generates a dedicated Go file with the full Diff function.
 //   - For other cases, appends the Diff implementation to an existing entry in the `files` map.
 //   - Recursively processes any sub-contexts to handle nested or related types.
-func WriteDiffFiles(dir, file string, files map[string]map[string]string, ctx data.Ctx) error {
+func WriteDiffFiles(dir, file string, files map[string]map[string]string, ctx data.Ctx, typed bool) error {
 	// Skip if no Diff function name
 	if ctx.DiffFuncName == "" {
 		return nil
@@ -75,15 +76,18 @@ func WriteDiffFiles(dir, file string, files map[string]map[string]string, ctx da
 	}
 
 	// Special handling for struct types and defined types
-	if ctx.ObjectKind == data.KindToString(data.Struct) || ctx.DefinedType {
+	if ctx.HasMethod() {
 		// Build output file path based on package path and type name
 		file = filepath.Join(dir, ctx.PkgPath, strings.ToLower(ctx.Type)+"_diff_generated.go")
 
 		// Prepare template arguments
+		resultType, resultInit := data.DiffResult(typed)
 		args := map[string]string{
+			"DiffResultType":      resultType,
+			"DiffResultInit":      resultInit,
 			"LeftSideComparison":  ctx.LeftSideComparison,
 			"RightSideComparison": ctx.RightSideComparison,
-			"Type":                ctx.Type,
+			"Type":                ctx.Type + ctx.TypeArgs,
 			"DiffImplementation":  ctx.DiffImplementation,
 		}
 
@@ -115,7 +119,7 @@ func WriteDiffFiles(dir, file string, files map[string]map[string]string, ctx da
 		}
 		// Recursively process sub-contexts
 		for _, subCtx := range ctx.SubCtxs {
-			WriteDiffFiles(dir, file, files, *subCtx)
+			WriteDiffFiles(dir, file, files, *subCtx, typed)
 		}
 		return nil
 	}
@@ -130,7 +134,7 @@ func WriteDiffFiles(dir, file string, files map[string]map[string]string, ctx da
 
 	// Recursively process sub-contexts
 	for _, subCtx := range ctx.SubCtxs {
-		WriteDiffFiles(dir, file, files, *subCtx)
+		WriteDiffFiles(dir, file, files, *subCtx, typed)
 	}
 	return nil
 }
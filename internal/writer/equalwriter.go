@@ -59,15 +59,15 @@ func WriteEqualFiles(dir, file string, files map[string]map[string]string, ctx d
 		return nil
 	}
 
-	// Case: Structs or explicitly defined types get their own file
-	if ctx.ObjectKind == data.KindToString(data.Struct) || ctx.DefinedType {
+	// Case: Structs or explicitly defined types get their own file, except instances of generic types
+	if ctx.HasMethod() {
 		file = filepath.Join(dir, ctx.PkgPath, strings.ToLower(ctx.Type)+"_equal_generated.go")
 
 		// Prepare the template arguments for Equal function generation
 		args := map[string]string{
 			"LeftSideComparison":  ctx.LeftSideComparison,
 			"RightSideComparison": ctx.RightSideComparison,
-			"Type":                ctx.Type,
+			"Type":                ctx.Type + ctx.TypeArgs,
 			"EqualImplementation": ctx.EqualImplementation,
 		}
 		// Render the Equal function template into a buffer
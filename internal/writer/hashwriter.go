@@ -0,0 +1,107 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package writer
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// hashTemplateRawTxt defines the Go function template for generating a Hash
+// method when the type is a struct. The implementation writes the values of the
+// fields compared by Equal to h.
+const hashTemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Hash(h hash.Hash64) {
+	{{.HashImplementation}}
+}
+`
+
+// hashTemplateRaw is the parsed template object for struct-based Hash generation.
+var hashTemplateRaw = template.Must(template.New("HashTemplate").Parse(hashTemplateRawTxt))
+
+// WriteHashFiles generates Go files containing Hash methods based on the
+// provided code generation context (`ctx`). It organizes generated code by output file
+// and package.
+//
+// Parameters:
+//   - dir: Base directory where files will be written
+//   - file: Initial target file path (may be overridden based on type and package)
+//   - files: Map of file paths to a map of code sections ("Package", "Imports", "Hash")
+//   - ctx: Code generation context containing metadata and generated implementations
+//
+// Behavior:
+//   - Skips generation if Hash function name or implementation is empty, or if there was an error.
+//   - For struct types, generates a dedicated Go file with the full Hash method.
+//   - For other cases, appends the Hash function to an existing entry in the `files` map.
+//   - Recursively processes any sub-contexts to handle nested or related types.
+func WriteHashFiles(dir, file string, files map[string]map[string]string, ctx data.Ctx) error {
+	if ctx.HashFuncName == "" {
+		return nil
+	}
+	if ctx.HashImplementation == "" {
+		return nil
+	}
+	if ctx.Err {
+		return nil
+	}
+
+	if ctx.HasMethod() {
+		file = filepath.Join(dir, ctx.PkgPath, strings.ToLower(ctx.Type)+"_hash_generated.go")
+
+		args := map[string]string{
+			"LeftSideComparison": ctx.LeftSideComparison,
+			"Type":               ctx.Type + ctx.TypeArgs,
+			"HashImplementation": ctx.HashImplementation,
+		}
+
+		contents := bytes.Buffer{}
+		err := hashTemplateRaw.Execute(&contents, args)
+		if err != nil {
+			return err
+		}
+
+		var importsClause string
+		if len(ctx.Imports) > 0 {
+			imports := bytes.Buffer{}
+			for imp := range ctx.Imports {
+				imports.WriteString("\"" + imp + "\"\n")
+			}
+			importsClause = "import (\n" + imports.String() + ")"
+		}
+		files[file] = map[string]string{
+			"Package": "package " + ctx.Pkg,
+			"Imports": importsClause,
+			"Hash":    contents.String(),
+		}
+		for _, subCtx := range ctx.SubCtxs {
+			WriteHashFiles(dir, file, files, *subCtx)
+		}
+		return nil
+	}
+
+	implementations := files[file]
+	if implementations == nil {
+		implementations = map[string]string{}
+		files[file] = implementations
+	}
+	implementations[ctx.HashFuncName] = ctx.HashImplementation
+
+	for _, subCtx := range ctx.SubCtxs {
+		WriteHashFiles(dir, file, files, *subCtx)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package writer
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// merge3TemplateRawTxt defines the Go function template for generating a Merge3
+// method when the type is a struct. The receiver is the common ancestor of ours and
+// theirs, the implementation merges them into merged and collects the conflicts.
+const merge3TemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Merge3(ours, theirs {{.Type}}) ({{.Type}}, []eqdiff.Conflict) {
+	{{.Merge3Implementation}}
+	return merged, conflicts
+}
+`
+
+// merge3TemplateRaw is the parsed template object for struct-based Merge3 generation.
+var merge3TemplateRaw = template.Must(template.New("Merge3Template").Parse(merge3TemplateRawTxt))
+
+// WriteMerge3Files generates Go files containing Merge3 methods based on the
+// provided code generation context (`ctx`). It organizes generated code by output file
+// and package.
+//
+// Parameters:
+//   - dir: Base directory where files will be written
+//   - file: Initial target file path (may be overridden based on type and package)
+//   - files: Map of file paths to a map of code sections ("Package", "Imports", "Merge3")
+//   - ctx: Code generation context containing metadata and generated implementations
+//
+// Behavior:
+//   - Skips generation if Merge3 function name or implementation is empty, or if there was an error.
+//   - For struct types, generates a dedicated Go file with the full Merge3 method.
+//   - For other cases, appends the Merge3 function to an existing entry in the `files` map.
+//   - Recursively processes any sub-contexts to handle nested or related types.
+func WriteMerge3Files(dir, file string, files map[string]map[string]string, ctx data.Ctx) error {
+	if ctx.Merge3FuncName == "" {
+		return nil
+	}
+	if ctx.Merge3Implementation == "" {
+		return nil
+	}
+	if ctx.Err {
+		return nil
+	}
+
+	if ctx.HasMethod() {
+		file = filepath.Join(dir, ctx.PkgPath, strings.ToLower(ctx.Type)+"_merge3_generated.go")
+
+		args := map[string]string{
+			"LeftSideComparison":   ctx.LeftSideComparison,
+			"Type":                 ctx.Type + ctx.TypeArgs,
+			"Merge3Implementation": ctx.Merge3Implementation,
+		}
+
+		contents := bytes.Buffer{}
+		err := merge3TemplateRaw.Execute(&contents, args)
+		if err != nil {
+			return err
+		}
+
+		var importsClause string
+		if len(ctx.Imports) > 0 {
+			imports := bytes.Buffer{}
+			for imp := range ctx.Imports {
+				imports.WriteString("\"" + imp + "\"\n")
+			}
+			importsClause = "import (\n" + imports.String() + ")"
+		}
+		files[file] = map[string]string{
+			"Package": "package " + ctx.Pkg,
+			"Imports": importsClause,
+			"Merge3":  contents.String(),
+		}
+		for _, subCtx := range ctx.SubCtxs {
+			WriteMerge3Files(dir, file, files, *subCtx)
+		}
+		return nil
+	}
+
+	implementations := files[file]
+	if implementations == nil {
+		implementations = map[string]string{}
+		files[file] = implementations
+	}
+	implementations[ctx.Merge3FuncName] = ctx.Merge3Implementation
+
+	for _, subCtx := range ctx.SubCtxs {
+		WriteMerge3Files(dir, file, files, *subCtx)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package writer
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// mergeTemplateRawTxt defines the Go function template for generating a Merge method
+// when the type is a struct. The implementation updates the fields of the receiver
+// copy, which is then returned as the merged value.
+const mergeTemplateRawTxt = `func ({{.LeftSideComparison}} {{.Type}}) Merge({{.RightSideComparison}} {{.Type}}) {{.Type}} {
+	{{.MergeImplementation}}
+	return {{.LeftSideComparison}}
+}
+`
+
+// mergeTemplateDefinedTxt defines the Go function template for generating a Merge method
+// for defined types (type aliases). The implementation is expected to return the merged
+// value directly.
+const mergeTemplateDefinedTxt = `func ({{.LeftSideComparison}} {{.Type}}) Merge({{.RightSideComparison}} {{.Type}}) {{.Type}} {
+	return {{.MergeImplementation}}
+}
+`
+
+// mergeTemplateRaw is the parsed template object for struct-based Merge generation.
+var mergeTemplateRaw = template.Must(template.New("MergeTemplate").Parse(mergeTemplateRawTxt))
+
+// mergeTemplateDefined is the parsed template object for defined-type Merge generation.
+var mergeTemplateDefined = template.Must(template.New("MergeTemplateDefined").Parse(mergeTemplateDefinedTxt))
+
+// WriteMergeFiles generates Go files containing Merge methods based on the provided
+// code generation context (`ctx`). It organizes generated code by output file and package.
+//
+// Parameters:
+//   - dir: Base directory where files will be written
+//   - file: Initial target file path (may be overridden based on type and package)
+//   - files: Map of file paths to a map of code sections ("Package", "Imports", "Merge")
+//   - ctx: Code generation context containing metadata and generated implementations
+//
+// Behavior:
+//   - Skips generation if Merge function name or implementation is empty, or if there was an error.
+//   - For struct types or defined types, generates a dedicated Go file with the full Merge function.
+//   - For other cases, appends the Merge implementation to an existing entry in the `files` map.
+//   - Recursively processes any sub-contexts to handle nested or related types.
+func WriteMergeFiles(dir, file string, files map[string]map[string]string, ctx data.Ctx) error {
+	if ctx.MergeFuncName == "" {
+		return nil
+	}
+	if ctx.MergeImplementation == "" {
+		return nil
+	}
+	if ctx.Err {
+		return nil
+	}
+
+	if ctx.HasMethod() {
+		file = filepath.Join(dir, ctx.PkgPath, strings.ToLower(ctx.Type)+"_merge_generated.go")
+
+		args := map[string]string{
+			"LeftSideComparison":  ctx.LeftSideComparison,
+			"RightSideComparison": ctx.RightSideComparison,
+			"Type":                ctx.Type + ctx.TypeArgs,
+			"MergeImplementation": ctx.MergeImplementation,
+		}
+
+		contents := bytes.Buffer{}
+		mergeTemplate := mergeTemplateRaw
+		if ctx.DefinedType {
+			mergeTemplate = mergeTemplateDefined
+		}
+		err := mergeTemplate.Execute(&contents, args)
+		if err != nil {
+			return err
+		}
+
+		var importsClause string
+		if len(ctx.Imports) > 0 {
+			imports := bytes.Buffer{}
+			for imp := range ctx.Imports {
+				imports.WriteString("\"" + imp + "\"\n")
+			}
+			importsClause = "import (\n" + imports.String() + ")"
+		}
+		files[file] = map[string]string{
+			"Package": "package " + ctx.Pkg,
+			"Imports": importsClause,
+			"Merge":   contents.String(),
+		}
+		for _, subCtx := range ctx.SubCtxs {
+			WriteMergeFiles(dir, file, files, *subCtx)
+		}
+		return nil
+	}
+
+	implementations := files[file]
+	if implementations == nil {
+		implementations = map[string]string{}
+		files[file] = implementations
+	}
+	implementations[ctx.MergeFuncName] = ctx.MergeImplementation
+
+	for _, subCtx := range ctx.SubCtxs {
+		WriteMergeFiles(dir, file, files, *subCtx)
+	}
+	return nil
+}
@@ -0,0 +1,377 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ApplyError is returned by generated ApplyDiff methods when a change cannot
+// be applied.
+type ApplyError struct {
+	Path string // Diff key of the change
+	Err  error
+}
+
+func (e *ApplyError) Error() string {
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *ApplyError) Unwrap() error {
+	return e.Err
+}
+
+// ErrUnknownField is returned by generated ApplyDiff methods for changes of
+// fields their struct does not have.
+var ErrUnknownField = errors.New("unknown field")
+
+// ApplyChanges calls apply with each change of a typed diff, in an order they
+// can be applied in sequentially, as JSONPatch does: moved values are removed
+// from their former index and added at their new one. It is called by
+// generated ApplyDiff methods.
+func ApplyChanges(changes []Change, apply func(change Change) error) error {
+	ordered, err := orderChanges(changes)
+	if err != nil {
+		return err
+	}
+	for _, change := range ordered {
+		err := apply(change.Change)
+		if err != nil {
+			// Errors of nested ApplyDiff methods hold the path of the
+			// change relative to their value
+			var applyErr *ApplyError
+			if errors.As(err, &applyErr) {
+				err = applyErr.Err
+			}
+			return &ApplyError{Path: change.Path, Err: err}
+		}
+	}
+	return nil
+}
+
+// SplitField returns the name of the struct field starting the path of a
+// change, e.g. "Servers" for "Servers[0].Name", and the change with the rest
+// of its path, e.g. "[0].Name".
+func SplitField(change Change) (string, Change, error) {
+	path := strings.TrimPrefix(change.Path, ".")
+	end := strings.IndexAny(path, ".[")
+	if end == 0 {
+		return "", change, fmt.Errorf("expected a field name, got %q", change.Path)
+	}
+	if end < 0 {
+		end = len(path)
+	}
+	change.Path = path[end:]
+	return path[:end], change, nil
+}
+
+// ApplyValue sets v to the new value of a change, or to its zero value if the
+// value is removed. Values of other types are converted, by Go conversion if
+// they have the kind of v, or by encoding them in JSON otherwise.
+func ApplyValue[T any](v *T, change Change) error {
+	if change.Path != "" {
+		return fmt.Errorf("unexpected path %q in %T value", change.Path, *v)
+	}
+	if change.Op == Removed || change.New == nil {
+		var zero T
+		*v = zero
+		return nil
+	}
+	value, err := convert[T](change.New)
+	if err != nil {
+		return err
+	}
+	*v = value
+	return nil
+}
+
+// ApplySlice applies a change to the slice s, calling apply to set the values
+// of its elements, from the zero value for added ones. Elements are selected
+// by index, e.g. "[1]", or by key for slices diffed with the key option, e.g.
+// "[Name=web]", in which case key returns the key of an element as formatted
+// by fmt. Elements selected by key are added at the end of the slice.
+func ApplySlice[S ~[]T, T any](s *S, change Change, apply func(v *T, change Change) error, key func(v T) string) error {
+	if change.Path == "" {
+		return ApplyValue(s, change)
+	}
+	token, rest, err := splitElement(change.Path)
+	if err != nil {
+		return err
+	}
+	change.Path = rest
+	elements := *s
+	if key != nil && isKeySelector(token) {
+		_, value, _ := strings.Cut(token, "=")
+		if change.Op == Added && rest == "" {
+			var elem T
+			err := apply(&elem, change)
+			if err != nil {
+				return err
+			}
+			*s = append(elements, elem)
+			return nil
+		}
+		// Diffs match the first element having a key, later ones are removed
+		matches := make([]int, 0, 1)
+		for i, elem := range elements {
+			if key(elem) == value {
+				matches = append(matches, i)
+			}
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("no element with key %s", token)
+		}
+		if change.Op == Removed && rest == "" {
+			i := matches[len(matches)-1]
+			*s = append(elements[:i:i], elements[i+1:]...)
+			return nil
+		}
+		return apply(&elements[matches[0]], change)
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 {
+		return fmt.Errorf("invalid index %q", token)
+	}
+	switch {
+	case change.Op == Added && rest == "":
+		if i > len(elements) {
+			return fmt.Errorf("index %d out of range, length %d", i, len(elements))
+		}
+		var elem T
+		err := apply(&elem, change)
+		if err != nil {
+			return err
+		}
+		grown := make(S, 0, len(elements)+1)
+		grown = append(grown, elements[:i]...)
+		grown = append(grown, elem)
+		*s = append(grown, elements[i:]...)
+		return nil
+	case i >= len(elements):
+		return fmt.Errorf("index %d out of range, length %d", i, len(elements))
+	case change.Op == Removed && rest == "":
+		*s = append(elements[:i:i], elements[i+1:]...)
+		return nil
+	}
+	return apply(&elements[i], change)
+}
+
+// ApplyArray applies a change to the elements of an array, calling apply to
+// set the value of the element selected by index, e.g. "[1]".
+func ApplyArray[T any](elements []T, change Change, apply func(v *T, change Change) error) error {
+	token, rest, err := splitElement(change.Path)
+	if err != nil {
+		return err
+	}
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || i >= len(elements) {
+		return fmt.Errorf("invalid index %q, length %d", token, len(elements))
+	}
+	change.Path = rest
+	return apply(&elements[i], change)
+}
+
+// ApplyMap applies a change to the map m, calling apply to set the values of
+// its entries, from the zero value for added ones. Entries are selected by key
+// as formatted by fmt, e.g. "[web]": only maps with keys of boolean, numeric
+// or string kinds can be changed. The map is created if it is nil.
+func ApplyMap[M ~map[K]V, K comparable, V any](m *M, change Change, apply func(v *V, change Change) error) error {
+	if change.Path == "" {
+		return ApplyValue(m, change)
+	}
+	token, rest, err := splitElement(change.Path)
+	if err != nil {
+		return err
+	}
+	k, err := parseKey[K](token)
+	if err != nil {
+		return err
+	}
+	if change.Op == Removed && rest == "" {
+		delete(*m, k)
+		return nil
+	}
+	v := (*m)[k]
+	change.Path = rest
+	err = apply(&v, change)
+	if err != nil {
+		return err
+	}
+	if *m == nil {
+		*m = make(M)
+	}
+	(*m)[k] = v
+	return nil
+}
+
+// ApplyPointer applies a change to the pointer p, calling apply to set the
+// pointed value, which is allocated if p is nil. Pointers set as a whole are
+// set to the new value of the change if it is a pointer, or to a copy of it.
+func ApplyPointer[P ~*T, T any](p *P, change Change, apply func(v *T, change Change) error) error {
+	if change.Path != "" {
+		if *p == nil {
+			*p = new(T)
+		}
+		return apply(*p, change)
+	}
+	if change.Op == Removed || change.New == nil {
+		*p = nil
+		return nil
+	}
+	if v, ok := change.New.(P); ok {
+		*p = v
+		return nil
+	}
+	if v, ok := change.New.(*T); ok {
+		*p = v
+		return nil
+	}
+	v := new(T)
+	err := apply(v, Change{Op: change.Op, Old: change.Old, New: change.New})
+	if err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// ApplyEmbedded applies a change to a member of the struct pointed by p, an
+// embedded pointer whose members are diffed as members of its struct, with
+// apply. The struct is allocated if p is nil, and only kept if the change
+// could be applied to it.
+func ApplyEmbedded[T any](p **T, change Change, apply func(v *T, change Change) error) error {
+	if *p != nil {
+		return apply(*p, change)
+	}
+	v := new(T)
+	if err := apply(v, change); err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// ApplyInterface applies a change to the interface value v. Changes of the
+// value held by v, with paths starting with ".", are applied with its
+// ApplyDiff method, on a copy of the value if it is not a pointer.
+func ApplyInterface[T any](v *T, change Change) error {
+	if change.Path == "" {
+		return ApplyValue(v, change)
+	}
+	if !strings.HasPrefix(change.Path, ".") {
+		return fmt.Errorf("unexpected path %q in %T value", change.Path, *v)
+	}
+	change.Path = change.Path[1:]
+	value := reflect.ValueOf(*v)
+	if !value.IsValid() || (value.Kind() == reflect.Pointer && value.IsNil()) {
+		return fmt.Errorf("unexpected path %q in nil %T value", change.Path, *v)
+	}
+	target := value
+	if value.Kind() != reflect.Pointer {
+		target = reflect.New(value.Type())
+		target.Elem().Set(value)
+	}
+	var err error
+	switch applier := target.Interface().(type) {
+	case interface{ ApplyDiff([]Change) error }:
+		err = applier.ApplyDiff([]Change{change})
+	default:
+		return fmt.Errorf("%s has no ApplyDiff method", value.Type())
+	}
+	if err != nil {
+		return err
+	}
+	if value.Kind() != reflect.Pointer {
+		*v = target.Elem().Interface().(T)
+	}
+	return nil
+}
+
+// splitElement returns the key of the element starting path, e.g. "0" for
+// "[0].Name", and the rest of the path, e.g. ".Name".
+func splitElement(path string) (string, string, error) {
+	if path[0] != '[' {
+		return "", "", fmt.Errorf("expected an element key, got %q", path)
+	}
+	end := closingBracket(path)
+	if end < 0 {
+		return "", "", fmt.Errorf("%s: unterminated element key", path)
+	}
+	return path[1:end], path[end+1:], nil
+}
+
+// parseKey parses a map key formatted by fmt.
+func parseKey[K comparable](token string) (K, error) {
+	var k K
+	key := reflect.ValueOf(&k).Elem()
+	switch key.Kind() {
+	case reflect.String:
+		key.SetString(token)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(token)
+		if err != nil {
+			return k, err
+		}
+		key.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(token, 10, key.Type().Bits())
+		if err != nil {
+			return k, err
+		}
+		key.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(token, 10, key.Type().Bits())
+		if err != nil {
+			return k, err
+		}
+		key.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(token, key.Type().Bits())
+		if err != nil {
+			return k, err
+		}
+		key.SetFloat(f)
+	default:
+		return k, fmt.Errorf("cannot parse map keys of type %T", k)
+	}
+	return k, nil
+}
+
+// convert converts v to T, by Go conversion if it has the kind of T, or by
+// encoding it in JSON otherwise.
+func convert[T any](v interface{}) (T, error) {
+	if t, ok := v.(T); ok {
+		return t, nil
+	}
+	var t T
+	value := reflect.ValueOf(v)
+	target := reflect.TypeOf(&t).Elem()
+	if value.Kind() == target.Kind() && value.Type().ConvertibleTo(target) {
+		return value.Convert(target).Interface().(T), nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return t, fmt.Errorf("cannot convert %T to %T: %w", v, t, err)
+	}
+	err = json.Unmarshal(data, &t)
+	if err != nil {
+		return t, fmt.Errorf("cannot convert %T to %T: %w", v, t, err)
+	}
+	return t, nil
+}
@@ -0,0 +1,34 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+// Op is the kind of a Change reported by typed Diff methods.
+type Op string
+
+const (
+	Added    Op = "added"    // The value only exists in the compared object
+	Removed  Op = "removed"  // The value only exists in the receiver
+	Modified Op = "modified" // The value exists on both sides but differs
+	Moved    Op = "moved"    // The value exists on both sides at different positions
+)
+
+// Change is a single difference reported by Diff methods generated with the
+// TypedDiff option.
+type Change struct {
+	Path string      // Path of the changed value, e.g. "Servers[0].Name"
+	Op   Op          // Kind of change
+	Old  interface{} // Value in the receiver, nil when Op is Added
+	New  interface{} // Value in the compared object, nil when Op is Removed
+	From string      // Path of the value in the receiver when Op is Moved
+}
@@ -0,0 +1,311 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+)
+
+// CompareBool compares booleans, false first. As the functions of the cmp
+// package, it returns -1 if x is less than y, 0 if they are equal and +1 if x
+// is greater than y.
+func CompareBool[T ~bool](x, y T) int {
+	switch {
+	case x == y:
+		return 0
+	case !bool(x):
+		return -1
+	}
+	return 1
+}
+
+// CompareComplex compares complex numbers by their real parts, then by
+// their imaginary parts.
+func CompareComplex[T ~complex64 | ~complex128](x, y T) int {
+	if c := cmp.Compare(real(complex128(x)), real(complex128(y))); c != 0 {
+		return c
+	}
+	return cmp.Compare(imag(complex128(x)), imag(complex128(y)))
+}
+
+// CompareComparable compares values of any comparable type, such as the keys
+// of maps: booleans, numbers and strings in their natural order, arrays and
+// structs member by member, and interfaces by the name of their dynamic type,
+// then by their values. Pointers and channels are compared by address, nil
+// first: their order is only stable within a process.
+func CompareComparable[T comparable](x, y T) int {
+	return compareReflectValues(reflect.ValueOf(&x).Elem(), reflect.ValueOf(&y).Elem())
+}
+
+// CompareSlice compares slices lexicographically, with compare for their
+// elements: the first unequal elements decide, then the shorter slice comes
+// first. Nil and empty slices are equal. Slices of elements which cannot be
+// compared have a nil compare function, and are compared by length. Arrays
+// are compared as slices of their elements.
+func CompareSlice[S ~[]T, T any](x, y S, compare func(x, y T) int) int {
+	if compare == nil {
+		return cmp.Compare(len(x), len(y))
+	}
+	return slices.CompareFunc(x, y, compare)
+}
+
+// CompareSet compares slices compared regardless of the order of their
+// elements, as CompareSlice compares them once sorted with compare: slices
+// holding the same elements in another order are equal.
+func CompareSet[S ~[]T, T any](x, y S, compare func(x, y T) int) int {
+	if compare == nil {
+		return cmp.Compare(len(x), len(y))
+	}
+	x, y = slices.Clone(x), slices.Clone(y)
+	slices.SortStableFunc(x, compare)
+	slices.SortStableFunc(y, compare)
+	return slices.CompareFunc(x, y, compare)
+}
+
+// CompareMap compares maps as CompareSlice compares the slices of their
+// entries sorted by key: keys are compared with CompareComparable, then
+// values with compare. Maps of values which cannot be compared have a nil
+// compare function, and are compared by their keys.
+func CompareMap[M ~map[K]V, K comparable, V any](x, y M, compare func(x, y V) int) int {
+	keysX, keysY := sortedKeys(x), sortedKeys(y)
+	for i := 0; i < len(keysX) && i < len(keysY); i++ {
+		if c := CompareComparable(keysX[i], keysY[i]); c != 0 {
+			return c
+		}
+		if compare == nil {
+			continue
+		}
+		if c := compare(x[keysX[i]], y[keysY[i]]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(keysX), len(keysY))
+}
+
+// ComparePointer compares pointers, nil first, then by the pointed values
+// compared with compare. Pointers to values which cannot be compared have a
+// nil compare function.
+func ComparePointer[P ~*T, T any](x, y P, compare func(x, y T) int) int {
+	switch {
+	case x == nil && y == nil:
+		return 0
+	case x == nil:
+		return -1
+	case y == nil:
+		return 1
+	case compare == nil:
+		return 0
+	}
+	return compare(*x, *y)
+}
+
+// CompareNilEmpty compares pointers whose nil value equals a pointer to the
+// zero value: nil pointers are compared as pointers to the zero value.
+func CompareNilEmpty[P ~*T, T any](x, y P, compare func(x, y T) int) int {
+	if compare == nil {
+		return 0
+	}
+	var zero T
+	if x == nil {
+		x = &zero
+	}
+	if y == nil {
+		y = &zero
+	}
+	return compare(*x, *y)
+}
+
+// CompareInterface compares interface values: nil values first, then values
+// by the name of their dynamic type, then values of the same type with their
+// Compare method, if they have one, or as CompareComparable compares them
+// if they are builtin values. Pointers are compared nil first, then by their
+// pointed values. Other values are equal.
+func CompareInterface(x, y interface{}) int {
+	switch {
+	case x == nil && y == nil:
+		return 0
+	case x == nil:
+		return -1
+	case y == nil:
+		return 1
+	}
+	vx, vy := reflect.ValueOf(x), reflect.ValueOf(y)
+	if c := cmp.Compare(vx.Type().String(), vy.Type().String()); c != 0 {
+		return c
+	}
+	if vx.Kind() == reflect.Pointer {
+		switch {
+		case vx.IsNil() && vy.IsNil():
+			return 0
+		case vx.IsNil():
+			return -1
+		case vy.IsNil():
+			return 1
+		}
+		vx, vy = vx.Elem(), vy.Elem()
+	}
+	if method := vx.MethodByName("Compare"); method.IsValid() {
+		methodType := method.Type()
+		if methodType.NumIn() == 1 && methodType.In(0) == vx.Type() &&
+			methodType.NumOut() == 1 && methodType.Out(0).Kind() == reflect.Int {
+			return int(method.Call([]reflect.Value{vy})[0].Int())
+		}
+	}
+	if vx.Type().PkgPath() == "" && vx.Comparable() {
+		return compareReflectValues(vx, vy)
+	}
+	return 0
+}
+
+// CompareDeep compares values of any type consistently with
+// reflect.DeepEqual, such as the values of type parameters which are not
+// comparable: values are compared as CompareComparable compares them, but
+// pointers by their pointed values, slices as CompareSlice compares them and
+// maps as CompareMap does. Nil slices and maps come before empty ones, as
+// they are not deeply equal. Functions are compared nil first, then by
+// address. Cyclic values are only supported through identical pointers.
+func CompareDeep[T any](x, y T) int {
+	return compareDeepValues(reflect.ValueOf(&x).Elem(), reflect.ValueOf(&y).Elem())
+}
+
+// sortedKeys returns the keys of a map sorted with CompareComparable.
+func sortedKeys[M ~map[K]V, K comparable, V any](m M) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.SortFunc(keys, CompareComparable[K])
+	return keys
+}
+
+// compareReflectValues compares values of the same comparable type, as
+// CompareComparable does.
+func compareReflectValues(x, y reflect.Value) int {
+	switch x.Kind() {
+	case reflect.Bool:
+		return CompareBool(x.Bool(), y.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(x.Int(), y.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(x.Uint(), y.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(x.Float(), y.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return CompareComplex(x.Complex(), y.Complex())
+	case reflect.String:
+		return cmp.Compare(x.String(), y.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return cmp.Compare(x.Pointer(), y.Pointer())
+	case reflect.Array:
+		for i := 0; i < x.Len(); i++ {
+			if c := compareReflectValues(x.Index(i), y.Index(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			if c := compareReflectValues(x.Field(i), y.Field(i)); c != 0 {
+				return c
+			}
+		}
+	case reflect.Interface:
+		switch {
+		case x.IsNil() && y.IsNil():
+			return 0
+		case x.IsNil():
+			return -1
+		case y.IsNil():
+			return 1
+		}
+		if c := cmp.Compare(x.Elem().Type().String(), y.Elem().Type().String()); c != 0 {
+			return c
+		}
+		return compareReflectValues(x.Elem(), y.Elem())
+	}
+	return 0
+}
+
+// compareDeepValues compares values of the same type, as CompareDeep does.
+func compareDeepValues(x, y reflect.Value) int {
+	switch x.Kind() {
+	case reflect.Pointer:
+		switch {
+		case x.Pointer() == y.Pointer():
+			return 0
+		case x.IsNil():
+			return -1
+		case y.IsNil():
+			return 1
+		}
+		return compareDeepValues(x.Elem(), y.Elem())
+	case reflect.Slice:
+		switch {
+		case x.IsNil() || y.IsNil():
+			return CompareBool(!x.IsNil(), !y.IsNil())
+		case x.Pointer() == y.Pointer() && x.Len() == y.Len():
+			return 0
+		}
+		fallthrough
+	case reflect.Array:
+		for i := 0; i < x.Len() && i < y.Len(); i++ {
+			if c := compareDeepValues(x.Index(i), y.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(x.Len(), y.Len())
+	case reflect.Map:
+		if x.IsNil() || y.IsNil() {
+			return CompareBool(!x.IsNil(), !y.IsNil())
+		}
+		keysX, keysY := x.MapKeys(), y.MapKeys()
+		for _, keys := range [][]reflect.Value{keysX, keysY} {
+			slices.SortFunc(keys, compareDeepValues)
+		}
+		for i := 0; i < len(keysX) && i < len(keysY); i++ {
+			if c := compareDeepValues(keysX[i], keysY[i]); c != 0 {
+				return c
+			}
+			if c := compareDeepValues(x.MapIndex(keysX[i]), y.MapIndex(keysY[i])); c != 0 {
+				return c
+			}
+		}
+		return cmp.Compare(len(keysX), len(keysY))
+	case reflect.Struct:
+		for i := 0; i < x.NumField(); i++ {
+			if c := compareDeepValues(x.Field(i), y.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		switch {
+		case x.IsNil() && y.IsNil():
+			return 0
+		case x.IsNil() || y.IsNil():
+			return CompareBool(!x.IsNil(), !y.IsNil())
+		}
+		if c := cmp.Compare(x.Elem().Type().String(), y.Elem().Type().String()); c != 0 {
+			return c
+		}
+		return compareDeepValues(x.Elem(), y.Elem())
+	case reflect.Func:
+		if x.IsNil() || y.IsNil() {
+			return CompareBool(!x.IsNil(), !y.IsNil())
+		}
+		return cmp.Compare(x.Pointer(), y.Pointer())
+	}
+	return compareReflectValues(x, y)
+}
@@ -0,0 +1,106 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+// checkAscending checks that values, sorted in ascending order, are ordered
+// by compare: it only returns 0 for a value compared with itself.
+func checkAscending[T any](t *testing.T, name string, values []T, compare func(x, y T) int) {
+	t.Helper()
+	for i, x := range values {
+		for j, y := range values {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := compare(x, y); got != want {
+				t.Errorf("%s(%#v, %#v) = %d, want %d", name, x, y, got, want)
+			}
+		}
+	}
+}
+
+func TestCompareDeep(t *testing.T) {
+	one, two := 1, 2
+	checkAscending(t, "CompareDeep", []*int{nil, &one, &two}, eqdiff.CompareDeep[*int])
+	checkAscending(t, "CompareDeep", [][]int{nil, {}, {1}, {1, 1}, {2}}, eqdiff.CompareDeep[[]int])
+	checkAscending(t, "CompareDeep", []map[string][]int{
+		nil,
+		{},
+		{"a": nil},
+		{"a": {}},
+		{"a": {1}},
+		{"a": {1}, "b": nil},
+		{"b": nil},
+	}, eqdiff.CompareDeep[map[string][]int])
+	checkAscending(t, "CompareDeep", []any{nil, []string{"a"}, 1, 2, "a"}, eqdiff.CompareDeep[any])
+
+	type record struct {
+		Name string
+		Tags []string
+	}
+	checkAscending(t, "CompareDeep", []record{
+		{},
+		{Tags: []string{"a"}},
+		{Name: "a", Tags: []string{}},
+		{Name: "a", Tags: []string{"a"}},
+	}, eqdiff.CompareDeep[record])
+
+	// Values equal with reflect.DeepEqual are equal
+	x, y := map[string]*record{"a": {Name: "a"}}, map[string]*record{"a": {Name: "a"}}
+	if !reflect.DeepEqual(x, y) || eqdiff.CompareDeep(x, y) != 0 {
+		t.Errorf("CompareDeep(%v, %v) = %d, want 0", x, y, eqdiff.CompareDeep(x, y))
+	}
+}
+
+func TestCompareContainers(t *testing.T) {
+	compareInt := eqdiff.CompareComparable[int]
+	checkAscending(t, "CompareSlice", [][]int{{}, {1}, {1, 1}, {2}},
+		func(x, y []int) int { return eqdiff.CompareSlice(x, y, compareInt) })
+	checkAscending(t, "CompareSet", [][]int{{}, {1}, {1, 1}, {1, 2}, {2}},
+		func(x, y []int) int { return eqdiff.CompareSet(x, y, compareInt) })
+	checkAscending(t, "CompareMap", []map[string]int{{}, {"a": 1}, {"a": 1, "b": 0}, {"a": 2}, {"b": 0}},
+		func(x, y map[string]int) int { return eqdiff.CompareMap(x, y, compareInt) })
+	one, two := 1, 2
+	checkAscending(t, "ComparePointer", []*int{nil, &one, &two},
+		func(x, y *int) int { return eqdiff.ComparePointer(x, y, compareInt) })
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "nil and empty slices", got: eqdiff.CompareSlice(nil, []int{}, compareInt), want: 0},
+		{name: "slices without compare function", got: eqdiff.CompareSlice([]int{2}, []int{1, 0}, nil), want: -1},
+		{name: "sets in another order", got: eqdiff.CompareSet([]int{2, 1}, []int{1, 2}, compareInt), want: 0},
+		{name: "maps without compare function", got: eqdiff.CompareMap(map[string]int{"a": 1}, map[string]int{"a": 2}, nil), want: 0},
+		{name: "nil pointer and pointer to zero value", got: eqdiff.CompareNilEmpty(nil, new(int), compareInt), want: 0},
+		{name: "interfaces of different types", got: eqdiff.CompareInterface(1, "a"), want: -1},
+		{name: "interfaces of the same type", got: eqdiff.CompareInterface("b", "a"), want: 1},
+	}
+	for _, test := range tests {
+		if test.got != test.want {
+			t.Errorf("%s: got %d, want %d", test.name, test.got, test.want)
+		}
+	}
+}
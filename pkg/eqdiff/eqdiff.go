@@ -16,6 +16,7 @@ package eqdiff
 import (
 	"bytes"
 	"fmt"
+	"go/types"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -24,8 +25,15 @@ import (
 
 	"github.com/haproxytech/go-method-gen/internal/common"
 	"github.com/haproxytech/go-method-gen/internal/data"
+	"github.com/haproxytech/go-method-gen/internal/generators/applydiff"
+	"github.com/haproxytech/go-method-gen/internal/generators/clone"
+	"github.com/haproxytech/go-method-gen/internal/generators/compare"
 	"github.com/haproxytech/go-method-gen/internal/generators/diff"
 	"github.com/haproxytech/go-method-gen/internal/generators/equal"
+	"github.com/haproxytech/go-method-gen/internal/generators/hash"
+	"github.com/haproxytech/go-method-gen/internal/generators/merge"
+	"github.com/haproxytech/go-method-gen/internal/generators/merge3"
+	"github.com/haproxytech/go-method-gen/internal/generators/mergepatch"
 	"github.com/haproxytech/go-method-gen/internal/parser"
 	"github.com/haproxytech/go-method-gen/internal/writer"
 	imp "golang.org/x/tools/imports"
@@ -33,25 +41,194 @@ import (
 	"mvdan.cc/gofumpt/format"
 )
 
+// GeneratedComment is the first line of the files written by go-method-gen.
+const GeneratedComment = "// Code generated by go-method-gen. DO NOT EDIT."
+
+// Methods that can be generated, for Options.Methods
+const (
+	MethodEqual      = "equal"
+	MethodDiff       = "diff"
+	MethodMerge      = "merge"
+	MethodClone      = "clone"
+	MethodMergePatch = "mergepatch" // Only generated if listed, implies MethodEqual
+	MethodApplyDiff  = "applydiff"  // Only generated if listed, requires TypedDiff
+	MethodMerge3     = "merge3"     // Only generated if listed, implies MethodEqual
+	MethodHash       = "hash"       // Only generated if listed
+	MethodCompare    = "compare"    // Only generated if listed
+)
+
+// optInMethods are the methods only generated if listed in Options.Methods.
+var optInMethods = []string{MethodMergePatch, MethodApplyDiff, MethodMerge3, MethodHash, MethodCompare}
+
 // Options for the code generation
 type Options struct {
-	OutputDir     string // Output directory for generated files
-	OverridesFile string // YAML file containing function overrides
-	HeaderPath    string // Optional header file to prepend to generated files
+	OutputDir     string   // Output directory for generated files
+	OverridesFile string   // YAML file containing function overrides
+	HeaderPath    string   // Optional header file to prepend to generated files
+	TypedDiff     bool     // Generate Diff methods returning []Change instead of map[string][]interface{}
+	InPackage     bool     // Write files in the directories of the packages of the types instead of OutputDir
+	PackagesDir   string   // Directory packages are resolved from with InPackage (default: current directory)
+	Strict        bool     // Fail with a *SkippedFieldsError if fields would be skipped by the generated functions
+	Report        *Report  // If not nil, filled with the fields skipped by the generated functions
+	Methods       []string // Methods to generate, among MethodEqual, MethodDiff, MethodMerge, MethodClone, MethodMergePatch, MethodApplyDiff, MethodMerge3, MethodHash and MethodCompare (default: all but MethodMergePatch, MethodApplyDiff, MethodMerge3, MethodHash and MethodCompare)
+	DiffNameTag   string   // Struct tag naming fields in diff keys, e.g. "json" (default: Go field names)
+}
+
+// generates reports whether the functions of method are generated with opts.
+func (opts Options) generates(method string) bool {
+	if len(opts.Methods) == 0 {
+		return !slices.Contains(optInMethods, method)
+	}
+	// MergePatch and Merge3 functions compare values with the Equal functions
+	return slices.Contains(opts.Methods, method) ||
+		(method == MethodEqual && (slices.Contains(opts.Methods, MethodMergePatch) || slices.Contains(opts.Methods, MethodMerge3)))
+}
+
+// ParseMethods parses a comma separated list of methods, such as "equal,diff",
+// for Options.Methods.
+func ParseMethods(list string) ([]string, error) {
+	var methods []string
+	for _, method := range strings.Split(list, ",") {
+		method = strings.ToLower(strings.TrimSpace(method))
+		if method == "" {
+			continue
+		}
+		if err := checkMethod(method); err != nil {
+			return nil, err
+		}
+		if !slices.Contains(methods, method) {
+			methods = append(methods, method)
+		}
+	}
+	if len(methods) == 0 {
+		return nil, fmt.Errorf("no method in %q", list)
+	}
+	return methods, nil
+}
+
+// checkMethod returns an error if method cannot be generated.
+func checkMethod(method string) error {
+	switch method {
+	case MethodEqual, MethodDiff, MethodMerge, MethodClone, MethodMergePatch, MethodApplyDiff, MethodMerge3, MethodHash,
+		MethodCompare:
+		return nil
+	}
+	return fmt.Errorf("unknown method %q, expected %s, %s, %s, %s, %s, %s, %s, %s or %s", method,
+		MethodEqual, MethodDiff, MethodMerge, MethodClone, MethodMergePatch, MethodApplyDiff, MethodMerge3, MethodHash,
+		MethodCompare)
+}
+
+// GeneratedFile is a Go source file produced by the generation.
+type GeneratedFile struct {
+	Path    string // Path of the file, in Options.OutputDir or in the directory of its package
+	Package string // Name of the package of the file
+	Source  []byte // Formatted source of the file
 }
 
-// Generate generates Equal and Diff functions for the provided types.
+// Generate generates Equal, Diff, Merge and Clone functions for the provided
+// types and writes their files.
 func Generate(types []reflect.Type, opts Options) error {
+	files, err := GenerateFiles(types, opts)
+	if err != nil {
+		return err
+	}
+	return WriteFiles(files)
+}
+
+// GenerateFiles generates Equal, Diff, Merge and Clone functions for the
+// provided types, as Generate does, and returns their files instead of
+// writing them. Types must not be nil, as reflect.TypeOf returns for variables
+// of interface types.
+func GenerateFiles(types []reflect.Type, opts Options) ([]GeneratedFile, error) {
 	roots := []*data.TypeNode{}
+	// Parse all types into TypeNode trees using reflection
+	for i, typ := range types {
+		if typ == nil {
+			return nil, fmt.Errorf("type %d is nil, interface types cannot be generated", i)
+		}
+		root := &data.TypeNode{}
+		roots = append(roots, root)
+		parser.Parse(root, typ, typ.PkgPath(), map[string]struct{}{})
+	}
+	// Values of interfaces are compared with the provided types implementing them
+	parser.DiscoverImplementations(roots, types)
+	return generate(roots, opts)
+}
+
+// GenerateFromGoTypes generates Equal, Diff, Merge and Clone functions for the
+// provided types, as Generate does, from their go/types information. Types
+// are typically looked up in packages loaded with golang.org/x/tools/go/packages,
+// which does not require building and running a program holding the types.
+func GenerateFromGoTypes(typs []types.Type, opts Options) error {
+	files, err := GenerateFilesFromGoTypes(typs, opts)
+	if err != nil {
+		return err
+	}
+	return WriteFiles(files)
+}
+
+// GenerateFilesFromGoTypes generates the functions of the provided types from
+// their go/types information, as GenerateFromGoTypes does, and returns their
+// files instead of writing them.
+func GenerateFilesFromGoTypes(typs []types.Type, opts Options) ([]GeneratedFile, error) {
+	roots := []*data.TypeNode{}
+	for _, typ := range typs {
+		root := &data.TypeNode{}
+		roots = append(roots, root)
+		var pkgPath string
+		if named, ok := types.Unalias(typ).(*types.Named); ok && named.Obj().Pkg() != nil {
+			pkgPath = named.Obj().Pkg().Path()
+		}
+		parser.ParseGoType(root, typ, pkgPath, map[string]struct{}{})
+	}
+	// Values of interfaces are compared with the provided types implementing them
+	parser.DiscoverGoTypeImplementations(roots, typs)
+	return generate(roots, opts)
+}
+
+// WriteFiles writes generated files, creating their directories. Existing files
+// are only overwritten if they were generated by go-method-gen: no file is
+// written if any of them was not.
+func WriteFiles(files []GeneratedFile) error {
+	for _, file := range files {
+		if err := checkOverwrite(file.Path); err != nil {
+			return err
+		}
+	}
+	for _, file := range files {
+		err := os.MkdirAll(filepath.Dir(file.Path), 0o755)
+		if err != nil {
+			return err
+		}
+		err = os.WriteFile(file.Path, file.Source, 0o644)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// generate returns the files of the functions of the parsed roots.
+func generate(roots []*data.TypeNode, opts Options) ([]GeneratedFile, error) {
 	dir := opts.OutputDir
 	var overrides map[string]common.OverrideFuncs
 	var headerContent string
 
+	for _, method := range opts.Methods {
+		if err := checkMethod(method); err != nil {
+			return nil, err
+		}
+	}
+	// Map diffs hold no operation, they cannot be replayed exactly
+	if opts.generates(MethodApplyDiff) && !opts.TypedDiff {
+		return nil, fmt.Errorf("method %s requires typed diffs", MethodApplyDiff)
+	}
+
 	// Read optional header content
 	if opts.HeaderPath != "" {
 		data, err := os.ReadFile(opts.HeaderPath)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		for _, line := range strings.Split(string(data), "\n") {
 			line = strings.TrimSpace(line)
@@ -62,28 +239,79 @@ func Generate(types []reflect.Type, opts Options) error {
 	if opts.OverridesFile != "" {
 		data, err := os.ReadFile(opts.OverridesFile)
 		if err != nil {
-			return fmt.Errorf("failed to read overrides file: %w", err)
+			return nil, fmt.Errorf("failed to read overrides file: %w", err)
 		}
 		err = yaml.Unmarshal(data, &overrides)
 		if err != nil {
-			return fmt.Errorf("failed to parse overrides YAML: %w", err)
+			return nil, fmt.Errorf("failed to parse overrides YAML: %w", err)
 		}
 	}
-	// Parse all types into TypeNode trees using reflection
-	for _, typ := range types {
-		root := &data.TypeNode{}
-		roots = append(roots, root)
-		parser.Parse(root, typ, typ.PkgPath(), map[string]struct{}{})
-	}
 
 	// Track functions already generated by package/baseDir to avoid duplicates
-	funcsByPkg := map[string]map[string]struct{}{}              // baseDir -> funcName
-	setEqualsFuncsByBaseDir := map[string]map[string]struct{}{} // baseDir -> Equal funcs
-	setDiffsFuncsByBaseDir := map[string]map[string]struct{}{}  // baseDir -> Diff funcs
+	funcsByPkg := map[string]map[string]struct{}{}                    // baseDir -> funcName
+	setEqualsFuncsByBaseDir := map[string]map[string]struct{}{}       // baseDir -> Equal funcs
+	setDiffsFuncsByBaseDir := map[string]map[string]struct{}{}        // baseDir -> Diff funcs
+	setMergesFuncsByBaseDir := map[string]map[string]struct{}{}       // baseDir -> Merge funcs
+	setClonesFuncsByBaseDir := map[string]map[string]struct{}{}       // baseDir -> Clone funcs
+	setMergePatchesFuncsByBaseDir := map[string]map[string]struct{}{} // baseDir -> MergePatch funcs
+	setApplyDiffsFuncsByBaseDir := map[string]map[string]struct{}{}   // baseDir -> ApplyDiff funcs
+	setMerge3sFuncsByBaseDir := map[string]map[string]struct{}{}      // baseDir -> Merge3 funcs
+	setHashesFuncsByBaseDir := map[string]map[string]struct{}{}       // baseDir -> Hash funcs
+	setComparesFuncsByBaseDir := map[string]map[string]struct{}{}     // baseDir -> Compare funcs
+	files := &generatedFiles{indexes: map[string]int{}}
+	// With InPackage, files are written for the output directory "" and then
+	// moved to the directories of their packages
+	write := func(contents map[string]map[string]string, prefix string, setFuncsByBaseDir map[string]map[string]struct{}) error {
+		return writeContents(files, contents, prefix, headerContent, funcsByPkg, setFuncsByBaseDir)
+	}
+	var pkgDirs *packageDirs
+	if opts.InPackage {
+		dir = ""
+		pkgDirs = newPackageDirs(opts.PackagesDir)
+		write = func(contents map[string]map[string]string, prefix string, setFuncsByBaseDir map[string]map[string]struct{}) error {
+			relocated, err := pkgDirs.relocate(contents)
+			if err != nil {
+				return err
+			}
+			return writeContents(files, relocated, prefix, headerContent, funcsByPkg, setFuncsByBaseDir)
+		}
+	}
+	// Helper functions shared by several roots are written once, with the
+	// first root using them: roots are sorted for the files not to depend on
+	// the order types are given in.
+	roots = slices.Clone(roots)
+	slices.SortStableFunc(roots, func(a, b *data.TypeNode) int {
+		return strings.Compare(a.PkgPath+"."+a.Type, b.PkgPath+"."+b.Type)
+	})
+	report := Report{}
+	reported := map[string]struct{}{}
+	for _, root := range roots {
+		parser.ApplyDiffNameTag(root, opts.DiffNameTag)
+		// Resolve the field paths targeted by field overrides
+		common.ResolveFieldPaths(root, overrides)
+		if err := checkFieldTags(root); err != nil {
+			return nil, err
+		}
+		if err := checkSliceKeys(root, opts.DiffNameTag); err != nil {
+			return nil, err
+		}
+		if pkgDirs != nil {
+			if err := pkgDirs.localize(root); err != nil {
+				return nil, err
+			}
+		}
+		reportSkippedFields(&report, root, overrides, reported)
+	}
+	if opts.Report != nil {
+		*opts.Report = report
+	}
+	if opts.Strict && len(report.Skipped) > 0 {
+		return nil, &SkippedFieldsError{Report: report}
+	}
 	for _, root := range roots {
 		// Generate Equal functions if not already present
 		ctx := &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
-		if !root.HasEqual {
+		if !root.HasEqual && opts.generates(MethodEqual) {
 			equal.Generate(root, ctx, equal.EqualCtx{
 				Overrides: overrides,
 			})
@@ -91,181 +319,325 @@ func Generate(types []reflect.Type, opts Options) error {
 		if len(ctx.SubCtxs) == 1 {
 			contents := map[string]map[string]string{} // file -> func -> implementation
 			writer.WriteEqualFiles(dir, "", contents, *ctx.SubCtxs[0])
-			sortedFiles := make([]string, 0, len(contents))
-			for file := range contents {
-				sortedFiles = append(sortedFiles, file)
-			}
-			slices.Sort(sortedFiles)
-			// Deduplicate functions per baseDir
-			for _, file := range sortedFiles {
-				funcs := contents[file]
-				for _, funName := range funcs {
-					if funName == "Equal" || !strings.HasPrefix(funName, "Equal") {
-						continue
-					}
-					basedirContent := filepath.Dir(file)
-					equalsfuncs := setEqualsFuncsByBaseDir[basedirContent]
-					if equalsfuncs == nil {
-						equalsfuncs = map[string]struct{}{}
-						setEqualsFuncsByBaseDir[basedirContent] = equalsfuncs
-
-					}
-					if _, exists := equalsfuncs[funName]; exists {
-						delete(funcs, funName)
-					}
-					equalsfuncs[funName] = struct{}{}
-				}
-			}
-			// Write files
-			for _, file := range sortedFiles {
-				funcs := contents[file]
-				baseDir := filepath.Dir(file)
-				pkgfuncs, pkgExists := funcsByPkg[baseDir]
-				if !pkgExists {
-					pkgfuncs = map[string]struct{}{}
-					funcsByPkg[baseDir] = pkgfuncs
-				}
-
-				var sb bytes.Buffer
-				err := os.MkdirAll(baseDir, 0o755)
-				if err != nil {
-					return err
-				}
-				// Header, package, imports
-				sb.WriteString("\n// Code generated by go-method-gen. DO NOT EDIT.\n\n")
-				sb.WriteString(headerContent + "\n")
-				pkg := funcs["Package"]
-				sb.WriteString(pkg + "\n")
-				delete(funcs, "Package")
-				imports := funcs["Imports"]
-				sb.WriteString(imports + "\n")
-				delete(funcs, "Imports")
-				// Append functions that were not already written
-				var hasFunc bool
-				sortedFuncs := make([]string, 0, len(funcs))
-				for funName := range funcs {
-					sortedFuncs = append(sortedFuncs, funName)
-				}
-				slices.Sort(sortedFuncs)
-				for _, sortedFun := range sortedFuncs {
-					fun := funcs[sortedFun]
-					if _, funExists := pkgfuncs[fun]; funExists {
-						continue
-					}
-					hasFunc = true
-					pkgfuncs[fun] = struct{}{}
-					sb.WriteString(fun + "\n")
-				}
-				// Format and write source
-				if hasFunc {
-					errWriting := writeFormattedFile(sb.Bytes(), file)
-					if errWriting != nil {
-						return errWriting
-					}
-				}
+			err := write(contents, "Equal", setEqualsFuncsByBaseDir)
+			if err != nil {
+				return nil, err
 			}
 		}
 
 		// Generate Diff functions if not already present
 		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
-		if !root.HasDiff {
+		hasDiff := root.HasDiff
+		if opts.TypedDiff {
+			hasDiff = root.HasTypedDiff
+		}
+		if !hasDiff && opts.generates(MethodDiff) {
 			diff.Generate(root, ctx, diff.DiffCtx{
 				Overrides: overrides,
+				Typed:     opts.TypedDiff,
+			})
+		}
+		if len(ctx.SubCtxs) == 1 {
+			contents := map[string]map[string]string{} // file -> func -> implementation
+			writer.WriteDiffFiles(dir, "", contents, *ctx.SubCtxs[0], opts.TypedDiff)
+			err := write(contents, "Diff", setDiffsFuncsByBaseDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate Merge functions if not already present
+		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
+		if !root.HasMerge && opts.generates(MethodMerge) {
+			merge.Generate(root, ctx, merge.MergeCtx{
+				Overrides: overrides,
+			})
+		}
+		if len(ctx.SubCtxs) == 1 {
+			contents := map[string]map[string]string{} // file -> func -> implementation
+			writer.WriteMergeFiles(dir, "", contents, *ctx.SubCtxs[0])
+			err := write(contents, "Merge", setMergesFuncsByBaseDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate Clone functions if not already present
+		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "clone"}
+		if !root.HasClone && opts.generates(MethodClone) {
+			clone.Generate(root, ctx, clone.CloneCtx{
+				Overrides: overrides,
 			})
 		}
 		if len(ctx.SubCtxs) == 1 {
 			contents := map[string]map[string]string{} // file -> func -> implementation
-			writer.WriteDiffFiles(dir, "", contents, *ctx.SubCtxs[0])
-			var files []string
-			for file := range contents {
-				files = append(files, file)
+			writer.WriteCloneFiles(dir, "", contents, *ctx.SubCtxs[0])
+			err := write(contents, "Clone", setClonesFuncsByBaseDir)
+			if err != nil {
+				return nil, err
 			}
-			slices.Sort(files)
-
-			// Deduplicate Diff functions per baseDir
-			for _, file := range files {
-				funcs := contents[file]
-				for funName := range funcs {
-					if funName == "Diff" || !strings.HasPrefix(funName, "Diff") {
-						continue
-					}
-					basedirContent := filepath.Dir(file)
-					diffsfuncs := setDiffsFuncsByBaseDir[basedirContent]
-					if diffsfuncs == nil {
-						diffsfuncs = map[string]struct{}{}
-						setDiffsFuncsByBaseDir[basedirContent] = diffsfuncs
-
-					}
-					if _, exists := diffsfuncs[funName]; exists {
-						delete(funcs, funName)
-					}
-					diffsfuncs[funName] = struct{}{}
-				}
+		}
+
+		// Generate MergePatch functions if not already present, for types
+		// whose Equal functions are generated too
+		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
+		if !root.HasMergePatch && !root.HasEqual && opts.generates(MethodMergePatch) {
+			mergepatch.Generate(root, ctx, mergepatch.MergePatchCtx{
+				Overrides: overrides,
+			})
+		}
+		if len(ctx.SubCtxs) == 1 {
+			contents := map[string]map[string]string{} // file -> func -> implementation
+			writer.WriteMergePatchFiles(dir, "", contents, *ctx.SubCtxs[0])
+			err := write(contents, "MergePatch", setMergePatchesFuncsByBaseDir)
+			if err != nil {
+				return nil, err
 			}
+		}
 
-			// Write files
-			for _, file := range files {
-				funcs := contents[file]
-				sortedFuncs := make([]string, 0, len(funcs))
-				for funName := range funcs {
-					sortedFuncs = append(sortedFuncs, funName)
-				}
-				slices.Sort(sortedFuncs)
-				baseDir := filepath.Dir(file)
-				pkgfuncs, pkgExists := funcsByPkg[baseDir]
-				if !pkgExists {
-					pkgfuncs = map[string]struct{}{}
-					funcsByPkg[baseDir] = pkgfuncs
-				}
-
-				var sb bytes.Buffer
-				err := os.MkdirAll(baseDir, 0o755)
-				if err != nil {
-					return err
-				}
-				sb.WriteString("\n// Code generated by go-method-gen. DO NOT EDIT.\n\n")
-				sb.WriteString(headerContent + "\n")
-				pkg := funcs["Package"]
-				sb.WriteString(pkg + "\n")
-				delete(funcs, "Package")
-				imports := funcs["Imports"]
-				sb.WriteString(imports + "\n")
-				delete(funcs, "Imports")
-				var hasFunc bool
-				for _, sortedFun := range sortedFuncs {
-					fun := funcs[sortedFun]
-					if _, funExists := pkgfuncs[fun]; funExists {
-						continue
-					}
-					hasFunc = true
-					pkgfuncs[fun] = struct{}{}
-					sb.WriteString(fun + "\n")
-				}
-				if hasFunc {
-					writeFormattedFile(sb.Bytes(), file)
-				}
+		// Generate ApplyDiff functions if not already present, for types
+		// whose Diff functions are generated too
+		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
+		if !root.HasTypedApplyDiff && !hasDiff && opts.generates(MethodApplyDiff) {
+			applydiff.Generate(root, ctx, applydiff.ApplyDiffCtx{Overrides: overrides})
+		}
+		if len(ctx.SubCtxs) == 1 {
+			contents := map[string]map[string]string{} // file -> func -> implementation
+			writer.WriteApplyDiffFiles(dir, "", contents, *ctx.SubCtxs[0])
+			err := write(contents, "ApplyDiff", setApplyDiffsFuncsByBaseDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate Merge3 functions if not already present, for types whose
+		// Equal functions are generated too
+		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
+		if !root.HasMerge3 && !root.HasEqual && opts.generates(MethodMerge3) {
+			merge3.Generate(root, ctx, merge3.Merge3Ctx{
+				Overrides: overrides,
+			})
+		}
+		if len(ctx.SubCtxs) == 1 {
+			contents := map[string]map[string]string{} // file -> func -> implementation
+			writer.WriteMerge3Files(dir, "", contents, *ctx.SubCtxs[0])
+			err := write(contents, "Merge3", setMerge3sFuncsByBaseDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate Hash functions if not already present, for types without
+		// a hand-written Equal method it could not be consistent with
+		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
+		if !root.HasHash && !root.HasEqual && opts.generates(MethodHash) {
+			hash.Generate(root, ctx, hash.HashCtx{
+				Overrides: overrides,
+			})
+		}
+		if len(ctx.SubCtxs) == 1 {
+			contents := map[string]map[string]string{} // file -> func -> implementation
+			writer.WriteHashFiles(dir, "", contents, *ctx.SubCtxs[0])
+			err := write(contents, "Hash", setHashesFuncsByBaseDir)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Generate Compare functions if not already present, for types
+		// without a hand-written Equal method they could not be consistent with
+		ctx = &data.Ctx{LeftSideComparison: "rec", RightSideComparison: "obj"}
+		if !root.HasCompare && !root.HasEqual && opts.generates(MethodCompare) {
+			compare.Generate(root, ctx, compare.CompareCtx{
+				Overrides: overrides,
+			})
+		}
+		if len(ctx.SubCtxs) == 1 {
+			contents := map[string]map[string]string{} // file -> func -> implementation
+			writer.WriteCompareFiles(dir, "", contents, *ctx.SubCtxs[0])
+			err := write(contents, "Compare", setComparesFuncsByBaseDir)
+			if err != nil {
+				return nil, err
 			}
 		}
 	}
-	return nil
+	return files.files, nil
 }
 
-// writeFormattedFile takes a byte slice of Go source code, formats it
-// with go/imports, and writes the formatted code to the file at the
-// given path. If an error occurs, it prints the error to stderr and
-// returns the error.
+// checkFieldTags returns the error of the first field of the tree rooted at
+// node whose gmg struct tag is invalid, e.g. has an unknown option.
+func checkFieldTags(node *data.TypeNode) error {
+	if node == nil {
+		return nil
+	}
+	if node.TagError != "" {
+		path := node.FieldPath
+		if path == "" {
+			path = node.Name
+		}
+		return fmt.Errorf("%s: %s", path, node.TagError)
+	}
+	for _, field := range node.Fields {
+		if err := checkFieldTags(field); err != nil {
+			return err
+		}
+	}
+	return checkFieldTags(node.SubNode)
+}
+
+// checkSliceKeys returns an error if the elements of a slice of the tree
+// rooted at node are matched by a key field they do not have, or which cannot
+// be compared: the generated functions would not compile. Key fields which are
+// pointers are marked, for elements to be matched by the values they point to,
+// and key fields are named in diff keys as other fields are, after the struct
+// tag diffNameTag.
+func checkSliceKeys(node *data.TypeNode, diffNameTag string) error {
+	if node == nil {
+		return nil
+	}
+	if node.Kind == data.Slice && node.SliceKey != "" {
+		path := node.FieldPath
+		if path == "" {
+			path = node.Name
+		}
+		elem := node.SubNode
+		if elem != nil && elem.Kind == data.Pointer {
+			elem = elem.SubNode
+		}
+		if elem == nil || elem.Kind != data.Struct {
+			return fmt.Errorf("%s: key=%s requires a slice of structs", path, node.SliceKey)
+		}
+		keyField, found := elem.KeyFields[node.SliceKey]
+		switch {
+		case !found:
+			return fmt.Errorf("%s: key=%s: %s has no field %s", path, node.SliceKey, elem.PackagedType, node.SliceKey)
+		case !keyField.Comparable:
+			return fmt.Errorf("%s: key=%s: field %s of %s is not comparable", path, node.SliceKey, node.SliceKey, elem.PackagedType)
+		}
+		node.SliceKeyPointer = keyField.Pointer
+		node.SliceKeyName = parser.FieldDiffName(node.SliceKey, reflect.StructTag(keyField.Tag), diffNameTag)
+	}
+	for _, field := range node.Fields {
+		if err := checkSliceKeys(field, diffNameTag); err != nil {
+			return err
+		}
+	}
+	return checkSliceKeys(node.SubNode, diffNameTag)
+}
+
+// generatedFiles collects the files produced by the writer passes, in the
+// order they are produced.
+type generatedFiles struct {
+	files   []GeneratedFile
+	indexes map[string]int // Path -> index in files
+}
+
+// add adds a file, replacing the file produced earlier at the same path if any.
+func (g *generatedFiles) add(file GeneratedFile) {
+	if i, found := g.indexes[file.Path]; found {
+		g.files[i] = file
+		return
+	}
+	g.indexes[file.Path] = len(g.files)
+	g.files = append(g.files, file)
+}
 
-func writeFormattedFile(contents []byte, file string) error {
+// writeContents adds to files the files produced by one writer pass (file ->
+// func -> implementation). Helper functions whose name starts with prefix are
+// written only once per output directory, and functions already written to a
+// package (tracked in funcsByPkg) are skipped. Files left without any function
+// are not added.
+func writeContents(files *generatedFiles, contents map[string]map[string]string, prefix, headerContent string,
+	funcsByPkg, setFuncsByBaseDir map[string]map[string]struct{},
+) error {
+	sortedFiles := make([]string, 0, len(contents))
+	for file := range contents {
+		sortedFiles = append(sortedFiles, file)
+	}
+	slices.Sort(sortedFiles)
+
+	// Deduplicate helper functions per baseDir
+	for _, file := range sortedFiles {
+		funcs := contents[file]
+		for funName := range funcs {
+			if funName == prefix || !strings.HasPrefix(funName, prefix) {
+				continue
+			}
+			basedirContent := filepath.Dir(file)
+			setFuncs := setFuncsByBaseDir[basedirContent]
+			if setFuncs == nil {
+				setFuncs = map[string]struct{}{}
+				setFuncsByBaseDir[basedirContent] = setFuncs
+			}
+			if _, exists := setFuncs[funName]; exists {
+				delete(funcs, funName)
+			}
+			setFuncs[funName] = struct{}{}
+		}
+	}
+
+	// Build files
+	for _, file := range sortedFiles {
+		funcs := contents[file]
+		baseDir := filepath.Dir(file)
+		pkgfuncs, pkgExists := funcsByPkg[baseDir]
+		if !pkgExists {
+			pkgfuncs = map[string]struct{}{}
+			funcsByPkg[baseDir] = pkgfuncs
+		}
+
+		var sb bytes.Buffer
+		// Header, package, imports
+		sb.WriteString("\n" + GeneratedComment + "\n\n")
+		sb.WriteString(headerContent + "\n")
+		pkg := funcs["Package"]
+		sb.WriteString(pkg + "\n")
+		delete(funcs, "Package")
+		imports := funcs["Imports"]
+		sb.WriteString(imports + "\n")
+		delete(funcs, "Imports")
+		// Append functions that were not already written
+		var hasFunc bool
+		sortedFuncs := make([]string, 0, len(funcs))
+		for funName := range funcs {
+			sortedFuncs = append(sortedFuncs, funName)
+		}
+		slices.Sort(sortedFuncs)
+		for _, sortedFun := range sortedFuncs {
+			fun := funcs[sortedFun]
+			if _, funExists := pkgfuncs[fun]; funExists {
+				continue
+			}
+			hasFunc = true
+			pkgfuncs[fun] = struct{}{}
+			sb.WriteString(fun + "\n")
+		}
+		// Format source
+		if hasFunc {
+			source, errFormat := formatSource(sb.Bytes(), file)
+			if errFormat != nil {
+				return errFormat
+			}
+			files.add(GeneratedFile{
+				Path:    file,
+				Package: strings.TrimPrefix(pkg, "package "),
+				Source:  source,
+			})
+		}
+	}
+	return nil
+}
+
+// formatSource takes a byte slice of Go source code for the given file path,
+// fixes its imports with go/imports and formats it with gofumpt. If an error
+// occurs, it prints the error and the source to stdout and returns the error.
+func formatSource(contents []byte, file string) ([]byte, error) {
 	// Use x/tools/imports to format code and fix imports
 	formattedCode, errFormat := imp.Process("", contents, nil)
 	if errFormat != nil {
 		fmt.Printf("file: %s, err: %s\n", file, errFormat.Error())
 		fmt.Println(string(contents))
-		return errFormat
-	}
-	final, err := format.Source(formattedCode, format.Options{})
-	if err != nil {
-		return err
+		return nil, errFormat
 	}
-	return os.WriteFile(file, final, 0o644)
+	return format.Source(formattedCode, format.Options{})
 }
@@ -0,0 +1,440 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+// testPkgPath is the import path of the packages generated by the tests.
+const testPkgPath = "example.com/models"
+
+// generateFromSource type-checks src as the package testPkgPath and returns
+// the files generated for its types typeNames with opts. Overrides, if any,
+// are written to a temporary file.
+func generateFromSource(t *testing.T, src, overrides string, typeNames []string, opts eqdiff.Options) []eqdiff.GeneratedFile {
+	t.Helper()
+	fset := token.NewFileSet()
+	pkg, _ := checkPackage(t, fset, map[string]string{"models.go": src})
+	if overrides != "" {
+		opts.OverridesFile = filepath.Join(t.TempDir(), "overrides.yaml")
+		if err := os.WriteFile(opts.OverridesFile, []byte(overrides), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var typs []types.Type
+	for _, name := range typeNames {
+		obj := pkg.Scope().Lookup(name)
+		if obj == nil {
+			t.Fatalf("type %s not found", name)
+		}
+		typs = append(typs, obj.Type())
+	}
+	files, err := eqdiff.GenerateFilesFromGoTypes(typs, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// checkGenerated type-checks the generated files with the source of their
+// package.
+func checkGenerated(t *testing.T, src string, files []eqdiff.GeneratedFile) {
+	t.Helper()
+	sources := map[string]string{"models.go": src}
+	for _, file := range files {
+		sources[filepath.Base(file.Path)] = string(file.Source)
+	}
+	checkPackage(t, token.NewFileSet(), sources)
+}
+
+// checkPackage parses and type-checks the files of the package testPkgPath.
+func checkPackage(t *testing.T, fset *token.FileSet, sources map[string]string) (*types.Package, []*ast.File) {
+	t.Helper()
+	var files []*ast.File
+	for name, src := range sources {
+		file, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, file)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(testPkgPath, fset, files, nil)
+	if err != nil {
+		t.Fatalf("%v\n%s", err, dumpSources(sources))
+	}
+	return pkg, files
+}
+
+// dumpSources returns the sources of a package, for failure messages.
+func dumpSources(sources map[string]string) string {
+	var dump strings.Builder
+	for name, src := range sources {
+		dump.WriteString("// " + name + "\n" + src + "\n")
+	}
+	return dump.String()
+}
+
+// hasFile reports whether a file named name was generated.
+func hasFile(files []eqdiff.GeneratedFile, name string) bool {
+	for _, file := range files {
+		if filepath.Base(file.Path) == name {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGenerateFieldPathOverrideOfSharedType(t *testing.T) {
+	// Bind is first met in the overridden path with F, or outside of it with B
+	for _, fields := range []string{"F Frontend\n\tB Bind", "B Bind\n\tF Frontend"} {
+		src := `package models
+
+type Bind struct {
+	Name string
+	Port int
+}
+
+type Frontend struct {
+	Binds []Bind
+}
+
+type Root struct {
+	` + fields + `
+}
+`
+		overrides := testPkgPath + ".Frontend.Binds[*].Name:\n  ignore: true\n"
+		files := generateFromSource(t, src, overrides, []string{"Root"}, eqdiff.Options{})
+		for _, name := range []string{"bind_equal_generated.go", "bind_diff_generated.go", "bind_merge_generated.go", "bind_clone_generated.go"} {
+			if !hasFile(files, name) {
+				t.Errorf("%s: %s not generated", strings.ReplaceAll(fields, "\n\t", ", "), name)
+			}
+		}
+		checkGenerated(t, src, files)
+	}
+}
+
+func TestGenerateFilesNilType(t *testing.T) {
+	var shape interface{ Area() float64 }
+	_, err := eqdiff.GenerateFiles([]reflect.Type{reflect.TypeOf(shape)}, eqdiff.Options{})
+	if err == nil {
+		t.Fatal("expected an error for a nil type")
+	}
+}
+
+func TestGenerateApplyDiffRequiresTypedDiff(t *testing.T) {
+	src := `package models
+
+type Frontend struct {
+	Labels map[string]string
+}
+`
+	fset := token.NewFileSet()
+	pkg, _ := checkPackage(t, fset, map[string]string{"models.go": src})
+	typs := []types.Type{pkg.Scope().Lookup("Frontend").Type()}
+	_, err := eqdiff.GenerateFilesFromGoTypes(typs, eqdiff.Options{Methods: []string{eqdiff.MethodApplyDiff}})
+	if err == nil {
+		t.Fatal("expected an error for ApplyDiff without typed diffs")
+	}
+	files := generateFromSource(t, src, "", []string{"Frontend"},
+		eqdiff.Options{TypedDiff: true, Methods: []string{eqdiff.MethodDiff, eqdiff.MethodApplyDiff}})
+	if !hasFile(files, "frontend_applydiff_generated.go") {
+		t.Error("frontend_applydiff_generated.go not generated")
+	}
+	checkGenerated(t, src, files)
+}
+
+type keyedServer struct {
+	Name *string
+}
+
+type keyedFrontend struct {
+	Servers []keyedServer `gmg:"key=Missing"`
+}
+
+func TestGenerateSliceKeyErrors(t *testing.T) {
+	_, err := eqdiff.GenerateFiles([]reflect.Type{reflect.TypeOf(keyedFrontend{})}, eqdiff.Options{})
+	if err == nil {
+		t.Error("expected an error for a missing key field of a reflect type")
+	}
+	tests := []struct {
+		name   string
+		fields string
+	}{
+		{name: "missing key field", fields: "Servers []Server `gmg:\"key=Missing\"`"},
+		{name: "key field not comparable", fields: "Servers []Server `gmg:\"key=Tags\"`"},
+		{name: "key field pointing to values not comparable", fields: "Servers []*Server `gmg:\"key=Aliases\"`"},
+		{name: "elements without fields", fields: "Names []string `gmg:\"key=Name\"`"},
+	}
+	for _, test := range tests {
+		src := `package models
+
+type Server struct {
+	Name    string
+	Tags    []string
+	Aliases *[]string
+}
+
+type Frontend struct {
+	` + test.fields + `
+}
+`
+		fset := token.NewFileSet()
+		pkg, _ := checkPackage(t, fset, map[string]string{"models.go": src})
+		typs := []types.Type{pkg.Scope().Lookup("Frontend").Type()}
+		if _, err := eqdiff.GenerateFilesFromGoTypes(typs, eqdiff.Options{}); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+type taggedFrontend struct {
+	Servers []keyedServer `gmg:"sett"`
+}
+
+func TestGenerateFieldTagErrors(t *testing.T) {
+	_, err := eqdiff.GenerateFiles([]reflect.Type{reflect.TypeOf(taggedFrontend{})}, eqdiff.Options{})
+	if err == nil || !strings.Contains(err.Error(), `unknown gmg option "sett"`) {
+		t.Errorf("expected an error for an unknown option of a reflect type, got %v", err)
+	}
+	tests := []struct {
+		name string
+		tag  string
+		want string
+	}{
+		{name: "unknown option", tag: "set,lsc", want: `unknown gmg option "lsc"`},
+		{name: "key without a field", tag: "key", want: "gmg option key requires a value"},
+		{name: "name without a value", tag: "name=", want: "gmg option name requires a value"},
+		{name: "flag with a value", tag: "set=true", want: "gmg option set takes no value"},
+	}
+	for _, test := range tests {
+		src := `package models
+
+type Server struct {
+	Name string
+}
+
+type Frontend struct {
+	Servers []Server ` + "`gmg:\"" + test.tag + "\"`" + `
+}
+`
+		fset := token.NewFileSet()
+		pkg, _ := checkPackage(t, fset, map[string]string{"models.go": src})
+		typs := []types.Type{pkg.Scope().Lookup("Frontend").Type()}
+		_, err := eqdiff.GenerateFilesFromGoTypes(typs, eqdiff.Options{})
+		if err == nil || !strings.Contains(err.Error(), test.want) || !strings.Contains(err.Error(), "Servers") {
+			t.Errorf("%s: got error %v, want an error about Servers containing %q", test.name, err, test.want)
+		}
+	}
+}
+
+func TestGenerateSliceKeyPromotedPointer(t *testing.T) {
+	src := `package models
+
+type Meta struct {
+	Name *string
+}
+
+type Server struct {
+	Meta
+	Port int
+}
+
+type Frontend struct {
+	Servers []Server ` + "`gmg:\"key=Name\"`" + `
+}
+`
+	files := generateFromSource(t, src, "", []string{"Frontend"}, eqdiff.Options{
+		TypedDiff: true,
+		Methods: []string{
+			eqdiff.MethodEqual, eqdiff.MethodDiff, eqdiff.MethodApplyDiff, eqdiff.MethodMerge3,
+			eqdiff.MethodHash, eqdiff.MethodCompare,
+		},
+	})
+	for _, file := range files {
+		if strings.Contains(string(file.Source), "return v.Name\n") || strings.Contains(string(file.Source), "fmt.Sprint(v.Name)") {
+			t.Errorf("%s: elements matched by the address of their key:\n%s", file.Path, file.Source)
+		}
+	}
+	checkGenerated(t, src, files)
+}
+
+func TestGenerateSliceKeyDiffNames(t *testing.T) {
+	src := `package models
+
+type Meta struct {
+	Name string ` + "`json:\"server_name\"`" + `
+}
+
+type Server struct {
+	Meta
+	ID   int ` + "`json:\"id\" gmg:\"name=uid\"`" + `
+	Port int
+}
+
+type Frontend struct {
+	Servers []Server ` + "`json:\"servers\" gmg:\"key=Name\"`" + `
+	Backups []Server ` + "`json:\"backups\" gmg:\"key=ID\"`" + `
+}
+`
+	files := generateFromSource(t, src, "", []string{"Frontend"}, eqdiff.Options{
+		DiffNameTag: "json",
+		TypedDiff:   true,
+		Methods:     []string{eqdiff.MethodEqual, eqdiff.MethodDiff, eqdiff.MethodMerge3},
+	})
+	var sources strings.Builder
+	for _, file := range files {
+		sources.Write(file.Source)
+	}
+	// Key fields are named in diffs and conflicts as other fields are
+	for _, want := range []string{`"[server_name=%v]"`, `"server_name", func(`, `"[uid=%v]"`, `"uid", func(`} {
+		if !strings.Contains(sources.String(), want) {
+			t.Errorf("no %s in the generated files:\n%s", want, sources.String())
+		}
+	}
+	checkGenerated(t, src, files)
+}
+
+func TestGenerateJSONNamesUntyped(t *testing.T) {
+	src := `package models
+
+type Addr struct {
+	Host string ` + "`json:\"host\"`" + `
+}
+
+type Listener struct {
+	*Addr
+	Secret string ` + "`json:\"-\"`" + `
+	Cert   []byte ` + "`json:\"cert\"`" + `
+}
+`
+	files := generateFromSource(t, src, "", []string{"Listener"}, eqdiff.Options{DiffNameTag: "json"})
+	for _, file := range files {
+		if filepath.Base(file.Path) == "listener_diff_generated.go" && strings.Contains(string(file.Source), "Secret") {
+			t.Errorf("%s: Secret diffed:\n%s", file.Path, file.Source)
+		}
+	}
+	checkGenerated(t, src, files)
+}
+
+// opaqueSrc holds values of a struct of another package with no exported
+// field, which are handled as a whole.
+const opaqueSrc = `package models
+
+import "time"
+
+type Record struct {
+	When   time.Time
+	Times  []time.Time
+	ByName map[string]time.Time
+	Last   *time.Time
+}
+`
+
+func TestGenerateOpaqueStructs(t *testing.T) {
+	files := generateFromSource(t, opaqueSrc, "", []string{"Record"}, eqdiff.Options{
+		TypedDiff: true,
+		Methods: []string{
+			eqdiff.MethodEqual, eqdiff.MethodDiff, eqdiff.MethodMerge, eqdiff.MethodClone, eqdiff.MethodApplyDiff,
+			eqdiff.MethodMerge3, eqdiff.MethodHash, eqdiff.MethodCompare, eqdiff.MethodMergePatch,
+		},
+	})
+	for _, file := range files {
+		for _, method := range []string{".Clone()", ".Diff(", ".Merge(", ".ApplyDiff("} {
+			if strings.Contains(string(file.Source), method) {
+				t.Errorf("%s: %s method of time.Time called:\n%s", file.Path, method, file.Source)
+			}
+		}
+	}
+	checkGenerated(t, opaqueSrc, files)
+}
+
+func TestGenerateMethods(t *testing.T) {
+	src := `package models
+
+type Server struct {
+	Name string
+	Port int
+}
+
+type Frontend struct {
+	Name    string
+	Servers []Server
+	Labels  map[string]string
+}
+`
+	tests := []struct {
+		methods []string
+		kinds   []string
+	}{
+		{methods: nil, kinds: []string{"equal", "diff", "merge", "clone"}},
+		{methods: []string{eqdiff.MethodEqual}, kinds: []string{"equal"}},
+		{methods: []string{eqdiff.MethodDiff}, kinds: []string{"diff"}},
+		{methods: []string{eqdiff.MethodClone, eqdiff.MethodHash}, kinds: []string{"clone", "hash"}},
+		// MergePatch and Merge3 functions call the Equal ones
+		{methods: []string{eqdiff.MethodMergePatch}, kinds: []string{"equal", "mergepatch"}},
+		{methods: []string{eqdiff.MethodMerge3}, kinds: []string{"equal", "merge3"}},
+	}
+	for _, test := range tests {
+		files := generateFromSource(t, src, "", []string{"Frontend"}, eqdiff.Options{Methods: test.methods})
+		var want []string
+		for _, typeName := range []string{"frontend", "server"} {
+			for _, kind := range test.kinds {
+				want = append(want, typeName+"_"+kind+"_generated.go")
+			}
+		}
+		var got []string
+		for _, file := range files {
+			got = append(got, filepath.Base(file.Path))
+			// Equal functions only compare values
+			if strings.HasSuffix(file.Path, "_equal_generated.go") && strings.Contains(string(file.Source), `"fmt"`) {
+				t.Errorf("%v: %s imports fmt:\n%s", test.methods, file.Path, file.Source)
+			}
+		}
+		slices.Sort(want)
+		slices.Sort(got)
+		if !slices.Equal(got, want) {
+			t.Errorf("%v: generated %v, want %v", test.methods, got, want)
+		}
+		checkGenerated(t, src, files)
+	}
+}
+
+func TestParseMethods(t *testing.T) {
+	methods, err := eqdiff.ParseMethods(" Equal, diff,,equal")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{eqdiff.MethodEqual, eqdiff.MethodDiff}; !slices.Equal(methods, want) {
+		t.Errorf("ParseMethods() = %v, want %v", methods, want)
+	}
+	for _, list := range []string{"", ",", "equal,unknown"} {
+		if _, err := eqdiff.ParseMethods(list); err == nil {
+			t.Errorf("ParseMethods(%q): expected an error", list)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import (
+	"flag"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// goldenDir is the directory of the golden package, whose generated files are
+// the golden files.
+const goldenDir = "internal/golden"
+
+func TestGenerateGolden(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.Join(goldenDir, "golden.go"), nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the types are loaded, generated files would declare their methods
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check("github.com/haproxytech/go-method-gen/pkg/eqdiff/internal/golden", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var typs []types.Type
+	for _, name := range []string{"Frontend", "Box", "Pool", "Listener", "Route", "Probe"} {
+		typs = append(typs, pkg.Scope().Lookup(name).Type())
+	}
+	files, err := eqdiff.GenerateFilesFromGoTypes(typs, eqdiff.Options{
+		OutputDir:   t.TempDir(),
+		HeaderPath:  "../../assets/license-header.txt",
+		TypedDiff:   true,
+		DiffNameTag: "json",
+		Methods: []string{
+			eqdiff.MethodEqual, eqdiff.MethodDiff, eqdiff.MethodMerge, eqdiff.MethodClone, eqdiff.MethodApplyDiff,
+			eqdiff.MethodMerge3, eqdiff.MethodCompare, eqdiff.MethodMergePatch, eqdiff.MethodHash,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generated := map[string]bool{}
+	for _, file := range files {
+		name := filepath.Base(file.Path)
+		generated[name] = true
+		golden := filepath.Join(goldenDir, name)
+		if *update {
+			if err := os.WriteFile(golden, file.Source, 0o644); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Errorf("%s: %v, run the test with -update", name, err)
+			continue
+		}
+		if string(want) != string(file.Source) {
+			t.Errorf("%s differs from its golden file, run the test with -update\n%s", name, file.Source)
+		}
+	}
+	// Golden files of functions no longer generated are stale
+	entries, err := os.ReadDir(goldenDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, "_generated.go") || generated[name] {
+			continue
+		}
+		if *update {
+			if err := os.Remove(filepath.Join(goldenDir, name)); err != nil {
+				t.Fatal(err)
+			}
+			continue
+		}
+		t.Errorf("%s is not generated, run the test with -update", name)
+	}
+}
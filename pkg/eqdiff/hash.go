@@ -0,0 +1,219 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import (
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"reflect"
+)
+
+// Hasher is implemented by values writing their content to a hash, such as
+// the ones having a generated Hash method: equal values write the same data.
+type Hasher interface {
+	Hash(h hash.Hash64)
+}
+
+// Hash returns the 64-bit FNV-1a hash of a value, e.g. to use it as a cache
+// key: equal values have the same hash.
+func Hash(v Hasher) uint64 {
+	h := fnv.New64a()
+	v.Hash(h)
+	return h.Sum64()
+}
+
+// HashBool writes a boolean to h.
+func HashBool[T ~bool](h hash.Hash64, v T) {
+	if v {
+		h.Write([]byte{1})
+		return
+	}
+	h.Write([]byte{0})
+}
+
+// HashInt writes a signed integer to h.
+func HashInt[T ~int | ~int8 | ~int16 | ~int32 | ~int64](h hash.Hash64, v T) {
+	hashUint64(h, uint64(v))
+}
+
+// HashUint writes an unsigned integer to h.
+func HashUint[T ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr](h hash.Hash64, v T) {
+	hashUint64(h, uint64(v))
+}
+
+// HashFloat writes a floating-point number to h. Positive and negative zeros
+// are equal, and write the same data.
+func HashFloat[T ~float32 | ~float64](h hash.Hash64, v T) {
+	if v == 0 {
+		hashUint64(h, 0)
+		return
+	}
+	hashUint64(h, math.Float64bits(float64(v)))
+}
+
+// HashComplex writes a complex number to h, as its real and imaginary parts.
+func HashComplex[T ~complex64 | ~complex128](h hash.Hash64, v T) {
+	HashFloat(h, real(complex128(v)))
+	HashFloat(h, imag(complex128(v)))
+}
+
+// HashString writes a string to h, preceded by its length for consecutive
+// strings not to write the same data as their concatenation.
+func HashString[T ~string](h hash.Hash64, v T) {
+	hashUint64(h, uint64(len(v)))
+	h.Write([]byte(v))
+}
+
+// HashComparable writes a value of any comparable type to h, such as the key
+// of a map: values equal with the == operator write the same data. Pointers
+// and channels write their address.
+func HashComparable[T comparable](h hash.Hash64, v T) {
+	hashReflectValue(h, reflect.ValueOf(&v).Elem())
+}
+
+// HashSlice writes the elements of a slice to h, in their order, with write,
+// preceded by their number. Slices of elements which cannot be hashed have a
+// nil write function, and only write their length. Arrays are written as
+// slices of their elements.
+func HashSlice[S ~[]T, T any](h hash.Hash64, s S, write func(h hash.Hash64, v T)) {
+	hashUint64(h, uint64(len(s)))
+	if write == nil {
+		return
+	}
+	for _, v := range s {
+		write(h, v)
+	}
+}
+
+// HashSet writes the elements of a slice compared regardless of their order
+// to h, with write: the hashes of the elements are summed, so that slices
+// holding the same elements in another order write the same data.
+func HashSet[S ~[]T, T any](h hash.Hash64, s S, write func(h hash.Hash64, v T)) {
+	hashUint64(h, uint64(len(s)))
+	if write == nil {
+		return
+	}
+	var sum uint64
+	for _, v := range s {
+		sum += hashOf(v, write)
+	}
+	hashUint64(h, sum)
+}
+
+// HashMap writes the entries of a map to h, with HashComparable for their
+// keys and write for their values: as map iteration order is random, the
+// hashes of the entries are summed. Maps of values which cannot be hashed
+// have a nil write function, and only write their keys.
+func HashMap[M ~map[K]V, K comparable, V any](h hash.Hash64, m M, write func(h hash.Hash64, v V)) {
+	hashUint64(h, uint64(len(m)))
+	var sum uint64
+	for k, v := range m {
+		sum += hashOf(v, func(h hash.Hash64, v V) {
+			HashComparable(h, k)
+			if write != nil {
+				write(h, v)
+			}
+		})
+	}
+	hashUint64(h, sum)
+}
+
+// HashPointer writes a pointer to h: whether it is nil, and the pointed value
+// written with write otherwise. Pointers to values which cannot be hashed have
+// a nil write function.
+func HashPointer[P ~*T, T any](h hash.Hash64, p P, write func(h hash.Hash64, v T)) {
+	if p == nil {
+		h.Write([]byte{0})
+		return
+	}
+	h.Write([]byte{1})
+	if write != nil {
+		write(h, *p)
+	}
+}
+
+// HashNilEmpty writes a pointer whose nil value equals a pointer to the zero
+// value to h: nil pointers write the zero value with write.
+func HashNilEmpty[P ~*T, T any](h hash.Hash64, p P, write func(h hash.Hash64, v T)) {
+	if write == nil {
+		return
+	}
+	if p == nil {
+		var zero T
+		write(h, zero)
+		return
+	}
+	write(h, *p)
+}
+
+// HashMethod writes a value to h with its Hash method, if it has one. It
+// writes the values of interfaces whose implementations are compared with
+// their Equal methods.
+func HashMethod[T any](h hash.Hash64, v T) {
+	if hasher, ok := any(v).(Hasher); ok {
+		hasher.Hash(h)
+	}
+}
+
+// hashOf returns the hash of a value written with write to a new hash.
+func hashOf[T any](v T, write func(h hash.Hash64, v T)) uint64 {
+	h := fnv.New64a()
+	write(h, v)
+	return h.Sum64()
+}
+
+// hashUint64 writes a 64-bit unsigned integer to h.
+func hashUint64(h hash.Hash64, v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	h.Write(b[:])
+}
+
+// hashReflectValue writes a comparable value to h, as HashComparable does.
+func hashReflectValue(h hash.Hash64, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Bool:
+		HashBool(h, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		HashInt(h, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		HashUint(h, v.Uint())
+	case reflect.Float32, reflect.Float64:
+		HashFloat(h, v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		HashComplex(h, v.Complex())
+	case reflect.String:
+		HashString(h, v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		HashUint(h, v.Pointer())
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			hashReflectValue(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			hashReflectValue(h, v.Field(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			h.Write([]byte{0})
+			return
+		}
+		h.Write([]byte{1})
+		HashString(h, v.Elem().Type().String())
+		hashReflectValue(h, v.Elem())
+	}
+}
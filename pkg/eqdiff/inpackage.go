@@ -0,0 +1,156 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/haproxytech/go-method-gen/internal/data"
+	"golang.org/x/tools/go/packages"
+)
+
+// errReadOnlyPackage is returned for the packages generated files cannot be
+// written in.
+var errReadOnlyPackage = errors.New("it does not belong to the main module nor to a module replaced by a local directory")
+
+// packageDirs resolves the directories holding the sources of packages, to
+// write generated files beside the types they belong to (Options.InPackage).
+type packageDirs struct {
+	dir      string                       // Directory packages are loaded from
+	pkgs     map[string]*packages.Package // Import path -> loaded package
+	readOnly map[string]struct{}          // Import paths of the packages files cannot be written in
+}
+
+// newPackageDirs returns a resolver loading packages from dir, or from the
+// current directory if dir is empty.
+func newPackageDirs(dir string) *packageDirs {
+	return &packageDirs{dir: dir, pkgs: map[string]*packages.Package{}, readOnly: map[string]struct{}{}}
+}
+
+// load returns the package of the import path pkgPath. Only packages of the
+// main module, or of modules replaced by a local directory, are returned:
+// other packages are read-only copies in the module cache.
+func (p *packageDirs) load(pkgPath string) (*packages.Package, error) {
+	if pkg, found := p.pkgs[pkgPath]; found {
+		return pkg, nil
+	}
+	if _, found := p.readOnly[pkgPath]; found {
+		return nil, fmt.Errorf("cannot write generated files in package %s: %w", pkgPath, errReadOnlyPackage)
+	}
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedModule,
+		Dir:  p.dir,
+	}
+	pkgs, err := packages.Load(cfg, pkgPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 || len(pkgs[0].Errors) > 0 || len(pkgs[0].GoFiles) == 0 {
+		return nil, fmt.Errorf("failed to locate the sources of package %s", pkgPath)
+	}
+	pkg := pkgs[0]
+	if pkg.Module == nil || (!pkg.Module.Main && (pkg.Module.Replace == nil || pkg.Module.Replace.Version != "")) {
+		p.readOnly[pkgPath] = struct{}{}
+		return nil, fmt.Errorf("cannot write generated files in package %s: %w", pkgPath, errReadOnlyPackage)
+	}
+	p.pkgs[pkgPath] = pkg
+	return pkg, nil
+}
+
+// localize makes the functions of the values of the tree rooted at node
+// whose types are declared in read-only packages, such as time.Duration, be
+// generated in the packages of their referers: methods cannot be declared in
+// these packages. Defined builtin types are compared as their underlying
+// builtin, other types get functions named after their qualified name.
+func (p *packageDirs) localize(node *data.TypeNode) error {
+	for _, sub := range append(slices.Clone(node.Fields), node.SubNode) {
+		if sub == nil {
+			continue
+		}
+		if sub.PkgPath != "" && sub.Type != "" && !sub.IsInstantiated() &&
+			sub.Kind != data.Interface && sub.Kind != data.TypeParam {
+			_, err := p.load(sub.PkgPath)
+			switch {
+			case errors.Is(err, errReadOnlyPackage):
+				localizeNode(sub)
+			case err != nil:
+				return err
+			}
+		}
+		if err := p.localize(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// localizeNode names the type of a node declared in a read-only package by
+// its qualified name, e.g. "time.Duration", for its values to be handled in
+// the packages of its referers.
+func localizeNode(node *data.TypeNode) {
+	if node.Imports == nil {
+		node.Imports = map[string]struct{}{}
+	}
+	node.Imports[node.PkgPath] = struct{}{}
+	node.SamePkgAsReferer = false
+	if node.Kind == data.Builtin {
+		node.Type, node.PkgPath = node.PackagedType, ""
+		return
+	}
+	if node.Kind != data.Struct {
+		node.Type = node.PackagedType
+	}
+	node.Foreign = true
+}
+
+// relocate moves the files produced by one writer pass for the output
+// directory "" (<import path>/<file>) to the directories of their packages,
+// with the package clause of the package as named in its sources.
+func (p *packageDirs) relocate(contents map[string]map[string]string) (map[string]map[string]string, error) {
+	relocated := make(map[string]map[string]string, len(contents))
+	for file, funcs := range contents {
+		pkgPath := path.Dir(filepath.ToSlash(file))
+		pkg, err := p.load(pkgPath)
+		if err != nil {
+			return nil, err
+		}
+		funcs["Package"] = "package " + pkg.Name
+		relocated[filepath.Join(filepath.Dir(pkg.GoFiles[0]), filepath.Base(file))] = funcs
+	}
+	return relocated, nil
+}
+
+// checkOverwrite returns an error if file exists and was not written by
+// go-method-gen, as reported by the GeneratedComment its files start with:
+// files generated by other tools are not overwritten either.
+func checkOverwrite(file string) error {
+	src, err := os.ReadFile(file)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(src), []byte(GeneratedComment)) {
+		return fmt.Errorf("refusing to overwrite %s, which was not generated by go-method-gen", file)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+// inPackageSrc refers to types of the standard library, in which no file can
+// be written.
+const inPackageSrc = `package models
+
+import (
+	"net"
+	"time"
+)
+
+type Frontend struct {
+	Name     string
+	Timeout  time.Duration
+	Timeouts []time.Duration
+	IP       net.IP
+	Addrs    map[string]net.IP
+}
+`
+
+// writeModule writes the module testPkgPath with src as its only file, and
+// returns its directory.
+func writeModule(t *testing.T, src string) string {
+	t.Helper()
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module "+testPkgPath+"\n\ngo 1.22\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = os.WriteFile(filepath.Join(dir, "models.go"), []byte(src), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// generateInPackage returns the files generated beside the type Frontend of
+// the module in dir.
+func generateInPackage(t *testing.T, dir string) []eqdiff.GeneratedFile {
+	t.Helper()
+	return generateFromSource(t, inPackageSrc, "", []string{"Frontend"}, eqdiff.Options{
+		InPackage:   true,
+		PackagesDir: dir,
+		TypedDiff:   true,
+		Methods: []string{
+			eqdiff.MethodEqual, eqdiff.MethodDiff, eqdiff.MethodMerge, eqdiff.MethodClone, eqdiff.MethodApplyDiff,
+			eqdiff.MethodMerge3, eqdiff.MethodHash, eqdiff.MethodCompare, eqdiff.MethodMergePatch,
+		},
+	})
+}
+
+func TestGenerateInPackageForeignTypes(t *testing.T) {
+	dir := writeModule(t, inPackageSrc)
+	files := generateInPackage(t, dir)
+	if len(files) == 0 {
+		t.Fatal("no file generated")
+	}
+	for _, file := range files {
+		if filepath.Dir(file.Path) != dir {
+			t.Errorf("%s not generated beside the types, in %s", file.Path, dir)
+		}
+		// Methods are not declared on types of other packages
+		if strings.Contains(string(file.Source), "func (x ") {
+			t.Errorf("%s: method declared on a type of another package:\n%s", file.Path, file.Source)
+		}
+	}
+	checkGenerated(t, inPackageSrc, files)
+}
+
+func TestWriteFilesOverwrite(t *testing.T) {
+	dir := writeModule(t, inPackageSrc)
+	files := generateInPackage(t, dir)
+	if err := eqdiff.WriteFiles(files); err != nil {
+		t.Fatal(err)
+	}
+	// Files written by go-method-gen are overwritten
+	if err := eqdiff.WriteFiles(files); err != nil {
+		t.Fatal(err)
+	}
+	for _, file := range files {
+		if err := os.Remove(file.Path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Files generated by other tools are not, and no file is written
+	last := files[len(files)-1].Path
+	foreign := "// Code generated by other-tool. DO NOT EDIT.\n\npackage models\n"
+	if err := os.WriteFile(last, []byte(foreign), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := eqdiff.WriteFiles(files); err == nil {
+		t.Errorf("expected an error overwriting %s", last)
+	}
+	if src, _ := os.ReadFile(last); string(src) != foreign {
+		t.Errorf("%s overwritten", last)
+	}
+	for _, file := range files[:len(files)-1] {
+		if _, err := os.Stat(file.Path); err == nil {
+			t.Errorf("%s written although %s cannot be overwritten", file.Path, last)
+		}
+	}
+}
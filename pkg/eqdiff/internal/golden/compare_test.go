@@ -0,0 +1,95 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package golden
+
+import (
+	"testing"
+)
+
+// checkOrder checks that values, sorted in ascending order, are ordered by
+// compare consistently with equal: compare returns 0 for equal values only,
+// and is antisymmetric and transitive.
+func checkOrder[T any](t *testing.T, values []T, compare func(x, y T) int, equal func(x, y T) bool) {
+	t.Helper()
+	for i, x := range values {
+		for j, y := range values {
+			want := 0
+			switch {
+			case i < j:
+				want = -1
+			case i > j:
+				want = 1
+			}
+			if got := compare(x, y); got != want {
+				t.Errorf("Compare(%+v, %+v) = %d, want %d", x, y, got, want)
+			}
+			if equal(x, y) != (i == j) {
+				t.Errorf("Equal(%+v, %+v) = %t", x, y, equal(x, y))
+			}
+		}
+	}
+}
+
+func TestCompareTypeParam(t *testing.T) {
+	one, two := 1, 2
+	checkOrder(t, []Box[int]{
+		{},
+		{P: &one},
+		{P: &two},
+		{List: []int{1}},
+		{List: []int{1, 1}},
+		{List: []int{2}},
+		{V: 1},
+		{V: 2},
+	}, Box[int].Compare, Box[int].Equal)
+
+	// Values of type parameters which are not comparable
+	checkOrder(t, []Box[[]string]{
+		{},
+		{V: []string{}},
+		{V: []string{"a"}},
+		{V: []string{"a", "b"}},
+		{V: []string{"b"}},
+	}, Box[[]string].Compare, Box[[]string].Equal)
+	checkOrder(t, []Box[map[string]int]{
+		{},
+		{V: map[string]int{}},
+		{V: map[string]int{"a": 1}},
+		{V: map[string]int{"a": 1, "b": 0}},
+		{V: map[string]int{"a": 2}},
+		{V: map[string]int{"b": 0}},
+	}, Box[map[string]int].Compare, Box[map[string]int].Equal)
+}
+
+func TestCompareFrontend(t *testing.T) {
+	a, b := Server{Name: "a", Port: 1}, Server{Name: "b", Port: 1}
+	checkOrder(t, []Frontend{
+		{},
+		{Name: "a"},
+		{Name: "a", Labels: map[string]string{"a": "1"}},
+		{Name: "a", Labels: map[string]string{"b": "0"}},
+		{Name: "a", Backups: []*Server{nil}},
+		{Name: "a", Backups: []*Server{&a}},
+		{Name: "a", Servers: []Server{a}},
+		{Name: "a", Servers: []Server{a, b}},
+		{Name: "a", Binds: []string{"x"}},
+		{Name: "b"},
+	}, Frontend.Compare, Frontend.Equal)
+
+	x := Frontend{Servers: []Server{a, b}, ACLs: []string{"y", "x"}}
+	y := Frontend{Servers: []Server{b, a}, ACLs: []string{"x", "y"}}
+	if !x.Equal(y) || x.Compare(y) != 0 {
+		t.Errorf("Compare(%+v, %+v) = %d, want 0", x, y, x.Compare(y))
+	}
+}
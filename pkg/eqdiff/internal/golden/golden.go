@@ -0,0 +1,107 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package golden holds the types of the golden files of the eqdiff tests. Its
+// generated files are compared with the ones generated by the tests, and
+// tested for the results of their methods.
+//
+// Regenerate them with: go test ./pkg/eqdiff -run TestGenerateGolden -update
+package golden
+
+// Server is an element of the slices of Frontend.
+type Server struct {
+	Name string `json:"name"`
+	Port int    `json:"port"`
+}
+
+// Frontend holds slices diffed with each compare option, and fields whose
+// diff keys are escaped in JSON Pointers.
+type Frontend struct {
+	Name    string            `json:"name"`
+	Binds   []string          `json:"binds" gmg:"lcs"`
+	Rules   []string          `json:"rules" gmg:"moves"`
+	Servers []Server          `json:"servers" gmg:"key=Name"`
+	ACLs    []string          `json:"acls" gmg:"set"`
+	Backups []*Server         `json:"backups"`
+	Labels  map[string]string `json:"labels"`
+}
+
+// Box holds values of a type parameter which is not comparable.
+type Box[T any] struct {
+	V    T   `json:"v"`
+	List []T `json:"list"`
+	P    *T  `json:"p"`
+}
+
+// Member is an element of the slices of Pool, matched by a pointer key.
+type Member struct {
+	ID     *string `json:"id"`
+	Weight int     `json:"weight"`
+}
+
+// Pool holds slices whose elements are matched by the values their pointer
+// key fields point to.
+type Pool struct {
+	Members []Member  `json:"members" gmg:"key=ID"`
+	Spares  []*Member `json:"spares" gmg:"key=ID"`
+}
+
+// Meta is embedded in Listener, its members are members of Listener in JSON.
+type Meta struct {
+	Owner string `json:"owner"`
+}
+
+// Addr is embedded in Listener through a pointer.
+type Addr struct {
+	Host string `json:"host"`
+	Port int    `json:"port"`
+}
+
+// Listener holds fields which encoding/json encodes in their own way:
+// embedded structs are flattened, fields named "-" are left out and slices of
+// bytes are encoded as strings.
+type Listener struct {
+	Meta
+	*Addr
+	Name   string   `json:"name"`
+	Secret string   `json:"-"`
+	Cert   []byte   `json:"cert"`
+	Keys   [][]byte `json:"keys"`
+}
+
+// Pair is a generic type, instantiated by the fields of Route.
+type Pair[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// Route holds instances of a generic type, for which functions are generated
+// as methods cannot be declared on them.
+type Route struct {
+	Target  Pair[string, *Server] `json:"target"`
+	Weights []Pair[string, int]   `json:"weights"`
+}
+
+// Check is implemented by no type of the package.
+type Check interface {
+	Run() error
+}
+
+// Probe holds interfaces without registered implementations, whose values are
+// compared with reflect.
+type Probe struct {
+	Name  string      `json:"name"`
+	Spec  interface{} `json:"spec"`
+	Check Check       `json:"check"`
+}
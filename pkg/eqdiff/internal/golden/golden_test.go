@@ -0,0 +1,668 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package golden
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+func TestDiffLCS(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []string
+		want []eqdiff.Change
+	}{
+		{
+			name: "insertion",
+			x:    []string{"a", "b", "c"},
+			y:    []string{"a", "x", "b", "c"},
+			want: []eqdiff.Change{{Path: "binds[1]", Op: eqdiff.Added, New: "x"}},
+		},
+		{
+			name: "deletion",
+			x:    []string{"a", "b", "c"},
+			y:    []string{"a", "c"},
+			want: []eqdiff.Change{{Path: "binds[1]", Op: eqdiff.Removed, Old: "b"}},
+		},
+		{
+			name: "replacement",
+			x:    []string{"a", "b", "c"},
+			y:    []string{"a", "x", "c"},
+			want: []eqdiff.Change{{Path: "binds[1]", Op: eqdiff.Modified, Old: "b", New: "x"}},
+		},
+		{
+			// Removed elements are reported at their index in x, added
+			// ones at their index in y
+			name: "shift",
+			x:    []string{"a", "b", "c"},
+			y:    []string{"z", "a", "b"},
+			want: []eqdiff.Change{
+				{Path: "binds[0]", Op: eqdiff.Added, New: "z"},
+				{Path: "binds[2]", Op: eqdiff.Removed, Old: "c"},
+			},
+		},
+		{
+			name: "growth",
+			x:    []string{"b"},
+			y:    []string{"a", "b", "c"},
+			want: []eqdiff.Change{
+				{Path: "binds[0]", Op: eqdiff.Added, New: "a"},
+				{Path: "binds[2]", Op: eqdiff.Added, New: "c"},
+			},
+		},
+		{
+			name: "shrink",
+			x:    []string{"a", "b", "c"},
+			y:    []string{"b"},
+			want: []eqdiff.Change{
+				{Path: "binds[0]", Op: eqdiff.Removed, Old: "a"},
+				{Path: "binds[2]", Op: eqdiff.Removed, Old: "c"},
+			},
+		},
+		{
+			name: "equal",
+			x:    []string{"a", "b"},
+			y:    []string{"a", "b"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x, y := Frontend{Binds: test.x}, Frontend{Binds: test.y}
+			if got := x.Diff(y); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Diff() = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDiffMoves(t *testing.T) {
+	tests := []struct {
+		name string
+		x, y []string
+		want []eqdiff.Change
+	}{
+		{
+			name: "move to front",
+			x:    []string{"a", "b", "c"},
+			y:    []string{"c", "a", "b"},
+			want: []eqdiff.Change{{Path: "rules[0]", Op: eqdiff.Moved, Old: "c", New: "c", From: "rules[2]"}},
+		},
+		{
+			name: "move to back",
+			x:    []string{"a", "b", "c"},
+			y:    []string{"b", "c", "a"},
+			want: []eqdiff.Change{{Path: "rules[2]", Op: eqdiff.Moved, Old: "a", New: "a", From: "rules[0]"}},
+		},
+		{
+			// Elements removed and added at the same index, with as many
+			// elements removed and added before them, are replaced
+			name: "replacement after move",
+			x:    []string{"a", "b", "c", "d"},
+			y:    []string{"b", "a", "x", "d"},
+			want: []eqdiff.Change{
+				{Path: "rules[0]", Op: eqdiff.Moved, Old: "b", New: "b", From: "rules[1]"},
+				{Path: "rules[2]", Op: eqdiff.Modified, Old: "c", New: "x"},
+			},
+		},
+		{
+			// Indexes of the elements following insertions differ on
+			// both sides: they are removed and added instead
+			name: "replacement after insertion",
+			x:    []string{"a", "b"},
+			y:    []string{"z", "a", "x"},
+			want: []eqdiff.Change{
+				{Path: "rules[1]", Op: eqdiff.Removed, Old: "b"},
+				{Path: "rules[0]", Op: eqdiff.Added, New: "z"},
+				{Path: "rules[2]", Op: eqdiff.Added, New: "x"},
+			},
+		},
+		{
+			name: "swap",
+			x:    []string{"a", "b", "c", "d"},
+			y:    []string{"d", "b", "c", "a"},
+			want: []eqdiff.Change{
+				{Path: "rules[3]", Op: eqdiff.Moved, Old: "a", New: "a", From: "rules[0]"},
+				{Path: "rules[0]", Op: eqdiff.Moved, Old: "d", New: "d", From: "rules[3]"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			x, y := Frontend{Rules: test.x}, Frontend{Rules: test.y}
+			if got := x.Diff(y); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("Diff() = %#v, want %#v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestApplyDiffRoundTrip(t *testing.T) {
+	a, b, c := Server{Name: "a", Port: 1}, Server{Name: "b", Port: 2}, Server{Name: "c", Port: 3}
+	tests := []struct {
+		name string
+		x, y Frontend
+	}{
+		{
+			name: "lcs slice grown and shrunk",
+			x:    Frontend{Binds: []string{"a", "b", "c"}},
+			y:    Frontend{Binds: []string{"z", "b", "x", "y"}},
+		},
+		{
+			name: "moved elements",
+			x:    Frontend{Rules: []string{"a", "b", "c", "d"}},
+			y:    Frontend{Rules: []string{"d", "b", "x", "a"}},
+		},
+		{
+			name: "keyed elements",
+			x:    Frontend{Servers: []Server{a, b}},
+			y:    Frontend{Servers: []Server{{Name: "b", Port: 4}, c}},
+		},
+		{
+			name: "set elements",
+			x:    Frontend{ACLs: []string{"a", "b", "c"}},
+			y:    Frontend{ACLs: []string{"c", "d"}},
+		},
+		{
+			name: "pointer elements",
+			x:    Frontend{Backups: []*Server{&a, nil, &b}},
+			y:    Frontend{Backups: []*Server{&c, &b}},
+		},
+		{
+			name: "map entries inserted, deleted and set to zero",
+			x:    Frontend{Labels: map[string]string{"a/b": "1", "c~d": "2", "e": "3"}},
+			y:    Frontend{Labels: map[string]string{"a/b": "", "e": "4", "f": ""}},
+		},
+		{
+			name: "emptied",
+			x:    Frontend{Name: "x", Binds: []string{"a"}, Servers: []Server{a}, Labels: map[string]string{"a": "1"}},
+			y:    Frontend{},
+		},
+		{
+			name: "filled",
+			x:    Frontend{},
+			y:    Frontend{Name: "y", Rules: []string{"a"}, Backups: []*Server{&c}, Labels: map[string]string{"a": "1"}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			z := test.x.Clone()
+			if err := z.ApplyDiff(test.x.Diff(test.y)); err != nil {
+				t.Fatal(err)
+			}
+			if !z.Equal(test.y) {
+				t.Errorf("ApplyDiff(Diff()) = %+v, want %+v", z, test.y)
+			}
+		})
+	}
+}
+
+func TestPointerKeys(t *testing.T) {
+	// Keys are new pointers for each value, matched by the values they point to
+	id := func(s string) *string { return &s }
+	x := Pool{
+		Members: []Member{{ID: id("a"), Weight: 1}, {ID: id("b"), Weight: 2}, {Weight: 3}},
+		Spares:  []*Member{{ID: id("a")}, nil},
+	}
+	reordered := Pool{
+		Members: []Member{{ID: id("b"), Weight: 2}, {Weight: 3}, {ID: id("a"), Weight: 1}},
+		Spares:  []*Member{nil, {ID: id("a")}},
+	}
+	if !x.Equal(reordered) {
+		t.Errorf("Equal(%+v, %+v) = false", x, reordered)
+	}
+	if diff := x.Diff(reordered); len(diff) != 0 {
+		t.Errorf("Diff(%+v, %+v) = %+v, want no change", x, reordered, diff)
+	}
+
+	y := Pool{
+		Members: []Member{{ID: id("b"), Weight: 4}, {Weight: 3}, {ID: id("c"), Weight: 1}},
+		Spares:  []*Member{nil, {ID: id("a"), Weight: 1}},
+	}
+	want := []eqdiff.Change{
+		{Path: "members[id=a]", Op: eqdiff.Removed, Old: Member{ID: id("a"), Weight: 1}},
+		{Path: "members[id=b].weight", Op: eqdiff.Modified, Old: 2, New: 4},
+		{Path: "members[id=c]", Op: eqdiff.Added, New: Member{ID: id("c"), Weight: 1}},
+		{Path: "spares[id=a].weight", Op: eqdiff.Modified, Old: 0, New: 1},
+	}
+	if got := x.Diff(y); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+	z := x.Clone()
+	if err := z.ApplyDiff(x.Diff(y)); err != nil {
+		t.Fatal(err)
+	}
+	if !z.Equal(y) {
+		t.Errorf("ApplyDiff(Diff()) = %+v, want %+v", z, y)
+	}
+	// Both sides modify the element of key b, with new pointers
+	ours := Pool{Members: []Member{{ID: id("a"), Weight: 1}, {ID: id("b"), Weight: 5}, {Weight: 3}}}
+	theirs := Pool{Members: []Member{{ID: id("b"), Weight: 2}, {Weight: 3}, {ID: id("a"), Weight: 6}}}
+	merged, conflicts := x.Merge3(ours, theirs)
+	if len(conflicts) != 0 {
+		t.Errorf("Merge3() conflicts = %+v, want none", conflicts)
+	}
+	wantMerged := Pool{Members: []Member{{ID: id("a"), Weight: 6}, {ID: id("b"), Weight: 5}, {Weight: 3}}}
+	if !merged.Equal(wantMerged) {
+		t.Errorf("Merge3() = %+v, want %+v", merged, wantMerged)
+	}
+
+	// Conflicts are at the paths of the diffs of both sides
+	theirs = Pool{Members: []Member{{ID: id("b"), Weight: 7}, {Weight: 3}, {ID: id("a"), Weight: 1}}}
+	ours.Spares, theirs.Spares = x.Spares, x.Spares
+	_, conflicts = eqdiff.Merge3(x, ours, theirs)
+	wantConflicts := []eqdiff.Conflict{{Path: "members[id=b].weight", Base: 2, Ours: 5, Theirs: 7}}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Errorf("Merge3() conflicts = %+v, want %+v", conflicts, wantConflicts)
+	}
+	for _, side := range []Pool{ours, theirs} {
+		if diff := x.Diff(side); len(diff) != 1 || diff[0].Path != wantConflicts[0].Path {
+			t.Errorf("Diff() = %+v, want a change of %s", diff, wantConflicts[0].Path)
+		}
+	}
+}
+
+func TestJSONPaths(t *testing.T) {
+	// Members of embedded structs are members of Listener, Secret is left out
+	// and slices of bytes are changed as a whole, as in their JSON documents
+	x := Listener{Meta: Meta{Owner: "a"}, Name: "l", Secret: "s", Cert: []byte("ab"), Keys: [][]byte{[]byte("k")}}
+	y := Listener{
+		Meta: Meta{Owner: "b"}, Addr: &Addr{Host: "h"}, Name: "l", Secret: "t",
+		Cert: []byte("ac"), Keys: [][]byte{[]byte("l")},
+	}
+	want := []eqdiff.Change{
+		{Path: "owner", Op: eqdiff.Modified, Old: "a", New: "b"},
+		{Path: "host", Op: eqdiff.Modified, Old: "", New: "h"},
+		{Path: "cert", Op: eqdiff.Modified, Old: []byte("ab"), New: []byte("ac")},
+		{Path: "keys[0]", Op: eqdiff.Modified, Old: []byte("k"), New: []byte("l")},
+	}
+	diff := x.Diff(y)
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Diff() = %+v, want %+v", diff, want)
+	}
+
+	// Members of the nil embedded pointer are missing from the document
+	patch, err := eqdiff.JSONPatchFor(x, diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := json.Marshal(patch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantPatch := `[{"op":"replace","path":"/owner","value":"b"},{"op":"add","path":"/host","value":"h"},` +
+		`{"op":"replace","path":"/cert","value":"YWM="},{"op":"replace","path":"/keys/0","value":"bA=="}]`
+	if string(got) != wantPatch {
+		t.Errorf("JSONPatchFor() = %s, want %s", got, wantPatch)
+	}
+
+	z := x.Clone()
+	if err := z.ApplyDiff(diff); err != nil {
+		t.Fatal(err)
+	}
+	y.Secret = x.Secret
+	if !z.Equal(y) {
+		t.Errorf("ApplyDiff(Diff()) = %+v, want %+v", z, y)
+	}
+	if err := z.ApplyDiff([]eqdiff.Change{{Path: "Secret", Op: eqdiff.Modified, New: "u"}}); !errors.Is(err, eqdiff.ErrUnknownField) {
+		t.Errorf("ApplyDiff() of Secret = %v, want an unknown field", err)
+	}
+
+	// Conflicts of members of embedded structs are named as their diff keys
+	ours, theirs := x.Clone(), x.Clone()
+	ours.Owner, theirs.Owner = "b", "c"
+	_, conflicts := x.Merge3(ours, theirs)
+	if len(conflicts) != 1 || conflicts[0].Path != "owner" {
+		t.Errorf("Merge3() conflicts = %+v, want one of owner", conflicts)
+	}
+}
+
+func TestCloneDepth(t *testing.T) {
+	id := func(s string) *string { return &s }
+	newPool := func() Pool {
+		return Pool{
+			Members: []Member{{ID: id("a"), Weight: 1}},
+			Spares:  []*Member{{ID: id("b"), Weight: 2}, nil},
+		}
+	}
+	x := newPool()
+	clone := x.Clone()
+	if !clone.Equal(x) || !reflect.DeepEqual(clone, x) {
+		t.Fatalf("Clone() = %+v, want %+v", clone, x)
+	}
+	// Values pointed to by the clone are copies, down to the key fields
+	*clone.Members[0].ID = "c"
+	clone.Members[0].Weight = 3
+	*clone.Spares[0].ID = "d"
+	clone.Spares[0].Weight = 4
+	clone.Spares[1] = &Member{}
+	if !reflect.DeepEqual(x, newPool()) {
+		t.Errorf("Pool modified through its clone: %+v", x)
+	}
+
+	newFrontend := func() Frontend {
+		return Frontend{
+			Binds:   []string{"a"},
+			Servers: []Server{{Name: "a", Port: 1}},
+			Backups: []*Server{{Name: "b", Port: 2}},
+			Labels:  map[string]string{"a": "1"},
+		}
+	}
+	f := newFrontend()
+	fClone := f.Clone()
+	fClone.Binds[0] = "b"
+	fClone.Servers[0].Port = 3
+	fClone.Backups[0].Port = 4
+	fClone.Labels["a"] = "2"
+	fClone.Labels["b"] = "3"
+	if !reflect.DeepEqual(f, newFrontend()) {
+		t.Errorf("Frontend modified through its clone: %+v", f)
+	}
+
+	newListener := func() Listener {
+		return Listener{Addr: &Addr{Host: "h"}, Cert: []byte("c"), Keys: [][]byte{[]byte("k")}}
+	}
+	l := newListener()
+	lClone := l.Clone()
+	lClone.Host = "i"
+	lClone.Cert[0] = 'd'
+	lClone.Keys[0][0] = 'l'
+	if !reflect.DeepEqual(l, newListener()) {
+		t.Errorf("Listener modified through its clone: %+v", l)
+	}
+
+	// Values of type parameters are copied by assignment
+	b := Box[[]int]{V: []int{1}, List: [][]int{{1}}, P: &[]int{1}}
+	bClone := b.Clone()
+	*bClone.P = append(*bClone.P, 2)
+	bClone.List[0] = []int{2}
+	if !reflect.DeepEqual(b, Box[[]int]{V: []int{1}, List: [][]int{{1}}, P: &[]int{1}}) {
+		t.Errorf("Box modified through its clone: %+v", b)
+	}
+	// Nil values stay nil
+	empty := Box[int]{}.Clone()
+	if empty.List != nil || empty.P != nil {
+		t.Errorf("Clone() of nil values = %+v", empty)
+	}
+}
+
+func TestGenerics(t *testing.T) {
+	one, otherOne, two := 1, 1, 2
+	x := Box[int]{V: 1, List: []int{1, 2}, P: &one}
+	y := Box[int]{V: 2, List: []int{1}, P: &two}
+	if !x.Equal(Box[int]{V: 1, List: []int{1, 2}, P: &otherOne}) || x.Equal(y) {
+		t.Errorf("Equal() of %+v", x)
+	}
+	want := []eqdiff.Change{
+		{Path: "v", Op: eqdiff.Modified, Old: 1, New: 2},
+		{Path: "list[1]", Op: eqdiff.Removed, Old: 2},
+		{Path: "p", Op: eqdiff.Modified, Old: 1, New: 2},
+	}
+	if got := x.Diff(y); !reflect.DeepEqual(got, want) {
+		t.Errorf("Diff() = %+v, want %+v", got, want)
+	}
+	// Zero values of type parameters are not merged
+	merged := x.Merge(Box[int]{List: []int{3}})
+	if !merged.Equal(Box[int]{V: 1, List: []int{3}, P: &one}) {
+		t.Errorf("Merge() = %+v", merged)
+	}
+
+	// Values of type parameters which are not comparable are compared deeply
+	s := Box[[]string]{V: []string{"a"}}
+	if !s.Equal(Box[[]string]{V: []string{"a"}}) || s.Equal(Box[[]string]{V: []string{"b"}}) {
+		t.Errorf("Equal() of %+v", s)
+	}
+	wantS := []eqdiff.Change{{Path: "v", Op: eqdiff.Modified, Old: []string{"a"}, New: []string{"b"}}}
+	if got := s.Diff(Box[[]string]{V: []string{"b"}}); !reflect.DeepEqual(got, wantS) {
+		t.Errorf("Diff() = %+v, want %+v", got, wantS)
+	}
+
+	// Instances of generic types are handled by functions named after their
+	// type arguments
+	r := Route{Target: Pair[string, *Server]{Key: "a", Value: &Server{Name: "a"}}, Weights: []Pair[string, int]{{"a", 1}}}
+	u := Route{Target: Pair[string, *Server]{Key: "a", Value: &Server{Name: "a", Port: 1}}, Weights: []Pair[string, int]{{"a", 2}}}
+	wantR := []eqdiff.Change{
+		{Path: "target.value.port", Op: eqdiff.Modified, Old: 0, New: 1},
+		{Path: "weights[0].value", Op: eqdiff.Modified, Old: 1, New: 2},
+	}
+	if got := r.Diff(u); !reflect.DeepEqual(got, wantR) {
+		t.Errorf("Diff() = %+v, want %+v", got, wantR)
+	}
+	z := r.Clone()
+	if err := z.ApplyDiff(r.Diff(u)); err != nil {
+		t.Fatal(err)
+	}
+	if !z.Equal(u) || z.Equal(r) {
+		t.Errorf("ApplyDiff(Diff()) = %+v, want %+v", z, u)
+	}
+	if r.Target.Value.Port != 0 {
+		t.Errorf("Route modified through its clone: %+v", r)
+	}
+}
+
+// applyMergePatch applies the JSON merge patch patch to the JSON document doc,
+// as RFC 7396 defines it.
+func applyMergePatch(doc, patch interface{}) interface{} {
+	patchMembers, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+	docMembers, ok := doc.(map[string]interface{})
+	if !ok {
+		docMembers = map[string]interface{}{}
+	}
+	for k, v := range patchMembers {
+		if v == nil {
+			delete(docMembers, k)
+			continue
+		}
+		docMembers[k] = applyMergePatch(docMembers[k], v)
+	}
+	return docMembers
+}
+
+// withoutNulls returns the JSON document doc without its null members.
+func withoutNulls(doc interface{}) interface{} {
+	members, ok := doc.(map[string]interface{})
+	if !ok {
+		return doc
+	}
+	for k, v := range members {
+		if v == nil {
+			delete(members, k)
+			continue
+		}
+		members[k] = withoutNulls(v)
+	}
+	return members
+}
+
+// checkMergePatch checks that the merge patch of x and y is want, and that it
+// turns the JSON document of x into the one of y.
+func checkMergePatch[T any](t *testing.T, x, y T, mergePatch func(x, y T) ([]byte, error), want string) {
+	t.Helper()
+	patch, err := mergePatch(x, y)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(patch) != want {
+		t.Errorf("MergePatch(%+v, %+v) = %s, want %s", x, y, patch, want)
+	}
+	var doc, patchDoc, wantDoc interface{}
+	for v, p := range map[interface{}]*interface{}{&x: &doc, &y: &wantDoc} {
+		contents, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := json.Unmarshal(contents, p); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := json.Unmarshal(patch, &patchDoc); err != nil {
+		t.Fatal(err)
+	}
+	// Merge patches remove null members, which are missing members for
+	// applications of JSON documents
+	if got := withoutNulls(applyMergePatch(doc, patchDoc)); !reflect.DeepEqual(got, withoutNulls(wantDoc)) {
+		t.Errorf("merge patch %s applied to %+v = %v, want %v", patch, x, got, wantDoc)
+	}
+}
+
+func TestMergePatch(t *testing.T) {
+	a, b := Server{Name: "a", Port: 1}, Server{Name: "b", Port: 2}
+	x := Frontend{Name: "x", Binds: []string{"a", "b"}, Servers: []Server{a}, Labels: map[string]string{"a": "1", "b": "2"}}
+	checkMergePatch(t, x, x.Clone(), Frontend.MergePatch, `{}`)
+	// Removed entries are null, slices are replaced as a whole
+	y := Frontend{Name: "y", Binds: []string{"b"}, Servers: []Server{a}, Labels: map[string]string{"b": "3", "c": ""}}
+	checkMergePatch(t, x, y, Frontend.MergePatch, `{"binds":["b"],"labels":{"a":null,"b":"3","c":""},"name":"y"}`)
+	checkMergePatch(t, x, Frontend{Name: "x", Binds: []string{"a", "b"}, Servers: []Server{a}}, Frontend.MergePatch,
+		`{"labels":null}`)
+
+	// Changed structs are nested objects, nil pointers are null
+	r := Route{Target: Pair[string, *Server]{Key: "a", Value: &a}, Weights: []Pair[string, int]{{"a", 1}}}
+	checkMergePatch(t, r, Route{Target: Pair[string, *Server]{Key: "a", Value: &b}, Weights: r.Weights}, Route.MergePatch,
+		`{"target":{"value":{"name":"b","port":2}}}`)
+	checkMergePatch(t, r, Route{Target: Pair[string, *Server]{Key: "b"}}, Route.MergePatch,
+		`{"target":{"key":"b","value":null},"weights":null}`)
+
+	// Members of embedded structs are members of their struct, fields named
+	// "-" are left out
+	l := Listener{Meta: Meta{Owner: "a"}, Name: "l", Secret: "s"}
+	checkMergePatch(t, l, Listener{Meta: Meta{Owner: "b"}, Addr: &Addr{Host: "h"}, Name: "l", Secret: "t"}, Listener.MergePatch,
+		`{"host":"h","owner":"b","port":0}`)
+	checkMergePatch(t, Listener{Addr: &Addr{Host: "h", Port: 1}}, Listener{}, Listener.MergePatch, `{"host":null,"port":null}`)
+}
+
+// checkHashes checks that values equal for equal have the same hash, and that
+// the values of distinct, which are all different, have distinct hashes.
+func checkHashes[T eqdiff.Hasher](t *testing.T, equal func(x, y T) bool, values []T, distinct []T) {
+	t.Helper()
+	for _, x := range values {
+		for _, y := range values {
+			if equal(x, y) && eqdiff.Hash(x) != eqdiff.Hash(y) {
+				t.Errorf("Hash(%+v) != Hash(%+v), which are equal", x, y)
+			}
+		}
+	}
+	hashes := map[uint64]T{}
+	for _, v := range distinct {
+		if other, found := hashes[eqdiff.Hash(v)]; found {
+			t.Errorf("Hash(%+v) == Hash(%+v)", v, other)
+		}
+		hashes[eqdiff.Hash(v)] = v
+	}
+}
+
+func TestHash(t *testing.T) {
+	a, b := Server{Name: "a", Port: 1}, Server{Name: "b", Port: 2}
+	b2 := b
+	frontends := []Frontend{
+		{},
+		{Name: "a"},
+		{Binds: []string{"a", "b"}},
+		{Binds: []string{"b", "a"}},
+		// Elements of sets and keyed slices are hashed regardless of their order
+		{ACLs: []string{"a", "b"}},
+		{ACLs: []string{"b", "a"}},
+		{Servers: []Server{a, b}},
+		{Servers: []Server{b, a}},
+		{Backups: []*Server{&b}},
+		{Backups: []*Server{&b2}},
+		{Backups: []*Server{nil}},
+		{Labels: map[string]string{"a": "1", "b": "2", "c": "3"}},
+		{Labels: map[string]string{"c": "3", "b": "2", "a": "1"}},
+		{Labels: map[string]string{"a": "2", "b": "1", "c": "3"}},
+	}
+	checkHashes(t, Frontend.Equal, frontends, []Frontend{
+		frontends[0], frontends[1], frontends[2], frontends[3], frontends[4], frontends[6], frontends[8], frontends[10],
+		frontends[11], frontends[13],
+	})
+
+	id := func(s string) *string { return &s }
+	pools := []Pool{
+		{Members: []Member{{ID: id("a"), Weight: 1}, {ID: id("b")}}},
+		{Members: []Member{{ID: id("b")}, {ID: id("a"), Weight: 1}}},
+		{Members: []Member{{ID: id("a")}, {ID: id("b"), Weight: 1}}},
+		{Spares: []*Member{{ID: id("a")}, nil}},
+		{Spares: []*Member{nil, {ID: id("a")}}},
+	}
+	checkHashes(t, Pool.Equal, pools, []Pool{pools[0], pools[2], pools[3]})
+
+	listeners := []Listener{
+		{},
+		{Addr: &Addr{}},
+		{Addr: &Addr{Host: "h"}},
+		{Meta: Meta{Owner: "a"}},
+		{Cert: []byte("a")},
+		{Keys: [][]byte{[]byte("a")}},
+	}
+	checkHashes(t, Listener.Equal, listeners, listeners)
+
+	// Values of type parameters are left out of the hash, as they cannot be
+	// hashed consistently with Equal
+	one, otherOne := 1, 1
+	checkHashes(t, Box[int].Equal, []Box[int]{{V: 1}, {V: 2}, {P: &one}, {P: &otherOne}, {List: []int{1}}}, nil)
+	checkHashes(t, Box[[]string].Equal, []Box[[]string]{{V: []string{"a"}}, {V: []string{"a"}}, {V: nil}}, nil)
+}
+
+// fileCheck implements Check, outside of the types functions are generated for.
+type fileCheck struct {
+	Paths []string
+}
+
+func (c fileCheck) Run() error { return nil }
+
+func TestInterfacesWithoutImplementations(t *testing.T) {
+	// Values of interfaces without registered implementations are compared
+	// with reflect.DeepEqual, and ordered consistently with it
+	x := Probe{Name: "p", Spec: map[string]int{"a": 1}, Check: fileCheck{Paths: []string{"/a"}}}
+	same := Probe{Name: "p", Spec: map[string]int{"a": 1}, Check: fileCheck{Paths: []string{"/a"}}}
+	y := Probe{Name: "p", Spec: map[string]int{"a": 2}, Check: nil}
+	if !x.Equal(same) || x.Compare(same) != 0 || len(x.Diff(same)) != 0 {
+		t.Errorf("%+v and %+v: Equal() = %v, Compare() = %d, Diff() = %+v, want equal values",
+			x, same, x.Equal(same), x.Compare(same), x.Diff(same))
+	}
+	if x.Equal(y) || x.Compare(y) == 0 || x.Compare(y) != -y.Compare(x) {
+		t.Errorf("%+v and %+v: Equal() = %v, Compare() = %d, want different values", x, y, x.Equal(y), x.Compare(y))
+	}
+
+	want := []eqdiff.Change{
+		{Path: "spec", Op: eqdiff.Modified, Old: x.Spec, New: y.Spec},
+		{Path: "check", Op: eqdiff.Removed, Old: x.Check},
+	}
+	diff := x.Diff(y)
+	if !reflect.DeepEqual(diff, want) {
+		t.Errorf("Diff() = %+v, want %+v", diff, want)
+	}
+	z := x.Clone()
+	if err := z.ApplyDiff(diff); err != nil {
+		t.Fatal(err)
+	}
+	if !z.Equal(y) {
+		t.Errorf("ApplyDiff(Diff()) = %+v, want %+v", z, y)
+	}
+
+	ours, theirs := x, x
+	ours.Spec, theirs.Spec = map[string]int{"a": 2}, map[string]int{"a": 3}
+	theirs.Check = nil
+	merged, conflicts := eqdiff.Merge3(x, ours, theirs)
+	wantConflicts := []eqdiff.Conflict{{Path: "spec", Base: x.Spec, Ours: ours.Spec, Theirs: theirs.Spec}}
+	if !reflect.DeepEqual(conflicts, wantConflicts) || merged.Check != nil {
+		t.Errorf("Merge3() = %+v, %+v, want the check of theirs and conflicts %+v", merged, conflicts, wantConflicts)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// Conflict is a value changed differently by both sides of a three-way merge.
+// The merged value keeps ours.
+type Conflict struct {
+	Path   string      // Path of the value, as in diffs, e.g. "Servers[0].Name"
+	Base   interface{} // Value in the common ancestor, nil if missing
+	Ours   interface{} // Value on our side, nil if missing
+	Theirs interface{} // Value on their side, nil if missing
+}
+
+// Merge3 merges the changes made to base, the common ancestor of a three-way
+// merge, by ours and theirs into a new value, with the Merge3 method of their
+// type, generated with the merge3 method: changes made on one side only are
+// kept, and values changed differently by both sides are conflicts, keeping
+// ours in the merged value.
+func Merge3[T interface {
+	Merge3(ours, theirs T) (T, []Conflict)
+}](base, ours, theirs T) (T, []Conflict) {
+	return base.Merge3(ours, theirs)
+}
+
+// AppendConflicts appends to conflicts the conflicts of a value nested under
+// key, e.g. a field name or "[0]", with their paths prefixed by key as the
+// diff keys of nested values are. Conflicts of the members of flattened
+// embedded structs, under an empty key, keep their paths. It is called by
+// generated Merge3 methods.
+func AppendConflicts(conflicts []Conflict, key string, nested []Conflict) []Conflict {
+	for _, conflict := range nested {
+		switch {
+		case key == "":
+		case conflict.Path == "":
+			conflict.Path = key
+		case conflict.Path[0] == '.' || conflict.Path[0] == '[':
+			conflict.Path = key + conflict.Path
+		default:
+			conflict.Path = key + "." + conflict.Path
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// Merge3Value merges the values of a three-way merge changed as a whole:
+// values changed on one side only take the value of that side, and values
+// changed on both sides are a conflict, unless they are changed to equal
+// values.
+func Merge3Value[T any](base, ours, theirs T, equal func(x, y T) bool) (T, []Conflict) {
+	switch {
+	case equal(base, ours):
+		return theirs, nil
+	case equal(base, theirs), equal(ours, theirs):
+		return ours, nil
+	}
+	return ours, []Conflict{{Base: base, Ours: ours, Theirs: theirs}}
+}
+
+// Merge3Slice merges the slices of a three-way merge. Slices changed on one
+// side only take the slice of that side. Slices of the same length on all
+// sides are merged element by element, with merge, or with Merge3Value if it
+// is nil. Other slices changed on both sides are a conflict: elements are
+// matched by index, and inserting or removing elements on one side shifts
+// the elements changed on the other one.
+func Merge3Slice[S ~[]T, T any](base, ours, theirs S, merge func(base, ours, theirs T) (T, []Conflict), equal func(x, y T) bool) (S, []Conflict) {
+	switch {
+	case slices.EqualFunc(base, ours, equal):
+		return theirs, nil
+	case slices.EqualFunc(base, theirs, equal), slices.EqualFunc(ours, theirs, equal):
+		return ours, nil
+	case len(ours) != len(base) || len(theirs) != len(base):
+		return ours, []Conflict{{Base: base, Ours: ours, Theirs: theirs}}
+	}
+	merged := make(S, len(ours))
+	return merged, Merge3Array(merged, base, ours, theirs, merge, equal)
+}
+
+// Merge3KeyedSlice merges the slices of a three-way merge whose elements are
+// matched by the value of their key field, formatted by key, as slices diffed
+// with the key option are. Conflicts of elements are reported at the paths of
+// their diffs, selecting them by name, the name of the key field in diff
+// keys, e.g. "[name=web1]". Slices changed on one side only take the
+// slice of that side. Otherwise, elements found on all sides are merged with
+// merge, or with Merge3Value if it is nil, and the others are added, removed
+// or changed as values changed as a whole are. Merged slices hold the
+// elements of ours, in their order, followed by the ones added by theirs.
+// Slices holding several elements with the same key are merged as a whole.
+func Merge3KeyedSlice[S ~[]T, T any](base, ours, theirs S, merge func(base, ours, theirs T) (T, []Conflict), equal func(x, y T) bool, name string, key func(v T) string) (S, []Conflict) {
+	switch {
+	case slices.EqualFunc(base, ours, equal):
+		return theirs, nil
+	case slices.EqualFunc(base, theirs, equal), slices.EqualFunc(ours, theirs, equal):
+		return ours, nil
+	}
+	indexes := func(s S) (map[string]int, bool) {
+		index := make(map[string]int, len(s))
+		for i, v := range s {
+			if _, found := index[key(v)]; found {
+				return nil, false
+			}
+			index[key(v)] = i
+		}
+		return index, true
+	}
+	indexBase, uniqueBase := indexes(base)
+	indexOurs, uniqueOurs := indexes(ours)
+	indexTheirs, uniqueTheirs := indexes(theirs)
+	if !uniqueBase || !uniqueOurs || !uniqueTheirs {
+		return ours, []Conflict{{Base: base, Ours: ours, Theirs: theirs}}
+	}
+
+	merged := make(S, 0, len(ours))
+	var conflicts []Conflict
+	conflict := func(path string, vb T, inBase bool, vo T, inOurs bool, vt T, inTheirs bool) {
+		conflicts = append(conflicts, Conflict{
+			Path:   path,
+			Base:   entry(vb, inBase),
+			Ours:   entry(vo, inOurs),
+			Theirs: entry(vt, inTheirs),
+		})
+	}
+	var zero T
+	for _, vo := range ours {
+		k := key(vo)
+		path := "[" + name + "=" + k + "]"
+		i, inBase := indexBase[k]
+		j, inTheirs := indexTheirs[k]
+		switch {
+		case inBase && inTheirs:
+			v, nested := merge3Element(base[i], vo, theirs[j], merge, equal)
+			merged = append(merged, v)
+			conflicts = AppendConflicts(conflicts, path, nested)
+		case inBase:
+			// Removed by theirs
+			if !equal(base[i], vo) {
+				conflict(path, base[i], true, vo, true, zero, false)
+				merged = append(merged, vo)
+			}
+		case inTheirs:
+			// Added by both sides
+			if !equal(vo, theirs[j]) {
+				conflict(path, zero, false, vo, true, theirs[j], true)
+			}
+			merged = append(merged, vo)
+		default:
+			merged = append(merged, vo)
+		}
+	}
+	for _, vt := range theirs {
+		k := key(vt)
+		if _, inOurs := indexOurs[k]; inOurs {
+			continue
+		}
+		path := "[" + name + "=" + k + "]"
+		if i, inBase := indexBase[k]; inBase {
+			// Removed by ours
+			if !equal(base[i], vt) {
+				conflict(path, base[i], true, zero, false, vt, true)
+			}
+			continue
+		}
+		merged = append(merged, vt)
+	}
+	return merged, conflicts
+}
+
+// Merge3Array merges the elements of arrays of a three-way merge into merged,
+// with merge, or with Merge3Value if it is nil, and returns their conflicts.
+// The arrays are passed as slices of the same length.
+func Merge3Array[T any](merged, base, ours, theirs []T, merge func(base, ours, theirs T) (T, []Conflict), equal func(x, y T) bool) []Conflict {
+	var conflicts []Conflict
+	for i := range merged {
+		var nested []Conflict
+		merged[i], nested = merge3Element(base[i], ours[i], theirs[i], merge, equal)
+		conflicts = AppendConflicts(conflicts, "["+strconv.Itoa(i)+"]", nested)
+	}
+	return conflicts
+}
+
+// Merge3Map merges the maps of a three-way merge. Maps changed on one side
+// only take the map of that side. Otherwise, entries found on all sides are
+// merged with merge, or with Merge3Value if it is nil, and the others are
+// added, removed or changed as values changed as a whole are: an entry
+// removed on one side and changed on the other one is a conflict.
+func Merge3Map[M ~map[K]V, K comparable, V any](base, ours, theirs M, merge func(base, ours, theirs V) (V, []Conflict), equal func(x, y V) bool) (M, []Conflict) {
+	switch {
+	case maps.EqualFunc(base, ours, equal):
+		return theirs, nil
+	case maps.EqualFunc(base, theirs, equal), maps.EqualFunc(ours, theirs, equal):
+		return ours, nil
+	}
+	// Keys are sorted for conflicts to be reported in a stable order
+	keys := map[string]K{}
+	for _, m := range []M{base, ours, theirs} {
+		for k := range m {
+			keys[fmt.Sprintf("[%v]", k)] = k
+		}
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		sortedKeys = append(sortedKeys, key)
+	}
+	sort.Strings(sortedKeys)
+
+	merged := make(M, len(ours))
+	var conflicts []Conflict
+	for _, key := range sortedKeys {
+		k := keys[key]
+		vb, inBase := base[k]
+		vo, inOurs := ours[k]
+		vt, inTheirs := theirs[k]
+		same := func(x V, inX bool, y V, inY bool) bool {
+			return inX == inY && (!inX || equal(x, y))
+		}
+		switch {
+		case inBase && inOurs && inTheirs:
+			var nested []Conflict
+			merged[k], nested = merge3Element(vb, vo, vt, merge, equal)
+			conflicts = AppendConflicts(conflicts, key, nested)
+			continue
+		case same(vb, inBase, vo, inOurs):
+			if inTheirs {
+				merged[k] = vt
+			}
+			continue
+		case same(vb, inBase, vt, inTheirs), same(vo, inOurs, vt, inTheirs):
+		default:
+			conflicts = append(conflicts, Conflict{
+				Path:   key,
+				Base:   entry(vb, inBase),
+				Ours:   entry(vo, inOurs),
+				Theirs: entry(vt, inTheirs),
+			})
+		}
+		if inOurs {
+			merged[k] = vo
+		}
+	}
+	return merged, conflicts
+}
+
+// Merge3Pointer merges the pointers of a three-way merge. Pointers changed on
+// one side only take the pointer of that side. Otherwise, pointed values
+// found on all sides are merged into a new value with merge, or with
+// Merge3Value if it is nil, and pointers set or reset on both sides are a
+// conflict.
+func Merge3Pointer[P ~*T, T any](base, ours, theirs P, merge func(base, ours, theirs T) (T, []Conflict), equal func(x, y T) bool) (P, []Conflict) {
+	same := func(x, y P) bool {
+		return x == nil && y == nil || x != nil && y != nil && equal(*x, *y)
+	}
+	switch {
+	case same(base, ours):
+		return theirs, nil
+	case same(base, theirs), same(ours, theirs):
+		return ours, nil
+	case base == nil || ours == nil || theirs == nil:
+		return ours, []Conflict{{Base: base, Ours: ours, Theirs: theirs}}
+	}
+	merged, nested := merge3Element(*base, *ours, *theirs, merge, equal)
+	return P(&merged), AppendConflicts(nil, "", nested)
+}
+
+// merge3Element merges the elements of a three-way merge with merge, or with
+// Merge3Value if it is nil.
+func merge3Element[T any](base, ours, theirs T, merge func(base, ours, theirs T) (T, []Conflict), equal func(x, y T) bool) (T, []Conflict) {
+	if merge == nil {
+		return Merge3Value(base, ours, theirs, equal)
+	}
+	return merge(base, ours, theirs)
+}
+
+// entry returns the value of a map entry, or nil if it is missing.
+func entry[V any](v V, found bool) interface{} {
+	if !found {
+		return nil
+	}
+	return v
+}
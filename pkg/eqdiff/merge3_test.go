@@ -0,0 +1,279 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+func equalInts(x, y int) bool { return x == y }
+
+func TestMerge3Value(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs int
+		want               int
+		wantConflict       bool
+	}{
+		{name: "unchanged", base: 1, ours: 1, theirs: 1, want: 1},
+		{name: "changed by ours", base: 1, ours: 2, theirs: 1, want: 2},
+		{name: "changed by theirs", base: 1, ours: 1, theirs: 3, want: 3},
+		{name: "changed alike", base: 1, ours: 2, theirs: 2, want: 2},
+		{name: "changed differently", base: 1, ours: 2, theirs: 3, want: 2, wantConflict: true},
+	}
+	for _, test := range tests {
+		got, conflicts := eqdiff.Merge3Value(test.base, test.ours, test.theirs, equalInts)
+		if got != test.want {
+			t.Errorf("%s: Merge3Value() = %d, want %d", test.name, got, test.want)
+		}
+		want := []eqdiff.Conflict(nil)
+		if test.wantConflict {
+			want = []eqdiff.Conflict{{Base: test.base, Ours: test.ours, Theirs: test.theirs}}
+		}
+		if !reflect.DeepEqual(conflicts, want) {
+			t.Errorf("%s: conflicts = %+v, want %+v", test.name, conflicts, want)
+		}
+	}
+}
+
+func TestMerge3Slice(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs []int
+		want               []int
+		wantConflicts      []eqdiff.Conflict
+	}{
+		{
+			name: "changed by theirs",
+			base: []int{1, 2}, ours: []int{1, 2}, theirs: []int{1, 2, 3},
+			want: []int{1, 2, 3},
+		},
+		{
+			name: "elements changed on both sides",
+			base: []int{1, 2, 3}, ours: []int{4, 2, 3}, theirs: []int{1, 2, 5},
+			want: []int{4, 2, 5},
+		},
+		{
+			name: "element changed differently",
+			base: []int{1, 2}, ours: []int{3, 2}, theirs: []int{4, 2},
+			want:          []int{3, 2},
+			wantConflicts: []eqdiff.Conflict{{Path: "[0]", Base: 1, Ours: 3, Theirs: 4}},
+		},
+		{
+			// Lengths changed on both sides cannot be merged by index
+			name: "lengths changed",
+			base: []int{1}, ours: []int{1, 2}, theirs: []int{},
+			want:          []int{1, 2},
+			wantConflicts: []eqdiff.Conflict{{Base: []int{1}, Ours: []int{1, 2}, Theirs: []int{}}},
+		},
+	}
+	for _, test := range tests {
+		got, conflicts := eqdiff.Merge3Slice(test.base, test.ours, test.theirs, nil, equalInts)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Merge3Slice() = %v, want %v", test.name, got, test.want)
+		}
+		if !reflect.DeepEqual(conflicts, test.wantConflicts) {
+			t.Errorf("%s: conflicts = %+v, want %+v", test.name, conflicts, test.wantConflicts)
+		}
+	}
+}
+
+func TestMerge3KeyedSlice(t *testing.T) {
+	type server struct {
+		Name string
+		Port int
+	}
+	equal := func(x, y server) bool { return x == y }
+	key := func(v server) string { return v.Name }
+	mergeServer := func(base, ours, theirs server) (server, []eqdiff.Conflict) {
+		port, conflicts := eqdiff.Merge3Value(base.Port, ours.Port, theirs.Port, equalInts)
+		return server{Name: ours.Name, Port: port}, eqdiff.AppendConflicts(nil, "Port", conflicts)
+	}
+	a, b, c := server{"a", 1}, server{"b", 2}, server{"c", 3}
+	tests := []struct {
+		name               string
+		base, ours, theirs []server
+		want               []server
+		wantConflicts      []eqdiff.Conflict
+	}{
+		{
+			name: "reordered by ours, changed by theirs",
+			base: []server{a, b}, ours: []server{b, a}, theirs: []server{a, {"b", 4}},
+			want: []server{{"b", 4}, a},
+		},
+		{
+			name: "added on both sides",
+			base: []server{a}, ours: []server{a, b}, theirs: []server{c, a},
+			want: []server{a, b, c},
+		},
+		{
+			name: "removed by theirs",
+			base: []server{a, b}, ours: []server{a, b, c}, theirs: []server{a},
+			want: []server{a, c},
+		},
+		{
+			name: "removed by theirs, changed by ours",
+			base: []server{a, b}, ours: []server{a, {"b", 4}, c}, theirs: []server{a},
+			want: []server{a, {"b", 4}, c},
+			wantConflicts: []eqdiff.Conflict{
+				{Path: "[Name=b]", Base: b, Ours: server{"b", 4}, Theirs: nil},
+			},
+		},
+		{
+			name: "removed by ours, changed by theirs",
+			base: []server{a, b}, ours: []server{a, c}, theirs: []server{a, {"b", 4}},
+			want: []server{a, c},
+			wantConflicts: []eqdiff.Conflict{
+				{Path: "[Name=b]", Base: b, Ours: nil, Theirs: server{"b", 4}},
+			},
+		},
+		{
+			name: "added differently on both sides",
+			base: []server{a}, ours: []server{a, b}, theirs: []server{a, {"b", 4}},
+			want: []server{a, b},
+			wantConflicts: []eqdiff.Conflict{
+				{Path: "[Name=b]", Base: nil, Ours: b, Theirs: server{"b", 4}},
+			},
+		},
+		{
+			name: "changed differently",
+			base: []server{a, b}, ours: []server{a, {"b", 4}}, theirs: []server{{"b", 5}, c},
+			want: []server{{"b", 4}, c},
+			wantConflicts: []eqdiff.Conflict{
+				{Path: "[Name=b].Port", Base: 2, Ours: 4, Theirs: 5},
+			},
+		},
+		{
+			// Elements with duplicate keys cannot be matched
+			name: "duplicate keys",
+			base: []server{a}, ours: []server{a, {"a", 4}}, theirs: []server{b},
+			want: []server{a, {"a", 4}},
+			wantConflicts: []eqdiff.Conflict{
+				{Base: []server{a}, Ours: []server{a, {"a", 4}}, Theirs: []server{b}},
+			},
+		},
+	}
+	for _, test := range tests {
+		got, conflicts := eqdiff.Merge3KeyedSlice(test.base, test.ours, test.theirs, mergeServer, equal, "Name", key)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Merge3KeyedSlice() = %v, want %v", test.name, got, test.want)
+		}
+		if !reflect.DeepEqual(conflicts, test.wantConflicts) {
+			t.Errorf("%s: conflicts = %+v, want %+v", test.name, conflicts, test.wantConflicts)
+		}
+	}
+}
+
+func TestMerge3Map(t *testing.T) {
+	tests := []struct {
+		name               string
+		base, ours, theirs map[string]int
+		want               map[string]int
+		wantConflicts      []eqdiff.Conflict
+	}{
+		{
+			name: "changed on both sides",
+			base: map[string]int{"a": 1, "b": 2}, ours: map[string]int{"a": 3, "b": 2},
+			theirs: map[string]int{"a": 1, "c": 4},
+			want:   map[string]int{"a": 3, "c": 4},
+		},
+		{
+			name: "removed alike",
+			base: map[string]int{"a": 1, "b": 2}, ours: map[string]int{"a": 3},
+			theirs: map[string]int{"a": 1},
+			want:   map[string]int{"a": 3},
+		},
+		{
+			name: "removed by ours, changed by theirs",
+			base: map[string]int{"a": 1, "b": 2}, ours: map[string]int{"b": 2, "c": 3},
+			theirs: map[string]int{"a": 4, "b": 2},
+			want:   map[string]int{"b": 2, "c": 3},
+			wantConflicts: []eqdiff.Conflict{
+				{Path: "[a]", Base: 1, Ours: nil, Theirs: 4},
+			},
+		},
+		{
+			name: "added differently, changed differently",
+			base: map[string]int{"a": 1}, ours: map[string]int{"a": 2, "b": 3},
+			theirs: map[string]int{"a": 4, "b": 5},
+			want:   map[string]int{"a": 2, "b": 3},
+			wantConflicts: []eqdiff.Conflict{
+				{Path: "[a]", Base: 1, Ours: 2, Theirs: 4},
+				{Path: "[b]", Base: nil, Ours: 3, Theirs: 5},
+			},
+		},
+	}
+	for _, test := range tests {
+		got, conflicts := eqdiff.Merge3Map(test.base, test.ours, test.theirs, nil, equalInts)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Merge3Map() = %v, want %v", test.name, got, test.want)
+		}
+		if !reflect.DeepEqual(conflicts, test.wantConflicts) {
+			t.Errorf("%s: conflicts = %+v, want %+v", test.name, conflicts, test.wantConflicts)
+		}
+	}
+}
+
+func TestMerge3Pointer(t *testing.T) {
+	ptr := func(v int) *int { return &v }
+	tests := []struct {
+		name               string
+		base, ours, theirs *int
+		want               *int
+		wantConflict       bool
+	}{
+		{name: "set by theirs", base: nil, ours: nil, theirs: ptr(1), want: ptr(1)},
+		{name: "reset by ours", base: ptr(1), ours: nil, theirs: ptr(1), want: nil},
+		{name: "set alike", base: nil, ours: ptr(1), theirs: ptr(1), want: ptr(1)},
+		{name: "reset by ours, changed by theirs", base: ptr(1), ours: nil, theirs: ptr(2), want: nil, wantConflict: true},
+		{name: "changed differently", base: ptr(1), ours: ptr(2), theirs: ptr(3), want: ptr(2), wantConflict: true},
+	}
+	for _, test := range tests {
+		got, conflicts := eqdiff.Merge3Pointer(test.base, test.ours, test.theirs, nil, equalInts)
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: Merge3Pointer() = %v, want %v", test.name, got, test.want)
+		}
+		if (len(conflicts) > 0) != test.wantConflict {
+			t.Errorf("%s: conflicts = %+v", test.name, conflicts)
+		}
+	}
+}
+
+func TestAppendConflicts(t *testing.T) {
+	nested := []eqdiff.Conflict{{Path: ""}, {Path: "[1]"}, {Path: ".Port"}, {Path: "Port"}}
+	var got []string
+	for _, conflict := range eqdiff.AppendConflicts(nil, "Servers", nested) {
+		got = append(got, conflict.Path)
+	}
+	want := []string{"Servers", "Servers[1]", "Servers.Port", "Servers.Port"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendConflicts() paths = %q, want %q", got, want)
+	}
+}
+
+func TestMerge3Array(t *testing.T) {
+	base, ours, theirs := [3]int{1, 2, 3}, [3]int{4, 2, 6}, [3]int{1, 5, 7}
+	var merged [3]int
+	conflicts := eqdiff.Merge3Array(merged[:], base[:], ours[:], theirs[:], nil, equalInts)
+	if want := [3]int{4, 5, 6}; merged != want {
+		t.Errorf("Merge3Array() = %v, want %v", merged, want)
+	}
+	want := []eqdiff.Conflict{{Path: "[2]", Base: 3, Ours: 6, Theirs: 7}}
+	if !reflect.DeepEqual(conflicts, want) {
+		t.Errorf("conflicts = %+v, want %+v", conflicts, want)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import "encoding/json"
+
+// MergePatchMap returns the JSON merge patch (RFC 7396) turning the JSON
+// object of the map x into the one of y, and whether it changes anything. It
+// is called by generated MergePatch methods.
+//
+// Entries missing from y are removed with null values, entries missing from x
+// are added, and entry returns the patch of the entries found in both maps
+// and whether it changes anything. Nil maps are encoded as null, so maps are
+// replaced as a whole if either of them is nil.
+func MergePatchMap[K comparable, V any](x, y map[K]V, entry func(vx, vy V) (interface{}, bool, error)) (interface{}, bool, error) {
+	if x == nil || y == nil {
+		return y, (x == nil) != (y == nil), nil
+	}
+	patch := map[K]interface{}{}
+	for k := range x {
+		if _, found := y[k]; !found {
+			patch[k] = nil
+		}
+	}
+	for k, vy := range y {
+		vx, found := x[k]
+		if !found {
+			patch[k] = vy
+			continue
+		}
+		entryPatch, changed, err := entry(vx, vy)
+		if err != nil {
+			return nil, false, err
+		}
+		if changed {
+			patch[k] = entryPatch
+		}
+	}
+	return patch, len(patch) > 0, nil
+}
+
+// MergePatchEmbedded adds to patch the JSON merge patch (RFC 7396) turning the
+// members of the embedded struct x into the ones of y, as encoding/json
+// flattens embedded structs into the JSON object of their struct. It is called
+// by generated MergePatch methods.
+//
+// Members are removed with null values if y is nil and added if x is nil.
+// Otherwise, mergePatch returns the merge patch of the structs, or the members
+// are replaced as a whole if it is nil.
+func MergePatchEmbedded[T any](patch map[string]interface{}, x, y *T, mergePatch func() ([]byte, error)) error {
+	if x == nil && y == nil {
+		return nil
+	}
+	if x != nil && y != nil && mergePatch != nil {
+		structPatch, err := mergePatch()
+		if err != nil {
+			return err
+		}
+		members := map[string]json.RawMessage{}
+		err = json.Unmarshal(structPatch, &members)
+		if err != nil {
+			return err
+		}
+		for k, v := range members {
+			patch[k] = v
+		}
+		return nil
+	}
+	removed, err := jsonMembers(x)
+	if err != nil {
+		return err
+	}
+	added, err := jsonMembers(y)
+	if err != nil {
+		return err
+	}
+	for k := range removed {
+		if _, found := added[k]; !found {
+			patch[k] = nil
+		}
+	}
+	for k, v := range added {
+		patch[k] = v
+	}
+	return nil
+}
+
+// jsonMembers returns the members of the JSON object of the struct v, or none
+// if v is nil.
+func jsonMembers[T any](v *T) (map[string]json.RawMessage, error) {
+	if v == nil {
+		return nil, nil
+	}
+	object, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	members := map[string]json.RawMessage{}
+	err = json.Unmarshal(object, &members)
+	return members, err
+}
@@ -0,0 +1,80 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+func TestGenerateParity(t *testing.T) {
+	typ := reflect.TypeOf(parityFrontend{})
+	opts := eqdiff.Options{
+		OutputDir:   t.TempDir(),
+		TypedDiff:   true,
+		DiffNameTag: "json",
+		Methods: []string{
+			eqdiff.MethodEqual, eqdiff.MethodDiff, eqdiff.MethodMerge, eqdiff.MethodClone, eqdiff.MethodApplyDiff,
+			eqdiff.MethodMerge3, eqdiff.MethodHash, eqdiff.MethodCompare, eqdiff.MethodMergePatch,
+		},
+	}
+	reflectFiles, err := eqdiff.GenerateFiles([]reflect.Type{typ}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "parity_types_test.go", nil, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	pkg, err := conf.Check(typ.PkgPath(), fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	goTypesFiles, err := eqdiff.GenerateFilesFromGoTypes([]types.Type{pkg.Scope().Lookup(typ.Name()).Type()}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Both front-ends generate the same files
+	sources := map[string]string{}
+	for _, file := range reflectFiles {
+		sources[file.Path] = string(file.Source)
+	}
+	for _, file := range goTypesFiles {
+		src, found := sources[file.Path]
+		switch {
+		case !found:
+			t.Errorf("%s only generated from go/types:\n%s", file.Path, file.Source)
+		case src != string(file.Source):
+			t.Errorf("%s differs:\nreflect:\n%s\ngo/types:\n%s", file.Path, src, file.Source)
+		}
+		delete(sources, file.Path)
+	}
+	for path, src := range sources {
+		t.Errorf("%s only generated from reflect:\n%s", path, src)
+	}
+	if len(reflectFiles) == 0 {
+		t.Error("no file generated")
+	}
+}
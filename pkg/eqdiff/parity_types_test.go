@@ -0,0 +1,41 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import "time"
+
+// The types of this file are generated both from reflect and from go/types by
+// TestGenerateParity, which type-checks this file on its own: it only holds
+// type declarations.
+
+type parityServer struct {
+	Name   string
+	Port   int
+	Weight *int
+	Tags   []string
+}
+
+type parityFrontend struct {
+	Name     string                   `json:"name"`
+	Servers  []parityServer           `json:"servers" gmg:"key=Name"`
+	Backups  []*parityServer          `json:"backups"`
+	Binds    []string                 `json:"binds" gmg:"set"`
+	Rules    []string                 `json:"rules" gmg:"lcs"`
+	Labels   map[string]string        `json:"labels"`
+	ByName   map[string]*parityServer `json:"by_name"`
+	Default  *parityServer            `json:"default"`
+	Timeout  time.Duration            `json:"timeout"`
+	Weights  [2]int                   `json:"weights"`
+	Disabled bool                     `json:"disabled" gmg:"-"`
+}
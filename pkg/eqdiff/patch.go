@@ -0,0 +1,498 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PatchOp is a JSON Patch operation (RFC 6902).
+type PatchOp struct {
+	Op    string      // "add", "remove" or "replace"
+	Path  string      // JSON Pointer (RFC 6901) of the value, e.g. "/servers/0/name"
+	Value interface{} // New value, unused by "remove"
+}
+
+// MarshalJSON encodes the operation as a JSON Patch operation object. The
+// value is always encoded for "add" and "replace", even if it is null, false
+// or zero.
+func (op PatchOp) MarshalJSON() ([]byte, error) {
+	if op.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{op.Op, op.Path})
+	}
+	return json.Marshal(struct {
+		Op    string      `json:"op"`
+		Path  string      `json:"path"`
+		Value interface{} `json:"value"`
+	}{op.Op, op.Path, op.Value})
+}
+
+// JSONPatch converts the changes returned by a typed Diff method into a JSON
+// Patch turning the receiver into the compared object. Diff keys must name
+// fields as their JSON documents do, e.g. with Options.DiffNameTag set to
+// "json". Moved values are removed from their former index and added at their
+// new one.
+//
+// Operations are ordered so that the patch can be applied sequentially:
+// elements are added and removed first, from the outermost slices in, removed
+// from the highest index down, then added from the lowest index up. The other
+// operations follow in the order of the diff.
+// Elements of slices matched by key, e.g. "servers[Name=web1]", have no index
+// in their path and cannot be converted: JSONPatchFor resolves them from the
+// receiver.
+func JSONPatch(changes []Change) ([]PatchOp, error) {
+	ordered, err := orderChanges(changes)
+	if err != nil {
+		return nil, err
+	}
+	patch := make([]PatchOp, 0, len(ordered))
+	for _, change := range ordered {
+		if slices.ContainsFunc(change.tokens, isKeySelector) {
+			return nil, fmt.Errorf("%s: elements of slices matched by key have no JSON Pointer, use JSONPatchFor", change.Path)
+		}
+		patch = append(patch, patchOp(change.Change, change.tokens))
+	}
+	return patch, nil
+}
+
+// JSONPatchFor converts the changes returned by the typed Diff method of
+// receiver into a JSON Patch, as JSONPatch does, resolving the elements of
+// slices matched by key into their indexes in the JSON document of receiver,
+// as they are when the operation is applied. Elements are selected by the
+// member of their JSON object named as the key field in diff keys, or, as
+// encoding/json does, by the member whose name matches it regardless of case.
+// Elements added by key are appended to their slice, with the "-" index.
+func JSONPatchFor(receiver interface{}, changes []Change) ([]PatchOp, error) {
+	doc, err := jsonDocument(receiver)
+	if err != nil {
+		return nil, err
+	}
+	ordered, err := orderChanges(changes)
+	if err != nil {
+		return nil, err
+	}
+	patch := make([]PatchOp, 0, len(ordered))
+	for _, change := range ordered {
+		op := patchOp(change.Change, change.tokens)
+		// Members left out of the document, such as the members of nil
+		// embedded pointers, are added rather than replaced
+		if op.Op == "replace" && missingMember(doc, change.tokens) {
+			op.Op = "add"
+		}
+		value, err := jsonDocument(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", change.Path, err)
+		}
+		// The document is patched as the operations are applied, for the
+		// indexes of the elements to follow the ones added and removed
+		patched, tokens, err := patchDocument(doc, op.Op, change.tokens, value)
+		switch {
+		case err == nil:
+			doc, op.Path = patched, pointer(tokens)
+		case slices.ContainsFunc(change.tokens, isKeySelector):
+			return nil, fmt.Errorf("%s: %w", change.Path, err)
+		}
+		// Other operations may target values left out of the document, such
+		// as empty values of fields with the omitempty option
+		patch = append(patch, op)
+	}
+	return patch, nil
+}
+
+// patchOp returns the JSON Patch operation of a change whose path has tokens.
+func patchOp(change Change, tokens []string) PatchOp {
+	op := "replace"
+	switch change.Op {
+	case Added:
+		op = "add"
+	case Removed:
+		op = "remove"
+	}
+	var value interface{}
+	if op != "remove" {
+		value = change.New
+	}
+	return PatchOp{Op: op, Path: pointer(tokens), Value: value}
+}
+
+// jsonDocument returns the JSON document of v, as decoded into an
+// interface{} value with numbers kept as json.Number.
+func jsonDocument(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var doc interface{}
+	err = decoder.Decode(&doc)
+	return doc, err
+}
+
+// patchDocument applies the JSON Patch operation op setting value at the path
+// tokens to the JSON document doc, and returns the patched document and the
+// tokens of the path, whose key selectors are resolved into indexes. Documents
+// are only changed if no error is returned.
+func patchDocument(doc interface{}, op string, tokens []string, value interface{}) (interface{}, []string, error) {
+	if len(tokens) == 0 {
+		if op == "remove" {
+			return nil, nil, nil
+		}
+		return value, nil, nil
+	}
+	token, last := tokens[0], len(tokens) == 1
+	switch container := doc.(type) {
+	case map[string]interface{}:
+		if last {
+			if op == "remove" {
+				delete(container, token)
+			} else {
+				container[token] = value
+			}
+			return container, tokens, nil
+		}
+		member, found := container[token]
+		if !found {
+			return nil, nil, fmt.Errorf("no member %q", token)
+		}
+		member, rest, err := patchDocument(member, op, tokens[1:], value)
+		if err != nil {
+			return nil, nil, err
+		}
+		container[token] = member
+		return container, append([]string{token}, rest...), nil
+	case []interface{}:
+		if last && op == "add" && isKeySelector(token) {
+			return append(container, value), []string{"-"}, nil
+		}
+		i, err := elementIndex(container, token, last && op == "add")
+		if err != nil {
+			return nil, nil, err
+		}
+		index := strconv.Itoa(i)
+		switch {
+		case last && op == "add":
+			return slices.Insert(container, i, value), []string{index}, nil
+		case last && op == "remove":
+			return slices.Delete(container, i, i+1), []string{index}, nil
+		case last:
+			container[i] = value
+			return container, []string{index}, nil
+		}
+		element, rest, err := patchDocument(container[i], op, tokens[1:], value)
+		if err != nil {
+			return nil, nil, err
+		}
+		container[i] = element
+		return container, append([]string{index}, rest...), nil
+	}
+	return nil, nil, fmt.Errorf("no member or element %q in %T value", token, doc)
+}
+
+// missingMember reports whether the value at the path tokens of the JSON
+// document doc is a member missing from its object.
+func missingMember(doc interface{}, tokens []string) bool {
+	for i, token := range tokens {
+		switch container := doc.(type) {
+		case map[string]interface{}:
+			member, found := container[token]
+			if !found {
+				return i == len(tokens)-1
+			}
+			doc = member
+		case []interface{}:
+			index, err := elementIndex(container, token, false)
+			if err != nil {
+				return false
+			}
+			doc = container[index]
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// elementIndex returns the index of the element of a JSON array selected by
+// token, as an index or as a key selector. Indexes may equal the length of the
+// array if adding is true.
+func elementIndex(elements []interface{}, token string, adding bool) (int, error) {
+	if !isKeySelector(token) {
+		i, err := strconv.Atoi(token)
+		switch {
+		case err != nil || !isIndex(token) || i < 0:
+			return 0, fmt.Errorf("invalid index %q", token)
+		case i > len(elements) || (i == len(elements) && !adding):
+			return 0, fmt.Errorf("index %d out of range, length %d", i, len(elements))
+		}
+		return i, nil
+	}
+	name, value, _ := strings.Cut(token, "=")
+	for i, element := range elements {
+		members, ok := element.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		member, found := members[name]
+		if !found {
+			for memberName, memberValue := range members {
+				if strings.EqualFold(memberName, name) {
+					member, found = memberValue, true
+					break
+				}
+			}
+		}
+		if found && fmt.Sprint(member) == value {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no element with key %s", token)
+}
+
+// JSONPatchFromMap converts the result of a Diff method returning
+// map[string][]interface{} into a JSON Patch, as JSONPatch does. As such
+// results hold no operation, values whose old value is nil are added, values
+// whose new value is nil are removed and others are replaced. Map entries
+// missing on one side are reported with the zero value of their type, which
+// is only nil for pointers, interfaces, maps and slices, and elements of
+// slices diffed with the set, lcs or moves options removed and added at the
+// same index are reported as modified: use typed diffs for exact patches.
+func JSONPatchFromMap(diff map[string][]interface{}) ([]PatchOp, error) {
+	changes, err := mapChanges(diff)
+	if err != nil {
+		return nil, err
+	}
+	return JSONPatch(changes)
+}
+
+// mapChanges returns the changes of the result of a Diff method returning
+// map[string][]interface{}, sorted by path. Values whose old value is nil are
+// added, values whose new value is nil are removed and others are modified.
+func mapChanges(diff map[string][]interface{}) ([]Change, error) {
+	keys := make([]string, 0, len(diff))
+	for key := range diff {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	changes := make([]Change, 0, len(keys))
+	for _, key := range keys {
+		values := diff[key]
+		if len(values) != 2 {
+			return nil, fmt.Errorf("%s: expected old and new values, got %d values", key, len(values))
+		}
+		op := Modified
+		switch {
+		case isNil(values[0]) && !isNil(values[1]):
+			op = Added
+		case !isNil(values[0]) && isNil(values[1]):
+			op = Removed
+		}
+		changes = append(changes, Change{Path: key, Op: op, Old: values[0], New: values[1]})
+	}
+	return changes, nil
+}
+
+// isNil reports whether v is nil or holds a nil pointer, interface, map or
+// slice.
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch value := reflect.ValueOf(v); value.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice:
+		return value.IsNil()
+	}
+	return false
+}
+
+// JSONPointer converts a diff key, e.g. "Servers[0].Name", into a JSON Pointer
+// (RFC 6901), e.g. "/Servers/0/Name". Fields and map keys are escaped, "~" as
+// "~0" and "/" as "~1".
+func JSONPointer(path string) (string, error) {
+	tokens, err := pathTokens(path)
+	if err != nil {
+		return "", err
+	}
+	if slices.ContainsFunc(tokens, isKeySelector) {
+		return "", fmt.Errorf("%s: elements of slices matched by key have no JSON Pointer", path)
+	}
+	return pointer(tokens), nil
+}
+
+// pointer returns the JSON Pointer of the tokens of a diff key.
+func pointer(tokens []string) string {
+	var sb strings.Builder
+	for _, token := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(token))
+	}
+	return sb.String()
+}
+
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// pathTokens splits a diff key into the names of its fields and the keys or
+// indexes of its elements. A map key ends with the first "]" followed by "."
+// or "[" or ending the diff key.
+func pathTokens(path string) ([]string, error) {
+	var tokens []string
+	for rest := path; rest != ""; {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+		case '[':
+			end := closingBracket(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("%s: unterminated element key", path)
+			}
+			tokens = append(tokens, rest[1:end])
+			rest = rest[end+1:]
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			tokens = append(tokens, rest[:end])
+			rest = rest[end:]
+		}
+	}
+	return tokens, nil
+}
+
+// closingBracket returns the index of the "]" closing the element key opened
+// at the start of s, or -1.
+func closingBracket(s string) int {
+	for i := 1; i < len(s); i++ {
+		if s[i] == ']' && (i+1 == len(s) || s[i+1] == '.' || s[i+1] == '[') {
+			return i
+		}
+	}
+	return -1
+}
+
+// isKeySelector reports whether an element key selects a slice element by
+// key, as in "[Name=web1]", rather than being a map key or an index.
+func isKeySelector(token string) bool {
+	name, _, found := strings.Cut(token, "=")
+	if !found || name == "" {
+		return false
+	}
+	for i, r := range name {
+		if !(r == '_' || 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || i > 0 && '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// orderedChange is a change along with the tokens of its path.
+type orderedChange struct {
+	Change
+	tokens []string
+}
+
+// orderChanges returns the changes of a typed diff in an order they can be
+// applied in sequentially. Elements are added and removed first, from the
+// outermost slices in: elements are removed from the highest index down, then
+// added from the lowest index up. The other changes follow in the order of
+// the diff. Moved values are removed from their former index and added at
+// their new one.
+func orderChanges(changes []Change) ([]orderedChange, error) {
+	var elements, others []orderedChange
+	order := func(change Change) error {
+		tokens, err := pathTokens(change.Path)
+		if err != nil {
+			return err
+		}
+		o := orderedChange{Change: change, tokens: tokens}
+		// Adding or removing elements shifts the indexes of the following ones
+		isElement := len(tokens) > 0 && isIndex(tokens[len(tokens)-1])
+		if isElement && (change.Op == Removed || change.Op == Added) {
+			elements = append(elements, o)
+		} else {
+			others = append(others, o)
+		}
+		return nil
+	}
+	for _, change := range changes {
+		switch change.Op {
+		case Added, Removed, Modified:
+			err := order(change)
+			if err != nil {
+				return nil, err
+			}
+		case Moved:
+			err := order(Change{Path: change.From, Op: Removed, Old: change.Old})
+			if err != nil {
+				return nil, err
+			}
+			err = order(Change{Path: change.Path, Op: Added, New: change.New})
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%s: unknown change operation %q", change.Path, change.Op)
+		}
+	}
+	// Paths of the elements of nested slices hold the indexes of the slices
+	// holding them once their elements are added and removed
+	sort.SliceStable(elements, func(i, j int) bool {
+		x, y := elements[i], elements[j]
+		switch {
+		case len(x.tokens) != len(y.tokens):
+			return len(x.tokens) < len(y.tokens)
+		case x.Op != y.Op:
+			return x.Op == Removed
+		case x.Op == Removed:
+			return compareTokens(x.tokens, y.tokens) > 0
+		}
+		return compareTokens(x.tokens, y.tokens) < 0
+	})
+	return append(elements, others...), nil
+}
+
+// isIndex reports whether token is a slice index.
+func isIndex(token string) bool {
+	_, err := strconv.Atoi(token)
+	return err == nil && !strings.HasPrefix(token, "+")
+}
+
+// compareTokens compares paths token by token, indexes numerically.
+func compareTokens(x, y []string) int {
+	for i := 0; i < len(x) && i < len(y); i++ {
+		if x[i] == y[i] {
+			continue
+		}
+		if isIndex(x[i]) && isIndex(y[i]) {
+			ix, _ := strconv.Atoi(x[i])
+			iy, _ := strconv.Atoi(y[i])
+			if ix < iy {
+				return -1
+			}
+			return 1
+		}
+		return strings.Compare(x[i], y[i])
+	}
+	return len(x) - len(y)
+}
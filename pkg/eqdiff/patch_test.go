@@ -0,0 +1,232 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/haproxytech/go-method-gen/pkg/eqdiff"
+)
+
+func TestJSONPointer(t *testing.T) {
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "", want: ""},
+		{path: "name", want: "/name"},
+		{path: "servers[0].name", want: "/servers/0/name"},
+		{path: "matrix[1][2]", want: "/matrix/1/2"},
+		{path: "labels[a/b]", want: "/labels/a~1b"},
+		{path: "labels[a~b]", want: "/labels/a~0b"},
+		{path: "labels[~/]", want: "/labels/~0~1"},
+		{path: "labels[~1]", want: "/labels/~01"},
+		{path: "labels[a.b]", want: "/labels/a.b"},
+		{path: "labels[a]b].c", want: "/labels/a]b/c"},
+		{path: "labels[a=b]", want: "/labels/a=b", wantErr: true},
+		{path: "labels[=b]", want: "/labels/=b"},
+		{path: "labels[a", wantErr: true},
+	}
+	for _, test := range tests {
+		got, err := eqdiff.JSONPointer(test.path)
+		if test.wantErr {
+			if err == nil {
+				t.Errorf("JSONPointer(%q) = %q, want an error", test.path, got)
+			}
+			continue
+		}
+		if err != nil || got != test.want {
+			t.Errorf("JSONPointer(%q) = %q, %v, want %q", test.path, got, err, test.want)
+		}
+	}
+}
+
+func TestJSONPatchOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []eqdiff.Change
+		want    []eqdiff.PatchOp
+	}{
+		{
+			name: "removals from the highest index down",
+			changes: []eqdiff.Change{
+				{Path: "a[0]", Op: eqdiff.Removed, Old: 1},
+				{Path: "a[10]", Op: eqdiff.Removed, Old: 3},
+				{Path: "a[2]", Op: eqdiff.Removed, Old: 2},
+			},
+			want: []eqdiff.PatchOp{
+				{Op: "remove", Path: "/a/10"},
+				{Op: "remove", Path: "/a/2"},
+				{Op: "remove", Path: "/a/0"},
+			},
+		},
+		{
+			name: "additions from the lowest index up, after removals",
+			changes: []eqdiff.Change{
+				{Path: "a[10]", Op: eqdiff.Added, New: 3},
+				{Path: "a[2]", Op: eqdiff.Added, New: 2},
+				{Path: "a[1]", Op: eqdiff.Removed, Old: 1},
+			},
+			want: []eqdiff.PatchOp{
+				{Op: "remove", Path: "/a/1"},
+				{Op: "add", Path: "/a/2", Value: 2},
+				{Op: "add", Path: "/a/10", Value: 3},
+			},
+		},
+		{
+			name: "outer slices first, other changes last in diff order",
+			changes: []eqdiff.Change{
+				{Path: "name", Op: eqdiff.Modified, Old: "x", New: "y"},
+				{Path: "a[1].b[0]", Op: eqdiff.Added, New: 1},
+				{Path: "a[0]", Op: eqdiff.Removed, Old: 0},
+				{Path: "a[1].c", Op: eqdiff.Modified, Old: 1, New: 2},
+			},
+			want: []eqdiff.PatchOp{
+				{Op: "remove", Path: "/a/0"},
+				{Op: "add", Path: "/a/1/b/0", Value: 1},
+				{Op: "replace", Path: "/name", Value: "y"},
+				{Op: "replace", Path: "/a/1/c", Value: 2},
+			},
+		},
+		{
+			name: "moves removed from their former index and added at their new one",
+			changes: []eqdiff.Change{
+				{Path: "a[0]", Op: eqdiff.Moved, Old: "c", New: "c", From: "a[2]"},
+				{Path: "a[3]", Op: eqdiff.Moved, Old: "a", New: "a", From: "a[0]"},
+			},
+			want: []eqdiff.PatchOp{
+				{Op: "remove", Path: "/a/2"},
+				{Op: "remove", Path: "/a/0"},
+				{Op: "add", Path: "/a/0", Value: "c"},
+				{Op: "add", Path: "/a/3", Value: "a"},
+			},
+		},
+		{
+			name: "removed values and map keys",
+			changes: []eqdiff.Change{
+				{Path: "m[a/b]", Op: eqdiff.Removed, Old: 1},
+				{Path: "m[7]", Op: eqdiff.Added, New: nil},
+			},
+			want: []eqdiff.PatchOp{
+				{Op: "add", Path: "/m/7"},
+				{Op: "remove", Path: "/m/a~1b"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := eqdiff.JSONPatch(test.changes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("JSONPatch() = %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestJSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		changes []eqdiff.Change
+	}{
+		{name: "key selector", changes: []eqdiff.Change{{Path: "servers[name=web]", Op: eqdiff.Removed}}},
+		{name: "unknown operation", changes: []eqdiff.Change{{Path: "name", Op: "renamed"}}},
+		{name: "unterminated key", changes: []eqdiff.Change{{Path: "m[a", Op: eqdiff.Modified}}},
+	}
+	for _, test := range tests {
+		if _, err := eqdiff.JSONPatch(test.changes); err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+	}
+}
+
+func TestJSONPatchFor(t *testing.T) {
+	type server struct {
+		Name string `json:"name"`
+		Port int    `json:"port"`
+	}
+	type frontend struct {
+		Servers []server `json:"servers"`
+	}
+	receiver := frontend{Servers: []server{{"a", 1}, {"b", 2}, {"c", 3}}}
+	// Elements matched by key are changed in the order of the diff
+	changes := []eqdiff.Change{
+		{Path: "servers[Name=a]", Op: eqdiff.Removed, Old: server{"a", 1}},
+		{Path: "servers[Name=c].port", Op: eqdiff.Modified, Old: 3, New: 4},
+		{Path: "servers[Name=d]", Op: eqdiff.Added, New: server{"d", 5}},
+	}
+	want := []eqdiff.PatchOp{
+		{Op: "remove", Path: "/servers/0"},
+		// Indexes follow the elements removed before
+		{Op: "replace", Path: "/servers/1/port", Value: 4},
+		{Op: "add", Path: "/servers/-", Value: server{"d", 5}},
+	}
+	got, err := eqdiff.JSONPatchFor(receiver, changes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSONPatchFor() = %+v, want %+v", got, want)
+	}
+
+	_, err = eqdiff.JSONPatchFor(receiver, []eqdiff.Change{{Path: "servers[Name=x].port", Op: eqdiff.Modified, New: 1}})
+	if err == nil {
+		t.Error("expected an error for a missing key")
+	}
+}
+
+func TestJSONPatchFromMap(t *testing.T) {
+	diff := map[string][]interface{}{
+		"name":     {"x", "y"},
+		"tags[1]":  {nil, "b"},
+		"ports[0]": {80, nil},
+	}
+	want := []eqdiff.PatchOp{
+		{Op: "remove", Path: "/ports/0"},
+		{Op: "add", Path: "/tags/1", Value: "b"},
+		{Op: "replace", Path: "/name", Value: "y"},
+	}
+	got, err := eqdiff.JSONPatchFromMap(diff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSONPatchFromMap() = %+v, want %+v", got, want)
+	}
+	if _, err := eqdiff.JSONPatchFromMap(map[string][]interface{}{"name": {"x"}}); err == nil {
+		t.Error("expected an error for a missing value")
+	}
+}
+
+func TestPatchOpMarshalJSON(t *testing.T) {
+	tests := []struct {
+		op   eqdiff.PatchOp
+		want string
+	}{
+		{op: eqdiff.PatchOp{Op: "remove", Path: "/a", Value: 1}, want: `{"op":"remove","path":"/a"}`},
+		{op: eqdiff.PatchOp{Op: "add", Path: "/a"}, want: `{"op":"add","path":"/a","value":null}`},
+		{op: eqdiff.PatchOp{Op: "replace", Path: "/a", Value: false}, want: `{"op":"replace","path":"/a","value":false}`},
+	}
+	for _, test := range tests {
+		got, err := json.Marshal(test.op)
+		if err != nil || string(got) != test.want {
+			t.Errorf("Marshal(%+v) = %s, %v, want %s", test.op, got, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2025 HAProxy Technologies LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package eqdiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/haproxytech/go-method-gen/internal/common"
+	"github.com/haproxytech/go-method-gen/internal/data"
+)
+
+// SkippedField is a field left out of the generated functions because its
+// values cannot be compared, diffed, merged nor cloned.
+type SkippedField struct {
+	Path   string `json:"path"`   // Field path, e.g. "example.com/models.Backend.OnChange"
+	Reason string `json:"reason"` // Why the field is skipped, e.g. "func values are not supported"
+}
+
+// Report lists the fields left out of the generated functions. Fields ignored
+// on purpose, with overrides or the "-" struct tag, are not reported.
+type Report struct {
+	Skipped []SkippedField `json:"skipped"`
+}
+
+// Summary returns a human readable summary of the report, one line per
+// skipped field, or an empty string if no field is skipped.
+func (r Report) Summary() string {
+	if len(r.Skipped) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%d field(s) skipped by the generated functions:\n", len(r.Skipped))
+	for _, field := range r.Skipped {
+		fmt.Fprintf(&sb, "  - %s: %s\n", field.Path, field.Reason)
+	}
+	return sb.String()
+}
+
+// WriteReport writes the report to file as JSON.
+func WriteReport(file string, report Report) error {
+	if report.Skipped == nil {
+		report.Skipped = []SkippedField{}
+	}
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(contents, '\n'), 0o644)
+}
+
+// SkippedFieldsError is returned with Options.Strict when fields would be
+// skipped by the generated functions. No file is generated.
+type SkippedFieldsError struct {
+	Report Report
+}
+
+func (e *SkippedFieldsError) Error() string {
+	fields := make([]string, len(e.Report.Skipped))
+	for i, field := range e.Report.Skipped {
+		fields[i] = field.Path + " (" + field.Reason + ")"
+	}
+	return "fields would be skipped by the generated functions: " + strings.Join(fields, ", ")
+}
+
+// reportSkippedFields adds to report the fields of the tree rooted at node
+// which are skipped by the generated functions. Fields of types shared by
+// several roots are reported once.
+func reportSkippedFields(report *Report, node *data.TypeNode, overrides map[string]common.OverrideFuncs,
+	reported map[string]struct{},
+) {
+	if node == nil {
+		return
+	}
+	for _, field := range node.Fields {
+		// Ignored fields are skipped on purpose
+		fieldType := field.Type
+		if fieldType == "" {
+			if pkgAndType := strings.SplitN(field.PackagedType, ".", 2); len(pkgAndType) > 1 {
+				fieldType = pkgAndType[0]
+			}
+		}
+		if override, found := common.LookupOverride(overrides, field, field.PkgPath+"."+fieldType); found && override.Ignore {
+			continue
+		}
+		if !field.Err {
+			reportSkippedFields(report, field, overrides, reported)
+			continue
+		}
+		if _, found := reported[field.FieldPath]; found {
+			continue
+		}
+		reported[field.FieldPath] = struct{}{}
+		report.Skipped = append(report.Skipped, SkippedField{Path: field.FieldPath, Reason: skipReason(field)})
+	}
+	reportSkippedFields(report, node.SubNode, overrides, reported)
+}
+
+// skipReason returns why the values of a node are not supported.
+func skipReason(node *data.TypeNode) string {
+	switch node.Kind {
+	case data.Func:
+		return "func values are not supported"
+	case data.Struct:
+		return "struct " + node.PackagedType + " has no supported fields"
+	case data.Pointer:
+		if node.SubNode != nil {
+			return skipReason(node.SubNode)
+		}
+	case data.Array, data.Slice, data.Map:
+		if node.SubNode != nil {
+			return "elements: " + skipReason(node.SubNode)
+		}
+	}
+	return node.PackagedType + " values are not supported"
+}